@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The ocm-fake-server command runs the in-memory fake of the clusters_mgmt API, so that the
+// provider and the modules that use it can be tried without an OCM account or network access.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/fake"
+)
+
+func main() {
+	var (
+		listen         string
+		readyAfter     time.Duration
+		uninstallAfter time.Duration
+		versions       string
+	)
+	flag.StringVar(&listen, "listen", "localhost:8000", "address where the server listens")
+	flag.DurationVar(&readyAfter, "ready-after", 30*time.Second,
+		"time that clusters stay in the 'installing' state")
+	flag.DurationVar(&uninstallAfter, "uninstall-after", 10*time.Second,
+		"time that clusters stay in the 'uninstalling' state")
+	flag.StringVar(&versions, "versions", strings.Join(fake.DefaultVersions, ","),
+		"comma separated list of OpenShift versions, the last one is the default")
+	flag.Parse()
+
+	server := fake.NewServer().
+		ReadyAfter(readyAfter).
+		UninstallAfter(uninstallAfter).
+		Versions(strings.Split(versions, ",")...).
+		Build()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL.RequestURI())
+		server.ServeHTTP(w, r)
+	})
+
+	fmt.Printf(`Use the following provider configuration:
+
+provider "rhcs" {
+  url   = "http://%s"
+  token = "%s"
+}
+
+`, listen, fake.Token())
+	log.Fatal(http.ListenAndServe(listen, handler))
+}
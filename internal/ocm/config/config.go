@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config knows how to load the configuration file written by the `ocm login` and
+// `rosa login` commands, so that the provider can reuse an existing login.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvVar is the name of the environment variable that the OCM and ROSA command line tools use to
+// override the location of the configuration file.
+const EnvVar = "OCM_CONFIG"
+
+// Config is the subset of the OCM command line configuration file that is relevant for the
+// provider.
+type Config struct {
+	AccessToken  string   `json:"access_token,omitempty"`
+	ClientID     string   `json:"client_id,omitempty"`
+	ClientSecret string   `json:"client_secret,omitempty"`
+	Insecure     bool     `json:"insecure,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	TokenURL     string   `json:"token_url,omitempty"`
+	URL          string   `json:"url,omitempty"`
+}
+
+// Location returns the path of the OCM configuration file. The `OCM_CONFIG` environment variable
+// takes precedence, then `ocm/ocm.json` inside the user configuration directory and finally the
+// legacy `~/.ocm.json` file, which is only returned when it exists.
+func Location() (string, error) {
+	if path, ok := os.LookupEnv(EnvVar); ok && path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("can't determine user configuration directory: %v", err)
+	}
+	path := filepath.Join(configDir, "ocm", "ocm.json")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path, nil
+	}
+	legacyPath := filepath.Join(homeDir, ".ocm.json")
+	if _, err := os.Stat(legacyPath); err == nil {
+		return legacyPath, nil
+	}
+	return path, nil
+}
+
+// Load reads the OCM configuration file from the default location. It returns nil without error
+// when the file doesn't exist.
+func Load() (*Config, error) {
+	path, err := Location()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the OCM configuration file from the given path. It returns nil without error
+// when the file doesn't exist.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't read OCM configuration file '%s': %v", path, err)
+	}
+	config := &Config{}
+	if len(data) == 0 {
+		return config, nil
+	}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse OCM configuration file '%s': %v", path, err)
+	}
+	return config, nil
+}
+
+// Tokens returns the access and refresh tokens stored in the configuration, skipping the empty
+// ones.
+func (c *Config) Tokens() []string {
+	tokens := []string{}
+	if c.AccessToken != "" {
+		tokens = append(tokens, c.AccessToken)
+	}
+	if c.RefreshToken != "" {
+		tokens = append(tokens, c.RefreshToken)
+	}
+	return tokens
+}
+
+// HasCredentials returns true if the configuration contains either tokens or client credentials
+// that can be used to authenticate.
+func (c *Config) HasCredentials() bool {
+	return len(c.Tokens()) > 0 || (c.ClientID != "" && c.ClientSecret != "")
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OCM configuration file", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(tmpDir, "ocm.json")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	Context("Location", func() {
+		It("Honors the OCM_CONFIG environment variable", func() {
+			GinkgoT().Setenv(EnvVar, "/my/ocm.json")
+			path, err := Location()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/my/ocm.json"))
+		})
+		It("Defaults to the user configuration directory", func() {
+			GinkgoT().Setenv(EnvVar, "")
+			GinkgoT().Setenv("XDG_CONFIG_HOME", tmpDir)
+			GinkgoT().Setenv("HOME", tmpDir)
+			path, err := Location()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal(filepath.Join(tmpDir, "ocm", "ocm.json")))
+		})
+		It("Falls back to the legacy file when it exists", func() {
+			GinkgoT().Setenv(EnvVar, "")
+			GinkgoT().Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "config"))
+			GinkgoT().Setenv("HOME", tmpDir)
+			legacyPath := filepath.Join(tmpDir, ".ocm.json")
+			Expect(os.WriteFile(legacyPath, []byte("{}"), 0600)).To(Succeed())
+			path, err := Location()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal(legacyPath))
+		})
+	})
+
+	Context("LoadFile", func() {
+		It("Returns nil when the file doesn't exist", func() {
+			config, err := LoadFile(filepath.Join(tmpDir, "missing.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(BeNil())
+		})
+		It("Parses the settings written by the login commands", func() {
+			path := writeConfig(`{
+				"access_token": "my-access",
+				"client_id": "cloud-services",
+				"refresh_token": "my-refresh",
+				"scopes": ["openid"],
+				"token_url": "https://sso.example.com/token",
+				"url": "https://api.example.com",
+				"insecure": true,
+				"pager": "less"
+			}`)
+			config, err := LoadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+			Expect(config.URL).To(Equal("https://api.example.com"))
+			Expect(config.TokenURL).To(Equal("https://sso.example.com/token"))
+			Expect(config.ClientID).To(Equal("cloud-services"))
+			Expect(config.Insecure).To(BeTrue())
+			Expect(config.Scopes).To(ConsistOf("openid"))
+			Expect(config.Tokens()).To(Equal([]string{"my-access", "my-refresh"}))
+			Expect(config.HasCredentials()).To(BeTrue())
+		})
+		It("Accepts an empty file", func() {
+			config, err := LoadFile(writeConfig(""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+			Expect(config.HasCredentials()).To(BeFalse())
+		})
+		It("Fails on malformed content", func() {
+			_, err := LoadFile(writeConfig("{"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't parse OCM configuration file"))
+		})
+		It("Treats client credentials as credentials", func() {
+			config, err := LoadFile(writeConfig(`{"client_id": "id", "client_secret": "secret"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Tokens()).To(BeEmpty())
+			Expect(config.HasCredentials()).To(BeTrue())
+		})
+	})
+})
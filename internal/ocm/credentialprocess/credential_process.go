@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentialprocess knows how to obtain OCM tokens from an external command, so that the
+// provider can be plugged into secret stores without persisting long lived secrets.
+//
+// The command is executed by the system shell and must write to its standard output a JSON
+// document like this:
+//
+//	{
+//	  "access_token": "eyJhbGciOi...",
+//	  "refresh_token": "eyJhbGciOi...",
+//	  "expires_at": "2024-06-01T10:00:00Z"
+//	}
+//
+// At least one of the tokens is mandatory. When the expiration isn't given it is taken from the
+// `exp` claim of the access token.
+package credentialprocess
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultExpiryWindow is how long before the expiration of the access token the command is
+	// executed again.
+	DefaultExpiryWindow = 1 * time.Minute
+
+	// DefaultLifetime is the lifetime assumed for access tokens whose expiration can't be
+	// determined.
+	DefaultLifetime = 5 * time.Minute
+)
+
+// Token is the result of executing the credential process.
+type Token struct {
+	AccessToken  string     `json:"access_token,omitempty"`
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+// Process executes the credential command and caches the token that it returns till it is close
+// to its expiration.
+type Process struct {
+	command      string
+	expiryWindow time.Duration
+	now          func() time.Time
+	run          func(ctx context.Context, command string) ([]byte, error)
+
+	lock      sync.Mutex
+	token     *Token
+	expiresAt time.Time
+}
+
+// New creates a credential process that runs the given command line.
+func New(command string) *Process {
+	return &Process{
+		command:      command,
+		expiryWindow: DefaultExpiryWindow,
+		now:          time.Now,
+		run:          runCommand,
+	}
+}
+
+// Token returns the current token, executing the command if there is no token yet or if the
+// cached one is about to expire.
+func (p *Process) Token(ctx context.Context) (*Token, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.token != nil && p.now().Add(p.expiryWindow).Before(p.expiresAt) {
+		return p.token, nil
+	}
+	output, err := p.run(ctx, p.command)
+	if err != nil {
+		return nil, err
+	}
+	token := &Token{}
+	err = json.Unmarshal(output, token)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse output of credential process: %v", err)
+	}
+	if token.AccessToken == "" && token.RefreshToken == "" {
+		return nil, fmt.Errorf("output of credential process doesn't contain an access or refresh token")
+	}
+	p.token = token
+	p.expiresAt = p.tokenExpiration(token)
+	return p.token, nil
+}
+
+// Wrap returns a round tripper that adds the access token returned by the credential process to
+// every request. It is intended to be used as transport wrapper of an unauthenticated
+// connection.
+func (p *Process) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &roundTripper{
+		process:   p,
+		transport: transport,
+	}
+}
+
+func (p *Process) tokenExpiration(token *Token) time.Time {
+	if token.ExpiresAt != nil {
+		return *token.ExpiresAt
+	}
+	if expiresAt, ok := jwtExpiration(token.AccessToken); ok {
+		return expiresAt
+	}
+	return p.now().Add(DefaultLifetime)
+}
+
+type roundTripper struct {
+	process   *Process
+	transport http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, err := t.process.Token(request.Context())
+	if err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("credential process didn't return an access token")
+	}
+	// Round trippers must not modify the original request:
+	request = request.Clone(request.Context())
+	request.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return t.transport.RoundTrip(request)
+}
+
+// jwtExpiration extracts the `exp` claim of a JSON web token without verifying its signature.
+func jwtExpiration(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	claims := struct {
+		Exp *int64 `json:"exp"`
+	}{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+	return time.Unix(*claims.Exp, 0), true
+}
+
+func runCommand(ctx context.Context, command string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("credential process failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,13 @@
+package credentialprocess
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCredentialProcess(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Credential Process Suite")
+}
@@ -0,0 +1,107 @@
+package credentialprocess
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential process", func() {
+	var (
+		ctx    context.Context
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("the test scripts require a POSIX shell")
+		}
+		ctx = context.Background()
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	// writeScript creates a script that prints the given output and counts its executions:
+	writeScript := func(output string) (string, string) {
+		counter := filepath.Join(tmpDir, "counter")
+		script := filepath.Join(tmpDir, "credentials.sh")
+		content := fmt.Sprintf("#!/bin/sh\necho x >> %s\ncat <<'JSON'\n%s\nJSON\n", counter, output)
+		Expect(os.WriteFile(script, []byte(content), 0700)).To(Succeed())
+		return script, counter
+	}
+
+	executions := func(counter string) int {
+		data, err := os.ReadFile(counter)
+		Expect(err).ToNot(HaveOccurred())
+		return len(data) / 2
+	}
+
+	It("Parses the token written by the command", func() {
+		script, _ := writeScript(`{"access_token": "my-access", "refresh_token": "my-refresh", ` +
+			`"expires_at": "2030-01-01T00:00:00Z"}`)
+		token, err := New(script).Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.AccessToken).To(Equal("my-access"))
+		Expect(token.RefreshToken).To(Equal("my-refresh"))
+		Expect(token.ExpiresAt.Year()).To(Equal(2030))
+	})
+
+	It("Caches the token till it is close to the expiration", func() {
+		script, counter := writeScript(`{"access_token": "my-access", "expires_at": "2030-01-01T00:00:00Z"}`)
+		process := New(script)
+		now := time.Date(2029, 12, 31, 23, 0, 0, 0, time.UTC)
+		process.now = func() time.Time { return now }
+
+		_, err := process.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = process.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(executions(counter)).To(Equal(1))
+
+		now = time.Date(2029, 12, 31, 23, 59, 30, 0, time.UTC)
+		_, err = process.Token(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(executions(counter)).To(Equal(2))
+	})
+
+	It("Takes the expiration from the access token claims", func() {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp": 1893456000}`))
+		process := New("")
+		expiresAt := process.tokenExpiration(&Token{AccessToken: "header." + payload + ".signature"})
+		Expect(expiresAt).To(Equal(time.Unix(1893456000, 0)))
+	})
+
+	It("Fails when the command fails", func() {
+		_, err := New("echo broken >&2; exit 3").Token(ctx)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("broken"))
+	})
+
+	It("Fails when the output contains no token", func() {
+		script, _ := writeScript(`{}`)
+		_, err := New(script).Token(ctx)
+		Expect(err).To(MatchError(ContainSubstring("doesn't contain an access or refresh token")))
+	})
+
+	It("Adds the access token to requests", func() {
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+		}))
+		defer server.Close()
+		script, _ := writeScript(`{"access_token": "my-access"}`)
+		client := &http.Client{Transport: New(script).Wrap(http.DefaultTransport)}
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(authorization).To(Equal("Bearer my-access"))
+	})
+})
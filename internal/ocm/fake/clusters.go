@@ -0,0 +1,383 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// States of the clusters:
+const (
+	StateInstalling   = "installing"
+	StateReady        = "ready"
+	StateUninstalling = "uninstalling"
+)
+
+// DefaultBaseDomain is the DNS domain of the clusters that don't specify one.
+const DefaultBaseDomain = "fake.openshiftapps.com"
+
+// versionPrefix is the prefix of the identifiers of the OpenShift versions.
+const versionPrefix = "openshift-v"
+
+// addVersions adds the versions offered by the server. Each version can be upgraded to the ones
+// that follow it.
+func (s *Server) addVersions(rawIDs []string) {
+	for i, rawID := range rawIDs {
+		upgrades := []interface{}{}
+		for _, next := range rawIDs[i+1:] {
+			upgrades = append(upgrades, next)
+		}
+		path := "/versions/" + versionPrefix + rawID
+		object := map[string]interface{}{
+			"raw_id":                       rawID,
+			"enabled":                      true,
+			"rosa_enabled":                 true,
+			"hosted_control_plane_enabled": true,
+			"channel_group":                "stable",
+			"default":                      i == len(rawIDs)-1,
+			"available_upgrades":           upgrades,
+			"release_image":                "quay.io/openshift-release-dev/ocp-release:" + rawID + "-multi",
+			"end_of_life_timestamp":        "2030-01-01T00:00:00Z",
+		}
+		s.complete(path, object)
+		s.store(path, object)
+	}
+}
+
+// defaultVersion returns the identifier of the default version, or an empty string if there are
+// no versions.
+func (s *Server) defaultVersion() string {
+	for _, path := range s.children("/versions") {
+		if s.objects[path]["default"] == true {
+			return s.objects[path]["id"].(string)
+		}
+	}
+	return ""
+}
+
+func (s *Server) createCluster(path string, cluster map[string]interface{}) (int, interface{}) {
+	name, _ := cluster["name"].(string)
+	if name == "" {
+		return failure(http.StatusBadRequest, "Attribute 'name' must be set")
+	}
+	for _, other := range s.children("/clusters") {
+		if s.objects[other]["name"] == name {
+			return failure(http.StatusBadRequest, "Cluster name '%s' already exists", name)
+		}
+	}
+
+	// Replace the version sent with the complete description of the version:
+	versionID := stringAt(cluster, "version", "id")
+	if versionID == "" {
+		versionID = s.defaultVersion()
+	}
+	version, ok := s.objects["/versions/"+versionID]
+	if !ok || version["enabled"] != true {
+		return failure(http.StatusBadRequest, "Version '%s' is not supported", versionID)
+	}
+	sentVersion, _ := cluster["version"].(map[string]interface{})
+	cluster["version"] = mergeObjects(sentVersion, map[string]interface{}{
+		"kind":               "Version",
+		"id":                 versionID,
+		"href":               version["href"],
+		"raw_id":             version["raw_id"],
+		"channel_group":      version["channel_group"],
+		"available_upgrades": version["available_upgrades"],
+	})
+	cluster["openshift_version"] = version["raw_id"]
+
+	// Add the fields calculated by the server:
+	id := cluster["id"].(string)
+	hosted := stringAt(cluster, "hypershift", "enabled") == "true"
+	prefix, _ := cluster["domain_prefix"].(string)
+	if prefix == "" {
+		prefix = name
+		cluster["domain_prefix"] = prefix
+	}
+	baseDomain := stringAt(cluster, "dns", "base_domain")
+	if baseDomain == "" {
+		baseDomain = DefaultBaseDomain
+	}
+	domain := prefix + "." + baseDomain
+	externalID := s.newID()
+	cluster = mergeObjects(map[string]interface{}{
+		"infra_id": name + "-" + externalID[27:],
+		"external_id": fmt.Sprintf("%s-%s-%s-%s-%s", externalID[:8], externalID[8:12], externalID[12:16],
+			externalID[16:20], externalID[20:]),
+		"creation_timestamp": s.clock().UTC().Format(time.RFC3339),
+		"product":            map[string]interface{}{"id": "rosa"},
+		"cloud_provider":     map[string]interface{}{"id": "aws"},
+		"region":             map[string]interface{}{"id": "us-east-1"},
+		"multi_az":           false,
+		"nodes":              map[string]interface{}{},
+	}, cluster)
+	cluster = mergeObjects(cluster, map[string]interface{}{
+		"state": StateInstalling,
+		"dns":   map[string]interface{}{"base_domain": baseDomain},
+		"api": map[string]interface{}{
+			"url":       "https://api." + domain + ":6443",
+			"listening": "external",
+		},
+		"console": map[string]interface{}{
+			"url": "https://console-openshift-console.apps." + domain,
+		},
+		"status": map[string]interface{}{
+			"state":     StateInstalling,
+			"dns_ready": false,
+		},
+	})
+	if cluster["aws"] != nil && stringAt(cluster, "aws", "sts", "role_arn") != "" &&
+		stringAt(cluster, "aws", "sts", "oidc_endpoint_url") == "" {
+		cluster = mergeObjects(cluster, map[string]interface{}{
+			"aws": map[string]interface{}{
+				"sts": map[string]interface{}{
+					"oidc_endpoint_url": "https://oidc." + baseDomain + "/" + id,
+				},
+			},
+		})
+	}
+	s.store(path, cluster)
+	s.timers[path] = s.clock().Add(s.readyAfter)
+
+	// Add the objects that the server creates together with the cluster:
+	nodes := cluster["nodes"].(map[string]interface{})
+	pool := map[string]interface{}{}
+	if autoscaling, ok := nodes["autoscale_compute"].(map[string]interface{}); ok {
+		pool["autoscaling"] = copyObject(autoscaling)
+	} else if replicas, ok := nodes["compute"]; ok {
+		pool["replicas"] = replicas
+	} else {
+		pool["replicas"] = 2
+		nodes["compute"] = 2
+	}
+	if hosted {
+		pool["id"] = "workers"
+		pool["aws_node_pool"] = map[string]interface{}{
+			"instance_type": stringAt(nodes, "compute_machine_type", "id"),
+		}
+		pool["version"] = map[string]interface{}{
+			"id":     versionID,
+			"raw_id": version["raw_id"],
+		}
+		if labels, ok := nodes["compute_labels"]; ok {
+			pool["labels"] = copyValue(labels)
+		}
+		s.addChild(path+"/node_pools/workers", pool)
+		setPoolStatus(s.objects[path+"/node_pools/workers"])
+	} else {
+		pool["id"] = "worker"
+		pool["instance_type"] = stringAt(nodes, "compute_machine_type", "id")
+		if zones, ok := nodes["availability_zones"]; ok {
+			pool["availability_zones"] = copyValue(zones)
+		}
+		if labels, ok := nodes["compute_labels"]; ok {
+			pool["labels"] = copyValue(labels)
+		}
+		s.addChild(path+"/machine_pools/worker", pool)
+		for _, group := range []string{"dedicated-admins", "cluster-admins"} {
+			s.addChild(path+"/groups/"+group, map[string]interface{}{})
+		}
+	}
+	s.addChild(path+"/ingresses/"+s.newID()[27:], map[string]interface{}{
+		"default":   true,
+		"listening": "external",
+		"dns_name":  "apps." + domain,
+	})
+	return http.StatusCreated, cluster
+}
+
+func (s *Server) addChild(path string, object map[string]interface{}) {
+	s.complete(path, object)
+	s.store(path, object)
+}
+
+// uninstallCluster moves the cluster to the uninstalling state. It is removed, together with
+// everything that it contains, once the configured delay passes.
+func (s *Server) uninstallCluster(path string, cluster map[string]interface{}) {
+	if cluster["state"] == StateUninstalling {
+		return
+	}
+	cluster["state"] = StateUninstalling
+	cluster["status"] = mergeObjects(cluster["status"].(map[string]interface{}), map[string]interface{}{
+		"state": StateUninstalling,
+	})
+	s.timers[path] = s.clock().Add(s.uninstallAfter)
+}
+
+// refresh applies the state transitions of the clusters whose delay has passed.
+func (s *Server) refresh() {
+	now := s.clock()
+	for path, deadline := range s.timers {
+		if now.Before(deadline) {
+			continue
+		}
+		cluster := s.objects[path]
+		switch cluster["state"] {
+		case StateInstalling:
+			delete(s.timers, path)
+			cluster["state"] = StateReady
+			cluster["status"] = mergeObjects(cluster["status"].(map[string]interface{}), map[string]interface{}{
+				"state":     StateReady,
+				"dns_ready": true,
+			})
+			setCurrentCompute(cluster)
+		case StateUninstalling:
+			s.removeTree(path)
+		default:
+			delete(s.timers, path)
+		}
+	}
+}
+
+// setCurrentCompute sets the number of compute nodes that are running, which is compared by the
+// provider with the number requested in order to decide if the cluster is ready.
+func setCurrentCompute(cluster map[string]interface{}) {
+	nodes, _ := cluster["nodes"].(map[string]interface{})
+	current := nodes["compute"]
+	if autoscaling, ok := nodes["autoscale_compute"].(map[string]interface{}); ok {
+		current = autoscaling["min_replicas"]
+	}
+	status := cluster["status"].(map[string]interface{})
+	status["current_compute"] = current
+}
+
+func (s *Server) createPool(route route, path string, pool map[string]interface{}) (int, interface{}) {
+	cluster := s.objects[route.parent]
+	if cluster["state"] != StateReady {
+		return failure(http.StatusBadRequest, "Cluster '%s' is in state '%s', can't add %s",
+			cluster["id"], cluster["state"], strings.ReplaceAll(route.name, "_", " "))
+	}
+	_, hasReplicas := pool["replicas"]
+	_, hasAutoscaling := pool["autoscaling"]
+	if hasReplicas == hasAutoscaling {
+		return failure(http.StatusBadRequest, "Either 'replicas' or 'autoscaling' must be set")
+	}
+	if route.name == "node_pools" {
+		if pool["version"] == nil {
+			pool["version"] = map[string]interface{}{
+				"id":     stringAt(cluster, "version", "id"),
+				"raw_id": stringAt(cluster, "version", "raw_id"),
+			}
+		}
+		setPoolStatus(pool)
+	}
+	s.store(path, pool)
+	return http.StatusCreated, pool
+}
+
+// setPoolStatus sets the number of replicas of a node pool that are running, assuming that the
+// changes are applied immediately.
+func setPoolStatus(pool map[string]interface{}) {
+	current := pool["replicas"]
+	if autoscaling, ok := pool["autoscaling"].(map[string]interface{}); ok {
+		current = autoscaling["min_replica"]
+	}
+	pool["status"] = map[string]interface{}{
+		"kind":             "NodePoolStatus",
+		"current_replicas": current,
+		"message":          "",
+	}
+}
+
+// createIdentityProvider saves the users of an HTPasswd identity provider as separate objects,
+// like the real server does, so that the passwords aren't returned.
+func (s *Server) createIdentityProvider(path string, provider map[string]interface{}) (int, interface{}) {
+	htpasswd, ok := provider["htpasswd"].(map[string]interface{})
+	var users []interface{}
+	if ok {
+		list, _ := htpasswd["users"].(map[string]interface{})
+		users, _ = list["items"].([]interface{})
+		delete(htpasswd, "users")
+	}
+	s.store(path, provider)
+	for _, item := range users {
+		user, _ := item.(map[string]interface{})
+		s.addChild(path+"/htpasswd_users/"+s.newID(), map[string]interface{}{
+			"username": user["username"],
+		})
+	}
+	return http.StatusCreated, provider
+}
+
+// createUpgradePolicy saves an upgrade policy together with its state, which starts as
+// `scheduled`.
+func (s *Server) createUpgradePolicy(path string, policy map[string]interface{}) (int, interface{}) {
+	s.store(path, policy)
+	s.addChild(path+"/state", map[string]interface{}{
+		"value":       "scheduled",
+		"description": "Upgrade scheduled.",
+	})
+	return http.StatusCreated, policy
+}
+
+// dryRun checks a request to create an object without creating it. For upgrade policies it
+// reports the version gates that haven't been agreed yet, like the real server does.
+func (s *Server) dryRun(route route, sent map[string]interface{}) (int, interface{}) {
+	if route.name != "upgrade_policies" {
+		return http.StatusNoContent, nil
+	}
+	version, _ := sent["version"].(string)
+	if version == "" {
+		return http.StatusNoContent, nil
+	}
+	agreed := map[string]bool{}
+	for _, path := range s.children(route.parent + "/gate_agreements") {
+		agreed[stringAt(s.objects[path], "version_gate", "id")] = true
+	}
+	missing := []interface{}{}
+	for _, path := range s.children("/version_gates") {
+		gate := s.objects[path]
+		prefix, _ := gate["version_raw_id_prefix"].(string)
+		if prefix != "" && strings.HasPrefix(version, prefix) && !agreed[gate["id"].(string)] {
+			missing = append(missing, gate)
+		}
+	}
+	if len(missing) == 0 {
+		return http.StatusNoContent, nil
+	}
+	status, result := failure(http.StatusBadRequest, "There are %d version gates that need to be agreed",
+		len(missing))
+	result.(map[string]interface{})["details"] = missing
+	return status, result
+}
+
+// stringAt returns the text representation of the value at the given path inside an object, or
+// an empty string if there is no such value.
+func stringAt(object map[string]interface{}, names ...string) string {
+	var value interface{} = object
+	for _, name := range names {
+		current, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value, ok = current[name]
+		if !ok {
+			return ""
+		}
+	}
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"strings"
+)
+
+// singletons are the names of the objects that don't belong to a collection, like the autoscaler
+// of a cluster.
+var singletons = map[string]bool{
+	"autoscaler":           true,
+	"control_plane":        true,
+	"external_auth_config": true,
+	"kubelet_config":       true,
+	"state":                true,
+}
+
+// kinds are the kinds of the objects of each collection or singleton whose kind can't be derived
+// from the name.
+var kinds = map[string]string{
+	"autoscaler":      "ClusterAutoscaler",
+	"gate_agreements": "VersionGateAgreement",
+	"htpasswd_users":  "HTPasswdUser",
+	"oidc_configs":    "OidcConfig",
+	"state":           "UpgradePolicyState",
+}
+
+// route describes a path of the API, relative to the prefix.
+type route struct {
+	// path is the cleaned path.
+	path string
+
+	// name is the name of the collection, or the name of the collection that contains the object,
+	// or the name of the singleton.
+	name string
+
+	// collection is true when the path is a collection.
+	collection bool
+
+	// singleton is true when the path is a singleton, like `/clusters/123/autoscaler`.
+	singleton bool
+
+	// parent is the path of the nearest object with an identifier that contains the path, for
+	// example `/clusters/123` for `/clusters/123/control_plane/upgrade_policies`. It is empty for
+	// the top level collections.
+	parent string
+}
+
+// parseRoute parses a path relative to the prefix. Paths alternate collection names and
+// identifiers, except for singletons, which have no identifier.
+func parseRoute(path string) (result route, ok bool) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	current := ""
+	parent := ""
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if segment == "" {
+			return
+		}
+		current += "/" + segment
+		result = route{
+			path:   current,
+			name:   segment,
+			parent: parent,
+		}
+		if singletons[segment] {
+			result.singleton = true
+			continue
+		}
+		if i == len(segments)-1 {
+			result.collection = true
+			break
+		}
+		i++
+		if segments[i] == "" {
+			return
+		}
+		current += "/" + segments[i]
+		result.path = current
+		parent = current
+	}
+	ok = true
+	return
+}
+
+// kindOf calculates the kind of the object with the given path, for example `MachinePool` for
+// `/clusters/123/machine_pools/worker`.
+func kindOf(path string) string {
+	route, ok := parseRoute(path)
+	if !ok {
+		return "Object"
+	}
+	if route.name == "upgrade_policies" {
+		switch {
+		case strings.Contains(route.path, "/control_plane/"):
+			return "ControlPlaneUpgradePolicy"
+		case strings.Contains(route.path, "/node_pools/"):
+			return "NodePoolUpgradePolicy"
+		}
+	}
+	if kind, ok := kinds[route.name]; ok {
+		return kind
+	}
+	name := route.name
+	if !route.singleton {
+		name = singular(name)
+	}
+	result := ""
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			result += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return result
+}
+
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	default:
+		return strings.TrimSuffix(name, "s")
+	}
+}
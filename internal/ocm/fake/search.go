@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// search is a parsed `search` parameter. Only the subset of the language used by the provider is
+// supported: comparisons of fields with literals, joined with `and`.
+type search []*searchClause
+
+type searchClause struct {
+	field    []string
+	operator string
+	values   []string
+}
+
+var (
+	searchAndRE    = regexp.MustCompile(`(?i)\s+and\s+`)
+	searchClauseRE = regexp.MustCompile(`(?i)^\s*([a-z0-9_.]+)\s*(=|!=|<>|\blike\b|\bin\b)\s*(.+?)\s*$`)
+	searchValueRE  = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+func parseSearch(text string) (search, error) {
+	result := search{}
+	if strings.TrimSpace(text) == "" {
+		return result, nil
+	}
+	for _, part := range searchAndRE.Split(text, -1) {
+		groups := searchClauseRE.FindStringSubmatch(part)
+		if groups == nil {
+			return nil, fmt.Errorf("search clause '%s' isn't supported", strings.TrimSpace(part))
+		}
+		clause := &searchClause{
+			field:    strings.Split(groups[1], "."),
+			operator: strings.ToLower(groups[2]),
+		}
+		for _, value := range searchValueRE.FindAllStringSubmatch(groups[3], -1) {
+			clause.values = append(clause.values, strings.ReplaceAll(value[1], "''", "'"))
+		}
+		if len(clause.values) == 0 || clause.operator != "in" && len(clause.values) > 1 {
+			return nil, fmt.Errorf("value of search clause '%s' isn't supported", strings.TrimSpace(part))
+		}
+		result = append(result, clause)
+	}
+	return result, nil
+}
+
+// matches checks if an object satisfies all the clauses.
+func (s search) matches(object map[string]interface{}) bool {
+	for _, clause := range s {
+		if !clause.matches(object) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *searchClause) matches(object map[string]interface{}) bool {
+	actual := stringAt(object, c.field...)
+	switch c.operator {
+	case "=":
+		return equalValues(actual, c.values[0])
+	case "!=", "<>":
+		return !equalValues(actual, c.values[0])
+	case "like":
+		pattern := "^" + strings.ReplaceAll(regexp.QuoteMeta(c.values[0]), "%", ".*") + "$"
+		return regexp.MustCompile(pattern).MatchString(actual)
+	default:
+		for _, value := range c.values {
+			if equalValues(actual, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// equalValues compares the text of a field with a literal. Boolean fields are written as `t` and
+// `f` in the literals.
+func equalValues(actual, literal string) bool {
+	switch literal {
+	case "t":
+		return actual == "true"
+	case "f":
+		return actual == "false" || actual == ""
+	default:
+		return actual == literal
+	}
+}
@@ -0,0 +1,529 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains an in-memory implementation of the parts of the clusters_mgmt API used
+// by the provider. It keeps the objects that are created, changed and deleted, and moves the
+// clusters through the states that the real service uses, so that tests can check the results of
+// running the provider instead of the exact sequence of requests that it sends.
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix is the path where the server serves the clusters_mgmt API.
+const Prefix = "/api/clusters_mgmt/v1"
+
+// OperationIDHeader is the response header that contains the identifier of the operation.
+const OperationIDHeader = "X-Operation-ID"
+
+// tokenPathSuffix is the end of the path of the requests sent to obtain tokens.
+const tokenPathSuffix = "/protocol/openid-connect/token"
+
+// Default delays of the state transitions of the clusters:
+const (
+	DefaultReadyAfter     time.Duration = 0
+	DefaultUninstallAfter time.Duration = 0
+)
+
+// DefaultVersions are the OpenShift versions that the server offers when no others are given.
+// The last one is the default.
+var DefaultVersions = []string{"4.13.10", "4.14.0", "4.14.1", "4.15.3"}
+
+// ServerBuilder contains the data and logic needed to build a fake server.
+type ServerBuilder struct {
+	readyAfter     time.Duration
+	uninstallAfter time.Duration
+	clock          func() time.Time
+	versions       []string
+}
+
+// Server is an in-memory fake of the clusters_mgmt API. It implements the http.Handler interface,
+// so it can be used with a test server or with a regular HTTP server. Requests sent to obtain
+// tokens are also accepted, and answered with tokens that aren't signed.
+//
+// Clusters are created in the `installing` state and become `ready` once the configured delay has
+// passed. Deleted clusters stay in the `uninstalling` state for the configured delay and are
+// removed afterwards. The transitions are applied when requests are received, so no background
+// goroutines are needed.
+type Server struct {
+	lock           sync.Mutex
+	readyAfter     time.Duration
+	uninstallAfter time.Duration
+	clock          func() time.Time
+	objects        map[string]map[string]interface{}
+	sequence       map[string]int
+	timers         map[string]time.Time
+	counter        int
+	operations     int
+}
+
+// NewServer creates a builder that can then be used to configure and create a fake server.
+func NewServer() *ServerBuilder {
+	return &ServerBuilder{
+		readyAfter:     DefaultReadyAfter,
+		uninstallAfter: DefaultUninstallAfter,
+		clock:          time.Now,
+		versions:       DefaultVersions,
+	}
+}
+
+// ReadyAfter sets the time that clusters stay in the `installing` state. The default is zero,
+// which means that clusters are ready as soon as they are read after being created.
+func (b *ServerBuilder) ReadyAfter(value time.Duration) *ServerBuilder {
+	b.readyAfter = value
+	return b
+}
+
+// UninstallAfter sets the time that clusters stay in the `uninstalling` state before they are
+// removed. The default is zero.
+func (b *ServerBuilder) UninstallAfter(value time.Duration) *ServerBuilder {
+	b.uninstallAfter = value
+	return b
+}
+
+// Clock sets the function used to get the current time. The default is time.Now. Tests can use
+// it to move the state transitions forward without waiting.
+func (b *ServerBuilder) Clock(value func() time.Time) *ServerBuilder {
+	b.clock = value
+	return b
+}
+
+// Versions sets the raw identifiers of the OpenShift versions offered by the server, for
+// example `4.14.1`, from older to newer. The last one is the default version.
+func (b *ServerBuilder) Versions(values ...string) *ServerBuilder {
+	b.versions = values
+	return b
+}
+
+// Build uses the information stored in the builder to create a new fake server.
+func (b *ServerBuilder) Build() *Server {
+	s := &Server{
+		readyAfter:     b.readyAfter,
+		uninstallAfter: b.uninstallAfter,
+		clock:          b.clock,
+		objects:        map[string]map[string]interface{}{},
+		sequence:       map[string]int{},
+		timers:         map[string]time.Time{},
+	}
+	s.addVersions(b.versions)
+	return s
+}
+
+// Get returns a copy of the object stored with the given path, for example
+// `/clusters/123/machine_pools/worker`. The path may also include the prefix of the API. The
+// result is nil if there is no such object.
+func (s *Server) Get(path string) map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.refresh()
+	object, ok := s.objects[cleanPath(path)]
+	if !ok {
+		return nil
+	}
+	return copyObject(object)
+}
+
+// Put stores an object with the given path, replacing the existing one if any. The `id`, `kind`
+// and `href` fields are added when they are missing. It is intended to prepare the objects that
+// tests need, and skips the checks and side effects of the requests.
+func (s *Server) Put(path string, object map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	path = cleanPath(path)
+	object = copyObject(object)
+	s.complete(path, object)
+	s.store(path, object)
+}
+
+// List returns copies of the objects of the given collection, for example `/clusters`, in the
+// order that they were created.
+func (s *Server) List(collection string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.refresh()
+	result := []map[string]interface{}{}
+	for _, path := range s.children(cleanPath(collection)) {
+		result = append(result, copyObject(s.objects[path]))
+	}
+	return result
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, tokenPathSuffix) {
+		s.serveTokens(w)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.operations++
+	operationID := fmt.Sprintf("fake-%d", s.operations)
+	w.Header().Set(OperationIDHeader, operationID)
+
+	var status int
+	var result interface{}
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	body, err := io.ReadAll(r.Body)
+	switch {
+	case err != nil:
+		status, result = failure(http.StatusBadRequest, "Can't read request body: %v", err)
+	case !strings.HasPrefix(path, Prefix+"/"):
+		status, result = failure(http.StatusNotFound, "Path '%s' isn't supported", path)
+	default:
+		s.refresh()
+		status, result = s.handle(r, strings.TrimPrefix(path, Prefix), body)
+	}
+
+	if status >= http.StatusBadRequest {
+		apiError := result.(map[string]interface{})
+		apiError["operation_id"] = operationID
+	}
+	if result == nil {
+		w.WriteHeader(status)
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func (s *Server) handle(r *http.Request, path string, body []byte) (int, interface{}) {
+	route, ok := parseRoute(path)
+	if !ok {
+		return failure(http.StatusNotFound, "Path '%s' isn't supported", Prefix+path)
+	}
+	if route.parent != "" && s.objects[route.parent] == nil {
+		return notFound(route.parent)
+	}
+	var sent map[string]interface{}
+	if len(body) > 0 {
+		err := json.Unmarshal(body, &sent)
+		if err != nil {
+			return failure(http.StatusBadRequest, "Can't parse request body: %v", err)
+		}
+	}
+	query := r.URL.Query()
+	switch {
+	case route.collection && r.Method == http.MethodGet:
+		return s.list(route, query)
+	case route.collection && r.Method == http.MethodPost:
+		if query.Get("dryRun") == "true" {
+			return s.dryRun(route, sent)
+		}
+		return s.create(route, sent)
+	case route.collection:
+		return failure(http.StatusMethodNotAllowed, "Method '%s' isn't allowed for collections", r.Method)
+	case r.Method == http.MethodGet:
+		object, ok := s.objects[route.path]
+		if !ok {
+			return notFound(route.path)
+		}
+		return http.StatusOK, object
+	case r.Method == http.MethodPost && route.singleton:
+		if _, ok := s.objects[route.path]; ok {
+			return failure(http.StatusConflict, "%s already exists", kindOf(route.path))
+		}
+		object := copyObject(sent)
+		s.complete(route.path, object)
+		s.store(route.path, object)
+		return http.StatusCreated, object
+	case r.Method == http.MethodPatch:
+		return s.update(route, sent)
+	case r.Method == http.MethodDelete:
+		return s.remove(route)
+	default:
+		return failure(http.StatusMethodNotAllowed, "Method '%s' isn't allowed for objects", r.Method)
+	}
+}
+
+func (s *Server) list(route route, query map[string][]string) (int, interface{}) {
+	filter, err := parseSearch(first(query["search"]))
+	if err != nil {
+		return failure(http.StatusBadRequest, "%v", err)
+	}
+	page, err := intParameter(query, "page", 1)
+	if err != nil {
+		return failure(http.StatusBadRequest, "%v", err)
+	}
+	size, err := intParameter(query, "size", 100)
+	if err != nil {
+		return failure(http.StatusBadRequest, "%v", err)
+	}
+	matches := []interface{}{}
+	for _, path := range s.children(route.path) {
+		object := s.objects[path]
+		if filter.matches(object) {
+			matches = append(matches, object)
+		}
+	}
+	start := (page - 1) * size
+	end := start + size
+	if start > len(matches) || start < 0 {
+		start = len(matches)
+	}
+	if end > len(matches) || end < 0 {
+		end = len(matches)
+	}
+	items := matches[start:end]
+	return http.StatusOK, map[string]interface{}{
+		"kind":  kindOf(route.path+"/x") + "List",
+		"href":  Prefix + route.path,
+		"page":  page,
+		"size":  len(items),
+		"total": len(matches),
+		"items": items,
+	}
+}
+
+func (s *Server) create(route route, sent map[string]interface{}) (int, interface{}) {
+	object := copyObject(sent)
+	id, _ := object["id"].(string)
+	if id == "" {
+		id = s.newID()
+	}
+	path := route.path + "/" + id
+	if _, ok := s.objects[path]; ok {
+		return failure(http.StatusConflict, "%s '%s' already exists", kindOf(path), id)
+	}
+	s.complete(path, object)
+	var status int
+	var result interface{}
+	switch route.name {
+	case "clusters":
+		status, result = s.createCluster(path, object)
+	case "machine_pools", "node_pools":
+		status, result = s.createPool(route, path, object)
+	case "identity_providers":
+		status, result = s.createIdentityProvider(path, object)
+	case "upgrade_policies":
+		status, result = s.createUpgradePolicy(path, object)
+	default:
+		s.store(path, object)
+		status, result = http.StatusCreated, object
+	}
+	return status, result
+}
+
+func (s *Server) update(route route, sent map[string]interface{}) (int, interface{}) {
+	current, ok := s.objects[route.path]
+	if !ok {
+		return notFound(route.path)
+	}
+	object := mergeObjects(current, sent)
+	object["id"] = current["id"]
+	switch route.name {
+	case "clusters":
+		if object["state"] == StateReady {
+			setCurrentCompute(object)
+		}
+	case "node_pools":
+		setPoolStatus(object)
+	}
+	s.objects[route.path] = object
+	return http.StatusOK, object
+}
+
+func (s *Server) remove(route route) (int, interface{}) {
+	object, ok := s.objects[route.path]
+	if !ok {
+		return notFound(route.path)
+	}
+	if route.name == "clusters" {
+		s.uninstallCluster(route.path, object)
+		return http.StatusNoContent, nil
+	}
+	s.removeTree(route.path)
+	return http.StatusNoContent, nil
+}
+
+// store saves an object, remembering the order of creation. Objects that replace another keep its
+// position.
+func (s *Server) store(path string, object map[string]interface{}) {
+	if _, ok := s.sequence[path]; !ok {
+		s.counter++
+		s.sequence[path] = s.counter
+	}
+	s.objects[path] = object
+}
+
+// removeTree removes an object and everything inside it.
+func (s *Server) removeTree(path string) {
+	for key := range s.objects {
+		if key == path || strings.HasPrefix(key, path+"/") {
+			delete(s.objects, key)
+			delete(s.sequence, key)
+			delete(s.timers, key)
+		}
+	}
+}
+
+// children returns the paths of the objects of a collection, in the order that they were
+// created.
+func (s *Server) children(collection string) []string {
+	result := []string{}
+	for path := range s.objects {
+		if strings.HasPrefix(path, collection+"/") &&
+			!strings.Contains(path[len(collection)+1:], "/") {
+			result = append(result, path)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return s.sequence[result[i]] < s.sequence[result[j]]
+	})
+	return result
+}
+
+// complete adds the fields that the server adds to all the objects.
+func (s *Server) complete(path string, object map[string]interface{}) {
+	route, _ := parseRoute(path)
+	if !route.singleton {
+		object["id"] = path[strings.LastIndex(path, "/")+1:]
+	}
+	object["href"] = Prefix + path
+	if _, ok := object["kind"]; !ok {
+		object["kind"] = kindOf(path)
+	}
+}
+
+// newID generates an identifier similar to the ones generated by the real server.
+func (s *Server) newID() string {
+	s.counter++
+	return fmt.Sprintf("%032x", s.counter)
+}
+
+func (s *Server) serveTokens(w http.ResponseWriter) {
+	body, err := json.Marshal(map[string]interface{}{
+		"access_token":  Token(),
+		"refresh_token": token("Refresh"),
+		"token_type":    "bearer",
+		"expires_in":    24 * 60 * 60,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// Token returns an access token that is accepted by the SDK, so it can be used in the `token`
+// attribute of the provider. It isn't signed, as the server doesn't check it.
+func Token() string {
+	return token("Bearer")
+}
+
+func token(typ string) string {
+	now := time.Now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(
+		`{"typ":%q,"exp":%d,"iat":%d}`, typ, now.Add(365*24*time.Hour).Unix(), now.Unix())))
+	return header + "." + claims + "."
+}
+
+// failure returns the status and the body of an error response.
+func failure(status int, format string, args ...interface{}) (int, interface{}) {
+	return status, map[string]interface{}{
+		"kind":   "Error",
+		"id":     strconv.Itoa(status),
+		"href":   Prefix + "/errors/" + strconv.Itoa(status),
+		"code":   fmt.Sprintf("CLUSTERS-MGMT-%d", status),
+		"reason": fmt.Sprintf(format, args...),
+	}
+}
+
+func notFound(path string) (int, interface{}) {
+	return failure(http.StatusNotFound, "%s '%s' not found", kindOf(path), path[strings.LastIndex(path, "/")+1:])
+}
+
+// cleanPath removes the prefix of the API and the trailing slash from a path.
+func cleanPath(path string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(path, Prefix), "/")
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func intParameter(query map[string][]string, name string, defaultValue int) (int, error) {
+	text := first(query[name])
+	if text == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("value '%s' of parameter '%s' isn't a positive integer", text, name)
+	}
+	return value, nil
+}
+
+// copyObject returns a deep copy of an object, so that the stored objects can't be changed
+// outside of the server.
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	return mergeObjects(nil, object)
+}
+
+// mergeObjects returns a new object with the fields of the current object replaced by the ones
+// that were sent. Nested objects are merged field by field, and lists are replaced.
+func mergeObjects(current, sent map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(current)+len(sent))
+	for k, v := range current {
+		result[k] = copyValue(v)
+	}
+	for k, v := range sent {
+		sentMap, sentIsMap := v.(map[string]interface{})
+		currentMap, currentIsMap := result[k].(map[string]interface{})
+		if sentIsMap && currentIsMap {
+			result[k] = mergeObjects(currentMap, sentMap)
+		} else {
+			result[k] = copyValue(v)
+		}
+	}
+	return result
+}
+
+func copyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return mergeObjects(nil, typed)
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, item := range typed {
+			result[i] = copyValue(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,251 @@
+package fake
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("Fake server", func() {
+	var (
+		now        time.Time
+		fake       *Server
+		server     *httptest.Server
+		connection *sdk.Connection
+		clusters   *cmv1.ClustersClient
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		fake = NewServer().
+			ReadyAfter(10 * time.Minute).
+			UninstallAfter(5 * time.Minute).
+			Clock(func() time.Time { return now }).
+			Build()
+		server = httptest.NewServer(fake)
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(server.URL).
+			Tokens(Token()).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusters = connection.ClustersMgmt().V1().Clusters()
+	})
+
+	AfterEach(func() {
+		connection.Close()
+		server.Close()
+	})
+
+	createCluster := func(builder *cmv1.ClusterBuilder) *cmv1.Cluster {
+		cluster, err := builder.Build()
+		Expect(err).ToNot(HaveOccurred())
+		response, err := clusters.Add().Body(cluster).Send()
+		Expect(err).ToNot(HaveOccurred())
+		return response.Body()
+	}
+
+	It("Moves the clusters through the installation and the uninstallation", func() {
+		cluster := createCluster(cmv1.NewCluster().Name("mycluster").
+			Nodes(cmv1.NewClusterNodes().Compute(3)))
+		Expect(cluster.State()).To(Equal(cmv1.ClusterStateInstalling))
+		Expect(cluster.Version().ID()).To(Equal("openshift-v4.15.3"))
+		Expect(cluster.API().URL()).To(Equal("https://api.mycluster.fake.openshiftapps.com:6443"))
+
+		get, err := clusters.Cluster(cluster.ID()).Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(get.Body().State()).To(Equal(cmv1.ClusterStateInstalling))
+
+		now = now.Add(10 * time.Minute)
+		get, err = clusters.Cluster(cluster.ID()).Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(get.Body().State()).To(Equal(cmv1.ClusterStateReady))
+		Expect(get.Body().Status().CurrentCompute()).To(Equal(3))
+
+		_, err = clusters.Cluster(cluster.ID()).Delete().Send()
+		Expect(err).ToNot(HaveOccurred())
+		get, err = clusters.Cluster(cluster.ID()).Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(get.Body().State()).To(Equal(cmv1.ClusterStateUninstalling))
+
+		now = now.Add(5 * time.Minute)
+		get, err = clusters.Cluster(cluster.ID()).Get().Send()
+		Expect(err).To(HaveOccurred())
+		Expect(get.Status()).To(Equal(http.StatusNotFound))
+		Expect(fake.Get("/clusters/" + cluster.ID() + "/machine_pools/worker")).To(BeNil())
+	})
+
+	It("Creates the default pools of the classic and hosted clusters", func() {
+		classic := createCluster(cmv1.NewCluster().Name("classic").
+			Nodes(cmv1.NewClusterNodes().Compute(2).
+				ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge"))))
+		pools, err := clusters.Cluster(classic.ID()).MachinePools().List().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pools.Items().Len()).To(Equal(1))
+		Expect(pools.Items().Get(0).ID()).To(Equal("worker"))
+		Expect(pools.Items().Get(0).InstanceType()).To(Equal("m5.xlarge"))
+		ingresses, err := clusters.Cluster(classic.ID()).Ingresses().List().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ingresses.Items().Get(0).Default()).To(BeTrue())
+
+		hosted := createCluster(cmv1.NewCluster().Name("hosted").
+			Hypershift(cmv1.NewHypershift().Enabled(true)).
+			Nodes(cmv1.NewClusterNodes().Compute(2)))
+		nodePool, err := clusters.Cluster(hosted.ID()).NodePools().NodePool("workers").Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nodePool.Body().Status().CurrentReplicas()).To(Equal(2))
+		Expect(nodePool.Body().Version().ID()).To(Equal("openshift-v4.15.3"))
+		Expect(fake.Get("/clusters/" + hosted.ID() + "/machine_pools/worker")).To(BeNil())
+	})
+
+	It("Rejects invalid clusters", func() {
+		createCluster(cmv1.NewCluster().Name("mycluster"))
+		cluster, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).ToNot(HaveOccurred())
+		response, err := clusters.Add().Body(cluster).Send()
+		Expect(err).To(HaveOccurred())
+		Expect(response.Error().Reason()).To(ContainSubstring("already exists"))
+		Expect(response.Error().OperationID()).ToNot(BeEmpty())
+
+		cluster, err = cmv1.NewCluster().Name("other").Version(cmv1.NewVersion().ID("openshift-v4.1.0")).Build()
+		Expect(err).ToNot(HaveOccurred())
+		response, err = clusters.Add().Body(cluster).Send()
+		Expect(err).To(HaveOccurred())
+		Expect(response.Status()).To(Equal(http.StatusBadRequest))
+		Expect(response.Error().Reason()).To(Equal("Version 'openshift-v4.1.0' is not supported"))
+	})
+
+	It("Keeps the machine pools added to ready clusters", func() {
+		cluster := createCluster(cmv1.NewCluster().Name("mycluster"))
+		pool, err := cmv1.NewMachinePool().ID("extra").Replicas(1).Build()
+		Expect(err).ToNot(HaveOccurred())
+		pools := clusters.Cluster(cluster.ID()).MachinePools()
+		_, err = pools.Add().Body(pool).Send()
+		Expect(err).To(MatchError(ContainSubstring("is in state 'installing'")))
+
+		now = now.Add(10 * time.Minute)
+		_, err = pools.Add().Body(pool).Send()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = pools.Add().Body(pool).Send()
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*ocmerrors.Error).Status()).To(Equal(http.StatusConflict))
+
+		patch, err := cmv1.NewMachinePool().Replicas(4).Build()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = pools.MachinePool("extra").Update().Body(patch).Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fake.Get("/clusters/" + cluster.ID() + "/machine_pools/extra")).To(HaveKeyWithValue("replicas", BeEquivalentTo(4)))
+
+		_, err = pools.MachinePool("extra").Delete().Send()
+		Expect(err).ToNot(HaveOccurred())
+		list, err := pools.List().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Total()).To(Equal(1))
+	})
+
+	It("Stores the HTPasswd users without the passwords", func() {
+		cluster := createCluster(cmv1.NewCluster().Name("mycluster"))
+		idp, err := cmv1.NewIdentityProvider().Name("htpasswd").Type(cmv1.IdentityProviderTypeHtpasswd).
+			Htpasswd(cmv1.NewHTPasswdIdentityProvider().Users(cmv1.NewHTPasswdUserList().Items(
+				cmv1.NewHTPasswdUser().Username("admin").Password("hunter2hunter2"),
+			))).Build()
+		Expect(err).ToNot(HaveOccurred())
+		created, err := clusters.Cluster(cluster.ID()).IdentityProviders().Add().Body(idp).Send()
+		Expect(err).ToNot(HaveOccurred())
+		users, err := clusters.Cluster(cluster.ID()).IdentityProviders().IdentityProvider(created.Body().ID()).
+			HtpasswdUsers().List().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users.Items().Len()).To(Equal(1))
+		Expect(users.Items().Get(0).Username()).To(Equal("admin"))
+		Expect(users.Items().Get(0).Password()).To(BeEmpty())
+	})
+
+	It("Creates the upgrade policies with their state and checks the version gates", func() {
+		cluster := createCluster(cmv1.NewCluster().Name("mycluster").
+			Version(cmv1.NewVersion().ID("openshift-v4.14.0")))
+		Expect(cluster.Version().AvailableUpgrades()).To(Equal([]string{"4.14.1", "4.15.3"}))
+		fake.Put("/version_gates/gate-1", map[string]interface{}{
+			"version_raw_id_prefix": "4.15",
+			"label":                 "api.openshift.com/gate-sts",
+		})
+
+		policies := clusters.Cluster(cluster.ID()).UpgradePolicies()
+		policy, err := cmv1.NewUpgradePolicy().Version("4.15.3").ScheduleType(cmv1.ScheduleTypeManual).Build()
+		Expect(err).ToNot(HaveOccurred())
+		response, err := policies.Add().Parameter("dryRun", true).Body(policy).Send()
+		Expect(err).To(HaveOccurred())
+		details, ok := response.Error().GetDetails()
+		Expect(ok).To(BeTrue())
+		Expect(details).To(HaveLen(1))
+
+		agreement, err := cmv1.NewVersionGateAgreement().VersionGate(cmv1.NewVersionGate().ID("gate-1")).Build()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = clusters.Cluster(cluster.ID()).GateAgreements().Add().Body(agreement).Send()
+		Expect(err).ToNot(HaveOccurred())
+		response, err = policies.Add().Parameter("dryRun", true).Body(policy).Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Status()).To(Equal(http.StatusNoContent))
+
+		response, err = policies.Add().Body(policy).Send()
+		Expect(err).ToNot(HaveOccurred())
+		state, err := policies.UpgradePolicy(response.Body().ID()).State().Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Body().Value()).To(Equal(cmv1.UpgradePolicyStateValueScheduled))
+	})
+
+	It("Manages the singletons of the clusters", func() {
+		cluster := createCluster(cmv1.NewCluster().Name("mycluster"))
+		autoscaler := clusters.Cluster(cluster.ID()).Autoscaler()
+		_, err := autoscaler.Get().Send()
+		Expect(err).To(HaveOccurred())
+
+		body, err := cmv1.NewClusterAutoscaler().MaxPodGracePeriod(10).Build()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = autoscaler.Post().Request(body).Send()
+		Expect(err).ToNot(HaveOccurred())
+		get, err := autoscaler.Get().Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(get.Body().MaxPodGracePeriod()).To(Equal(10))
+
+		_, err = autoscaler.Delete().Send()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = autoscaler.Get().Send()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Filters and pages the lists", func() {
+		for _, name := range []string{"a", "b", "c"} {
+			createCluster(cmv1.NewCluster().Name(name))
+		}
+		list, err := clusters.List().Search("name = 'b' or name = 'c'").Send()
+		Expect(err).To(HaveOccurred())
+		Expect(list.Status()).To(Equal(http.StatusBadRequest))
+
+		list, err = clusters.List().Search("name in ('b', 'c') and state = 'installing'").Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Total()).To(Equal(2))
+		Expect(list.Items().Get(0).Name()).To(Equal("b"))
+
+		list, err = clusters.List().Size(2).Page(2).Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Total()).To(Equal(3))
+		Expect(list.Items().Len()).To(Equal(1))
+		Expect(list.Items().Get(0).Name()).To(Equal("c"))
+
+		versions, err := connection.ClustersMgmt().V1().Versions().List().
+			Search("enabled = 't' and raw_id like '4.14%'").Send()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions.Total()).To(Equal(2))
+	})
+
+	It("Reports the objects of missing clusters as not found", func() {
+		_, err := clusters.Cluster("123").MachinePools().List().Send()
+		Expect(err).To(MatchError(ContainSubstring("Cluster '123' not found")))
+	})
+})
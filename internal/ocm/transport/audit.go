@@ -0,0 +1,316 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// OperationIDHeader is the response header that contains the identifier that OCM assigns to each
+// request. It is what the support team needs to find the request in the server logs.
+const OperationIDHeader = "X-Operation-ID"
+
+// AuditRecord is one line of the audit log. It describes one request that created, changed or
+// deleted something in OCM.
+type AuditRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+
+	// ResourceType is the kind of the OCM object, for example `cluster` or `machine_pool`, and
+	// ResourceAddress its location inside the API, for example `clusters/123/machine_pools/workers`.
+	ResourceType    string `json:"resource_type"`
+	ResourceAddress string `json:"resource_address"`
+
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operation_id,omitempty"`
+	Status      int    `json:"status,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	// Changes contains the fields sent in the request body, with the previous value of the
+	// object when it is being updated. Secrets are redacted.
+	Changes []AuditChange `json:"changes,omitempty"`
+}
+
+// AuditChange is the change of one field of an OCM object. The path uses dots to separate the
+// names of the nested fields, for example `aws.tags.owner`.
+type AuditChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// AuditLog writes a JSON line for each request that the provider sends to OCM to create, change
+// or delete something. Read only requests and the requests sent to obtain tokens aren't written.
+type AuditLog struct {
+	path string
+	now  func() time.Time
+	lock sync.Mutex
+}
+
+// NewAuditLog creates an audit log that appends the records to the given file, creating it if
+// needed. It checks that the file can be written.
+func NewAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("can't open audit log file: %v", err)
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("can't open audit log file: %v", err)
+	}
+	return &AuditLog{
+		path: path,
+		now:  time.Now,
+	}, nil
+}
+
+// Wrap returns a round tripper that writes the audit records for the requests that go through
+// it. It should be added before the retry wrapper, so that a request that is retried is written
+// only once.
+func (l *AuditLog) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &auditRoundTripper{
+		log:       l,
+		transport: transport,
+	}
+}
+
+// Write appends a record to the file.
+func (l *AuditLog) Write(record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+type auditRoundTripper struct {
+	log       *AuditLog
+	transport http.RoundTripper
+}
+
+func (t *auditRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.transport.RoundTrip(request)
+	}
+	if !strings.HasPrefix(request.URL.Path, "/api/") {
+		return t.transport.RoundTrip(request)
+	}
+	ctx := request.Context()
+
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	// Updates only contain the fields that change, so the current object is needed to know
+	// what they are changed from:
+	var current interface{}
+	if request.Method == http.MethodPatch {
+		current = t.current(request)
+	}
+
+	record := &AuditRecord{
+		Timestamp: t.log.now().UTC(),
+		Method:    request.Method,
+		Path:      request.URL.Path,
+	}
+	record.ResourceType, record.ResourceAddress = auditResource(request.URL.Path)
+	if len(body) > 0 {
+		var sent interface{}
+		if json.Unmarshal(body, &sent) == nil {
+			record.Changes = auditChanges(current, sent)
+		}
+	}
+
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.Status = response.StatusCode
+		record.OperationID = response.Header.Get(OperationIDHeader)
+		if request.Method == http.MethodPost || record.OperationID == "" {
+			response.Body, record.OperationID, record.ResourceAddress = auditResponse(
+				response.Body, record.OperationID, record.ResourceAddress, request.Method,
+			)
+		}
+	}
+
+	writeErr := t.log.Write(record)
+	if writeErr != nil {
+		tflog.Error(ctx, fmt.Sprintf("Can't write audit record for request %s %s: %v",
+			request.Method, request.URL.Path, writeErr))
+	}
+	return response, err
+}
+
+// current fetches the object that is going to be changed by the given request. It returns nil
+// if the object can't be fetched.
+func (t *auditRoundTripper) current(request *http.Request) interface{} {
+	get, err := http.NewRequestWithContext(request.Context(), http.MethodGet, request.URL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	get.Header = request.Header.Clone()
+	get.Header.Del("Content-Type")
+	get.Header.Del("Content-Length")
+	response, err := t.transport.RoundTrip(get)
+	if err != nil {
+		return nil
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, response.Body)
+		return nil
+	}
+	var result interface{}
+	if json.NewDecoder(response.Body).Decode(&result) != nil {
+		return nil
+	}
+	return result
+}
+
+// auditResponse reads the body of the response to find the identifier of the created object and,
+// for errors, the operation identifier. It returns a new body with the same content.
+func auditResponse(body io.ReadCloser, operationID, address, method string) (io.ReadCloser, string, string) {
+	data, err := io.ReadAll(body)
+	body.Close()
+	result := io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return result, operationID, address
+	}
+	var object struct {
+		ID          string `json:"id"`
+		Kind        string `json:"kind"`
+		OperationID string `json:"operation_id"`
+	}
+	if json.Unmarshal(data, &object) != nil {
+		return result, operationID, address
+	}
+	if operationID == "" {
+		operationID = object.OperationID
+	}
+	if method == http.MethodPost && object.ID != "" && object.Kind != "Error" &&
+		!strings.HasSuffix(address, "/"+object.ID) {
+		address = address + "/" + object.ID
+	}
+	return result, operationID, address
+}
+
+// auditResource calculates the kind and the address of the object from the path of the request,
+// for example `/api/clusters_mgmt/v1/clusters/123/machine_pools/workers` is a `machine_pool` with
+// address `clusters/123/machine_pools/workers`.
+func auditResource(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	// Remove the `api`, service and version prefix:
+	if len(segments) > 3 {
+		segments = segments[3:]
+	}
+	address := strings.Join(segments, "/")
+	kind := segments[len(segments)-1]
+	if len(segments)%2 == 0 {
+		kind = segments[len(segments)-2]
+	}
+	return singular(kind), address
+}
+
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"):
+		return name
+	default:
+		return strings.TrimSuffix(name, "s")
+	}
+}
+
+// auditChanges compares the fields sent in a request with the current values of the object.
+// Nested objects are compared field by field, lists as a whole.
+func auditChanges(current, sent interface{}) []AuditChange {
+	changes := []AuditChange{}
+	collectChanges("", false, current, sent, &changes)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func collectChanges(path string, secret bool, current, sent interface{}, changes *[]AuditChange) {
+	sentMap, ok := sent.(map[string]interface{})
+	if ok {
+		currentMap, _ := current.(map[string]interface{})
+		for key, value := range sentMap {
+			child := key
+			if path != "" {
+				child = path + "." + key
+			}
+			collectChanges(child, secret || IsSecretKey(key), currentMap[key], value, changes)
+		}
+		return
+	}
+	if reflect.DeepEqual(current, sent) {
+		return
+	}
+	change := AuditChange{
+		Path: path,
+		Old:  current,
+		New:  sent,
+	}
+	if secret {
+		if change.Old != nil {
+			change.Old = Redacted
+		}
+		change.New = Redacted
+	} else {
+		change.Old = RedactValue(change.Old)
+		change.New = RedactValue(change.New)
+	}
+	*changes = append(*changes, change)
+}
@@ -0,0 +1,132 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit log", func() {
+	var (
+		server  *httptest.Server
+		handler http.HandlerFunc
+		file    string
+		client  *http.Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r)
+		}))
+		file = filepath.Join(GinkgoT().TempDir(), "audit.jsonl")
+		log, err := NewAuditLog(file)
+		Expect(err).ToNot(HaveOccurred())
+		log.now = func() time.Time { return time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC) }
+		client = &http.Client{Transport: log.Wrap(http.DefaultTransport)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(method, path, body string) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+	}
+
+	records := func() []AuditRecord {
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		result := []AuditRecord{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			if line == "" {
+				continue
+			}
+			record := AuditRecord{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			result = append(result, record)
+		}
+		return result
+	}
+
+	It("Writes the created objects with their identifier and redacted secrets", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(OperationIDHeader, "op-1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, `{"kind": "IdentityProvider", "id": "idp-1"}`)
+		}
+		send(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/identity_providers",
+			`{"name": "htpasswd", "htpasswd": {"users": {"items": [{"username": "admin", "password": "secret"}]}}}`)
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		record := result[0]
+		Expect(record.Timestamp).To(Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+		Expect(record.ResourceType).To(Equal("identity_provider"))
+		Expect(record.ResourceAddress).To(Equal("clusters/123/identity_providers/idp-1"))
+		Expect(record.Method).To(Equal(http.MethodPost))
+		Expect(record.Path).To(Equal("/api/clusters_mgmt/v1/clusters/123/identity_providers"))
+		Expect(record.OperationID).To(Equal("op-1"))
+		Expect(record.Status).To(Equal(http.StatusCreated))
+		Expect(record.Changes).To(HaveLen(2))
+		Expect(record.Changes[0].Path).To(Equal("htpasswd.users.items"))
+		Expect(record.Changes[0].New).To(Equal([]interface{}{
+			map[string]interface{}{"username": "admin", "password": Redacted},
+		}))
+		Expect(record.Changes[1]).To(Equal(AuditChange{Path: "name", New: "htpasswd"}))
+	})
+
+	It("Writes the previous values of the updated fields", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, `{"id": "workers", "replicas": 2, "labels": {"a": "b"}}`)
+				return
+			}
+			w.Header().Set(OperationIDHeader, "op-2")
+			_, _ = io.WriteString(w, `{"id": "workers", "replicas": 3, "labels": {"a": "b"}}`)
+		}
+		send(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123/machine_pools/workers",
+			`{"replicas": 3, "labels": {"a": "b"}}`)
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].ResourceType).To(Equal("machine_pool"))
+		Expect(result[0].ResourceAddress).To(Equal("clusters/123/machine_pools/workers"))
+		Expect(result[0].Changes).To(Equal([]AuditChange{{Path: "replicas", Old: 2.0, New: 3.0}}))
+	})
+
+	It("Takes the operation identifier of errors from the body", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"kind": "Error", "id": "400", "operation_id": "op-3"}`)
+		}
+		send(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/ingresses/abc", "")
+
+		result := records()
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].ResourceType).To(Equal("ingress"))
+		Expect(result[0].ResourceAddress).To(Equal("clusters/123/ingresses/abc"))
+		Expect(result[0].OperationID).To(Equal("op-3"))
+		Expect(result[0].Status).To(Equal(http.StatusBadRequest))
+		Expect(result[0].Changes).To(BeEmpty())
+	})
+
+	It("Ignores read only and token requests", func() {
+		handler = func(w http.ResponseWriter, r *http.Request) {}
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "")
+		send(http.MethodPost, "/auth/realms/redhat-external/protocol/openid-connect/token", "client_secret=x")
+		Expect(records()).To(BeEmpty())
+	})
+})
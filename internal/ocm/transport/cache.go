@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultCacheTTL is how long responses are cached when no other value is given.
+const DefaultCacheTTL = 1 * time.Minute
+
+// cacheablePathREs matches the paths of the read mostly resources whose responses are cached.
+var cacheablePathREs = []*regexp.Regexp{
+	regexp.MustCompile(`^/api/clusters_mgmt/v1/versions(/[^/]+)?$`),
+	regexp.MustCompile(`^/api/clusters_mgmt/v1/machine_types$`),
+	regexp.MustCompile(`^/api/clusters_mgmt/v1/cloud_providers(/[^/]+(/regions(/[^/]+)?)?)?$`),
+	regexp.MustCompile(`^/api/clusters_mgmt/v1/clusters/[^/]+$`),
+}
+
+// clusterPathRE extracts the path of the cluster affected by a request.
+var clusterPathRE = regexp.MustCompile(`^/api/clusters_mgmt/v1/clusters/[^/]+`)
+
+// Cache keeps the successful responses of the GET requests for versions, machine types, cloud
+// providers, regions and clusters for a limited time, so that the resources and data sources that
+// share a connection don't send the same requests again and again during a plan.
+//
+// Requests that modify a cluster, or anything inside it, remove the cached cluster. Requests with
+// the `Cache-Control: no-cache` header always go to the server, and update the cache.
+type Cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	path      string
+	status    int
+	header    http.Header
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewCache creates a cache that keeps the responses for the given time. A zero time disables the
+// cache.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]*cacheEntry{},
+	}
+}
+
+// Wrap returns a round tripper that serves the cacheable requests from the cache. All the round
+// trippers created by the same cache share the cached responses.
+func (c *Cache) Wrap(transport http.RoundTripper) http.RoundTripper {
+	if c.ttl <= 0 {
+		return transport
+	}
+	return &cacheRoundTripper{
+		cache:     c,
+		transport: transport,
+	}
+}
+
+// Purge removes all the cached responses.
+func (c *Cache) Purge() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = map[string]*cacheEntry{}
+}
+
+func (c *Cache) get(key string) *cacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry
+}
+
+func (c *Cache) put(key string, entry *cacheEntry) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now()
+	for k, v := range c.entries {
+		if !now.Before(v.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	entry.expiresAt = now.Add(c.ttl)
+	c.entries[key] = entry
+}
+
+// invalidate removes the cached responses for the given path and everything below it.
+func (c *Cache) invalidate(path string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for k, v := range c.entries {
+		if v.path == path || strings.HasPrefix(v.path, path+"/") {
+			delete(c.entries, k)
+		}
+	}
+}
+
+type cacheRoundTripper struct {
+	cache     *Cache
+	transport http.RoundTripper
+}
+
+func (t *cacheRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet {
+		clusterPath := clusterPathRE.FindString(request.URL.Path)
+		if clusterPath == "" || request.Method == http.MethodHead || request.Method == http.MethodOptions {
+			return t.transport.RoundTrip(request)
+		}
+		// The cluster is removed also after the change, in case it was read again while the
+		// change was in progress:
+		t.cache.invalidate(clusterPath)
+		defer t.cache.invalidate(clusterPath)
+		return t.transport.RoundTrip(request)
+	}
+	if !isCacheable(request.URL.Path) {
+		return t.transport.RoundTrip(request)
+	}
+
+	key := request.URL.String()
+	if request.Header.Get("Cache-Control") != "no-cache" {
+		if entry := t.cache.get(key); entry != nil {
+			tflog.Debug(request.Context(), "Serving OCM response from cache", map[string]interface{}{
+				"url": key,
+			})
+			return entry.response(request), nil
+		}
+	}
+
+	response, err := t.transport.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{
+		path:   request.URL.Path,
+		status: response.StatusCode,
+		header: response.Header.Clone(),
+		body:   body,
+	}
+	t.cache.put(key, entry)
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return response, nil
+}
+
+func (e *cacheEntry) response(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       request,
+	}
+}
+
+func isCacheable(path string) bool {
+	for _, re := range cacheablePathREs {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
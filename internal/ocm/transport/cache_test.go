@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		server   *httptest.Server
+		requests int32
+		status   int
+		cache    *Cache
+		client   *http.Client
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count := atomic.AddInt32(&requests, 1)
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, r.Method+" "+r.URL.Path+" "+string(rune('0'+count)))
+		}))
+		now = time.Unix(0, 0)
+		cache = NewCache(time.Minute)
+		cache.now = func() time.Time { return now }
+		client = &http.Client{Transport: cache.Wrap(http.DefaultTransport)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(method, path string, header ...string) string {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+		for i := 0; i < len(header); i += 2 {
+			request.Header.Set(header[i], header[i+1])
+		}
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return string(body)
+	}
+
+	It("Serves repeated lookups from the cache till they expire", func() {
+		first := send(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.15.1")
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.15.1")).To(Equal(first))
+		Expect(requests).To(BeEquivalentTo(1))
+
+		now = now.Add(time.Minute)
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/versions/openshift-v4.15.1")).ToNot(Equal(first))
+		Expect(requests).To(BeEquivalentTo(2))
+	})
+
+	It("Takes the query into account", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=1")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=2")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions?page=1")
+		Expect(requests).To(BeEquivalentTo(2))
+	})
+
+	It("Doesn't cache other resources or failed requests", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools")
+		Expect(requests).To(BeEquivalentTo(2))
+
+		status = http.StatusNotFound
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/456")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/456")
+		Expect(requests).To(BeEquivalentTo(4))
+	})
+
+	It("Forgets a cluster when it or its parts are modified", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/456")
+		send(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/machine_pools")
+		Expect(requests).To(BeEquivalentTo(3))
+
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123")).To(HaveSuffix("4"))
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/456")).To(HaveSuffix("2"))
+	})
+
+	It("Goes to the server when asked not to use the cache", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123")
+		body := send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "Cache-Control", "no-cache")
+		Expect(body).To(HaveSuffix("2"))
+		Expect(send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123")).To(Equal(body))
+	})
+
+	It("Returns responses that can be read by several clients", func() {
+		send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types")
+		first := send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types")
+		second := send(http.MethodGet, "/api/clusters_mgmt/v1/machine_types")
+		Expect(first).To(Equal(second))
+		Expect(strings.HasPrefix(first, "GET ")).To(BeTrue())
+	})
+	It("Doesn't wrap the transport when the time is zero", func() {
+		Expect(NewCache(0).Wrap(http.DefaultTransport)).To(BeIdenticalTo(http.DefaultTransport))
+	})
+})
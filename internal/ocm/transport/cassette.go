@@ -0,0 +1,431 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Modes of a cassette:
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+// CassetteModes are the valid modes of a cassette.
+var CassetteModes = []string{CassetteRecord, CassetteReplay}
+
+// tokenPathSuffix is the end of the path of the requests sent to obtain tokens.
+const tokenPathSuffix = "/protocol/openid-connect/token"
+
+// Interaction is a request sent by the provider and the response that it returned, as saved in a
+// cassette. Secrets in the bodies are redacted.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of the request that is used to find the interaction in replay mode.
+// For the requests sent to OCM the URL contains only the path and the query, so the cassette can
+// be replayed against any server. For the requests sent to other services it is the complete URL.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response returned for a recorded request.
+type RecordedResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body,omitempty"`
+
+	// Certificates are the base64 encoded DER certificates presented by services other than
+	// OCM, because the provider uses them, for example to calculate the thumbprint of the OIDC
+	// endpoint of a cluster.
+	Certificates []string `json:"certificates,omitempty"`
+}
+
+type cassetteFile struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// recordedHeaders are the response headers saved in the cassette. The rest are discarded because
+// they aren't used by the provider and may identify the user.
+var recordedHeaders = []string{"Content-Type", OperationIDHeader}
+
+// Cassette records the requests sent to OCM and to other services, and their responses, into a
+// file, and later replays them without network access.
+//
+// In replay mode each request is answered with the next recorded interaction that has the same
+// method, URL and body. When the interactions of a read only request are exhausted the last one
+// is repeated, as the provider may poll more times than during the recording. Requests without
+// any matching interaction fail, so that differences with the recording aren't hidden.
+//
+// Requests sent to obtain tokens aren't recorded. In replay mode they are answered with tokens
+// that are accepted by the provider but not by any server.
+type Cassette struct {
+	path         string
+	mode         string
+	lock         sync.Mutex
+	interactions []*Interaction
+	used         map[*Interaction]bool
+}
+
+// NewCassette creates a cassette that adds the interactions to the given file in record mode, or
+// that loads them from it in replay mode.
+func NewCassette(path, mode string) (*Cassette, error) {
+	c := &Cassette{
+		path: path,
+		mode: mode,
+		used: map[*Interaction]bool{},
+	}
+	switch mode {
+	case CassetteRecord:
+		// Terraform runs the provider several times for a single command, for example for the
+		// plan and for the apply, so the interactions are added to the ones already recorded:
+		_, err := os.Stat(path)
+		if err == nil {
+			err = c.load()
+		} else if os.IsNotExist(err) {
+			err = c.save()
+		}
+		if err != nil {
+			return nil, err
+		}
+	case CassetteReplay:
+		err := c.load()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cassette mode must be one of %s, got '%s'",
+			strings.Join(CassetteModes, ", "), mode)
+	}
+	return c, nil
+}
+
+func (c *Cassette) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("can't read cassette: %v", err)
+	}
+	file := &cassetteFile{}
+	err = json.Unmarshal(data, file)
+	if err != nil {
+		return fmt.Errorf("can't parse cassette '%s': %v", c.path, err)
+	}
+	c.interactions = file.Interactions
+	return nil
+}
+
+// Wrap returns a round tripper that records or replays the requests sent to OCM.
+func (c *Cassette) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &cassetteRoundTripper{
+		cassette:  c,
+		transport: transport,
+	}
+}
+
+// WrapExternal returns a round tripper that records or replays the requests sent to services
+// other than OCM. These are identified by their complete URL, and their certificates are saved
+// with the responses.
+func (c *Cassette) WrapExternal(transport http.RoundTripper) http.RoundTripper {
+	return &cassetteRoundTripper{
+		cassette:  c,
+		transport: transport,
+		external:  true,
+	}
+}
+
+// Unused returns the recorded interactions that haven't been replayed yet.
+func (c *Cassette) Unused() []*Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	result := []*Interaction{}
+	for _, interaction := range c.interactions {
+		if !c.used[interaction] {
+			result = append(result, interaction)
+		}
+	}
+	return result
+}
+
+type cassetteRoundTripper struct {
+	cassette  *Cassette
+	transport http.RoundTripper
+	external  bool
+}
+
+func (t *cassetteRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	isToken := !t.external && strings.HasSuffix(request.URL.Path, tokenPathSuffix)
+	if t.cassette.mode == CassetteRecord {
+		if isToken {
+			return t.transport.RoundTrip(request)
+		}
+		return t.record(request)
+	}
+	if isToken {
+		return replayTokens(request)
+	}
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	recorded := newRecordedRequest(request, body, t.external)
+	interaction := t.cassette.find(recorded)
+	if interaction == nil {
+		err = fmt.Errorf("no interaction recorded in cassette '%s' matches request %s %s",
+			t.cassette.path, recorded.Method, recorded.URL)
+		tflog.Error(request.Context(), err.Error(), map[string]interface{}{
+			"body": recorded.Body,
+		})
+		return nil, err
+	}
+	return interaction.Response.response(request)
+}
+
+func (t *cassetteRoundTripper) record(request *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	interaction := &Interaction{
+		Request: newRecordedRequest(request, body, t.external),
+		Response: RecordedResponse{
+			Status: response.StatusCode,
+			Header: map[string]string{},
+			Body:   redactBody(data),
+		},
+	}
+	for _, name := range recordedHeaders {
+		if value := response.Header.Get(name); value != "" {
+			interaction.Response.Header[name] = value
+		}
+	}
+	if t.external && response.TLS != nil {
+		for _, certificate := range response.TLS.PeerCertificates {
+			interaction.Response.Certificates = append(interaction.Response.Certificates,
+				base64.StdEncoding.EncodeToString(certificate.Raw))
+		}
+	}
+	err = t.cassette.add(interaction)
+	if err != nil {
+		tflog.Error(request.Context(), fmt.Sprintf("Can't save cassette: %v", err))
+	}
+	return response, nil
+}
+
+func (c *Cassette) add(interaction *Interaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	return c.saveLocked()
+}
+
+func (c *Cassette) save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.saveLocked()
+}
+
+// saveLocked writes all the interactions to the file, so that it is complete even if the
+// provider is stopped. The lock must be held.
+func (c *Cassette) saveLocked() error {
+	file := &cassetteFile{
+		Interactions: c.interactions,
+	}
+	if file.Interactions == nil {
+		file.Interactions = []*Interaction{}
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0600)
+}
+
+// find returns the next unused interaction for the request, or the last one for read only
+// requests when all of them have been used.
+func (c *Cassette) find(request RecordedRequest) *Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var last *Interaction
+	for _, interaction := range c.interactions {
+		if interaction.Request != request {
+			continue
+		}
+		if !c.used[interaction] {
+			c.used[interaction] = true
+			return interaction
+		}
+		last = interaction
+	}
+	if request.Method == http.MethodGet {
+		return last
+	}
+	return nil
+}
+
+// ServeHTTP answers the requests with the recorded interactions, so that a cassette can be used
+// as the handler of a test server.
+func (c *Cassette) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := readRequestBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	recorded := newRecordedRequest(r, body, false)
+	interaction := c.find(recorded)
+	if interaction == nil {
+		http.Error(w, fmt.Sprintf("no interaction recorded matches request %s %s",
+			recorded.Method, recorded.URL), http.StatusNotImplemented)
+		return
+	}
+	for name, value := range interaction.Response.Header {
+		w.Header().Set(name, value)
+	}
+	w.WriteHeader(interaction.Response.Status)
+	_, _ = io.WriteString(w, interaction.Response.Body)
+}
+
+func newRecordedRequest(request *http.Request, body []byte, external bool) RecordedRequest {
+	url := request.URL.RequestURI()
+	if external {
+		url = request.URL.String()
+	}
+	return RecordedRequest{
+		Method: request.Method,
+		URL:    url,
+		Body:   redactBody(body),
+	}
+}
+
+func (r RecordedResponse) response(request *http.Request) (*http.Response, error) {
+	header := http.Header{}
+	for name, value := range r.Header {
+		header.Set(name, value)
+	}
+	var state *tls.ConnectionState
+	if len(r.Certificates) > 0 {
+		state = &tls.ConnectionState{}
+		for _, encoded := range r.Certificates {
+			der, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("can't decode recorded certificate: %v", err)
+			}
+			certificate, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse recorded certificate: %v", err)
+			}
+			state.PeerCertificates = append(state.PeerCertificates, certificate)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		TLS:           state,
+		Request:       request,
+	}, nil
+}
+
+// readRequestBody reads the body of the request and replaces it with a copy, so that it can
+// still be sent.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// redactBody redacts the secrets of a JSON body. The result is compact, so that bodies that only
+// differ in the formatting are equal.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return string(body)
+	}
+	result, err := json.Marshal(RedactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(result)
+}
+
+// replayTokens answers a token request with tokens that expire in a day. They aren't signed, but
+// the provider doesn't verify the signatures, only the servers do.
+func replayTokens(request *http.Request) (*http.Response, error) {
+	expiresAt := time.Now().Add(24 * time.Hour).Unix()
+	token := func(typ string) string {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+		claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(
+			`{"typ":%q,"exp":%d,"iat":%d}`, typ, expiresAt, time.Now().Unix())))
+		return header + "." + claims + "."
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"access_token":  token("Bearer"),
+		"refresh_token": token("Refresh"),
+		"token_type":    "bearer",
+		"expires_in":    24 * 60 * 60,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return RecordedResponse{
+		Status: http.StatusOK,
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   string(body),
+	}.response(request)
+}
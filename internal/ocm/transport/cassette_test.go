@@ -0,0 +1,181 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cassette", func() {
+	var (
+		server *httptest.Server
+		file   string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(OperationIDHeader, "op-1")
+			w.Header().Set("Set-Cookie", "session=abc")
+			switch {
+			case strings.HasSuffix(r.URL.Path, tokenPathSuffix):
+				_, _ = io.WriteString(w, `{"access_token": "real"}`)
+			case r.Method == http.MethodPost:
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write(body)
+			default:
+				_, _ = io.WriteString(w, `{"id": "123", "state": "installing", "kubeconfig": "secret"}`)
+			}
+		}))
+		file = filepath.Join(GinkgoT().TempDir(), "cassette.json")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(client *http.Client, method, url, body string) (*http.Response, string, error) {
+		request, err := http.NewRequest(method, url, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		response, err := client.Do(request)
+		if err != nil {
+			return nil, "", err
+		}
+		defer response.Body.Close()
+		data, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		return response, string(data), nil
+	}
+
+	record := func() {
+		cassette, err := NewCassette(file, CassetteRecord)
+		Expect(err).ToNot(HaveOccurred())
+		client := &http.Client{Transport: cassette.Wrap(http.DefaultTransport)}
+		_, _, err = send(client, http.MethodPost, server.URL+"/auth/realms/redhat-external"+tokenPathSuffix, "")
+		Expect(err).ToNot(HaveOccurred())
+		_, body, err := send(client, http.MethodPost, server.URL+"/api/clusters_mgmt/v1/clusters/123/identity_providers",
+			`{"htpasswd": {"users": {"items": [{"username": "admin", "password": "hunter2"}]}}}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(ContainSubstring("hunter2"))
+		_, body, err = send(client, http.MethodGet, server.URL+"/api/clusters_mgmt/v1/clusters/123", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(ContainSubstring(`"kubeconfig": "secret"`))
+	}
+
+	It("Records the interactions with the secrets redacted", func() {
+		record()
+		data, err := os.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).ToNot(ContainSubstring("hunter2"))
+		Expect(string(data)).ToNot(ContainSubstring("secret"))
+		Expect(string(data)).ToNot(ContainSubstring("session"))
+		Expect(string(data)).ToNot(ContainSubstring("real"))
+
+		recorded := &cassetteFile{}
+		Expect(json.Unmarshal(data, recorded)).To(Succeed())
+		Expect(recorded.Interactions).To(HaveLen(2))
+		Expect(recorded.Interactions[1].Request).To(Equal(RecordedRequest{
+			Method: http.MethodGet,
+			URL:    "/api/clusters_mgmt/v1/clusters/123",
+		}))
+		Expect(recorded.Interactions[1].Response.Header).To(Equal(map[string]string{
+			"Content-Type":    "application/json",
+			OperationIDHeader: "op-1",
+		}))
+	})
+
+	It("Adds the interactions to an existing cassette", func() {
+		record()
+		record()
+		cassette, err := NewCassette(file, CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cassette.Unused()).To(HaveLen(4))
+	})
+
+	It("Replays the interactions without sending requests", func() {
+		record()
+		server.Close()
+
+		cassette, err := NewCassette(file, CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		client := &http.Client{Transport: cassette.Wrap(http.DefaultTransport)}
+
+		response, body, err := send(client, http.MethodPost, "https://sso.example.com/auth/realms/x"+tokenPathSuffix, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring("access_token"))
+
+		response, _, err = send(client, http.MethodPost, "https://api.example.com/api/clusters_mgmt/v1/clusters/123/identity_providers",
+			`{"htpasswd":{"users":{"items":[{"username":"admin","password":"other"}]}}}`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+		for i := 0; i < 3; i++ {
+			response, body, err = send(client, http.MethodGet, "https://api.example.com/api/clusters_mgmt/v1/clusters/123", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Header.Get(OperationIDHeader)).To(Equal("op-1"))
+			Expect(body).To(ContainSubstring(`"state":"installing"`))
+		}
+		Expect(cassette.Unused()).To(BeEmpty())
+	})
+
+	It("Fails the requests that weren't recorded", func() {
+		record()
+		cassette, err := NewCassette(file, CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		client := &http.Client{Transport: cassette.Wrap(http.DefaultTransport)}
+
+		_, _, err = send(client, http.MethodGet, server.URL+"/api/clusters_mgmt/v1/clusters/456", "")
+		Expect(err).To(MatchError(ContainSubstring("matches request GET /api/clusters_mgmt/v1/clusters/456")))
+
+		// Mutations are replayed only once:
+		body := `{"htpasswd":{"users":{"items":[{"username":"admin","password":"x"}]}}}`
+		_, _, err = send(client, http.MethodPost, server.URL+"/api/clusters_mgmt/v1/clusters/123/identity_providers", body)
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = send(client, http.MethodPost, server.URL+"/api/clusters_mgmt/v1/clusters/123/identity_providers", body)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Records the certificates of other services and replays them", func() {
+		tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "ok")
+		}))
+		defer tlsServer.Close()
+
+		cassette, err := NewCassette(file, CassetteRecord)
+		Expect(err).ToNot(HaveOccurred())
+		client := &http.Client{Transport: cassette.WrapExternal(tlsServer.Client().Transport)}
+		_, _, err = send(client, http.MethodGet, tlsServer.URL+"/keys", "")
+		Expect(err).ToNot(HaveOccurred())
+		tlsServer.Close()
+
+		cassette, err = NewCassette(file, CassetteReplay)
+		Expect(err).ToNot(HaveOccurred())
+		client = &http.Client{Transport: cassette.WrapExternal(http.DefaultTransport)}
+		response, body, err := send(client, http.MethodGet, tlsServer.URL+"/keys", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(body).To(Equal("ok"))
+		Expect(response.TLS).ToNot(BeNil())
+		Expect(response.TLS.PeerCertificates).To(HaveLen(1))
+		Expect(response.TLS.PeerCertificates[0].Raw).To(Equal(tlsServer.Certificate().Raw))
+
+		// Other services are identified by the complete URL:
+		_, _, err = send(client, http.MethodGet, "https://other.example.com/keys", "")
+		Expect(err).To(MatchError(ContainSubstring("matches request GET https://other.example.com/keys")))
+	})
+
+	It("Rejects unknown modes and missing cassettes", func() {
+		_, err := NewCassette(file, "play")
+		Expect(err).To(MatchError(ContainSubstring("must be one of record, replay")))
+		_, err = NewCassette(file, CassetteReplay)
+		Expect(err).To(MatchError(ContainSubstring("can't read cassette")))
+	})
+})
@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DryRun intercepts the requests that would create, change or delete something in OCM. They are
+// written to the log and answered with a synthesized success, while the read only requests are
+// sent to the server.
+//
+// The objects created, changed or deleted are remembered, so that reading them again returns
+// what the provider would see if the requests had been sent. Only the objects themselves are
+// remembered, lists returned by the server don't include them.
+type DryRun struct {
+	lock    sync.Mutex
+	objects map[string]map[string]interface{}
+	deleted map[string]bool
+	counter int
+}
+
+// NewDryRun creates an empty dry run.
+func NewDryRun() *DryRun {
+	return &DryRun{
+		objects: map[string]map[string]interface{}{},
+		deleted: map[string]bool{},
+	}
+}
+
+// Wrap returns a round tripper that intercepts the mutating requests. It should be the first
+// wrapper added, so that the cache and the audit log don't see the intercepted requests.
+func (d *DryRun) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &dryRunRoundTripper{
+		dryRun:    d,
+		transport: transport,
+	}
+}
+
+type dryRunRoundTripper struct {
+	dryRun    *DryRun
+	transport http.RoundTripper
+}
+
+func (t *dryRunRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Requests sent to obtain tokens, and requests that ask the server for its own dry run,
+	// don't change anything:
+	path := strings.TrimSuffix(request.URL.Path, "/")
+	if !strings.HasPrefix(path, "/api/") || request.URL.Query().Get("dryRun") == "true" {
+		return t.transport.RoundTrip(request)
+	}
+	switch request.Method {
+	case http.MethodGet:
+		return t.get(request, path)
+	case http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return t.mutate(request, path)
+	default:
+		return t.transport.RoundTrip(request)
+	}
+}
+
+func (t *dryRunRoundTripper) get(request *http.Request, path string) (*http.Response, error) {
+	t.dryRun.lock.Lock()
+	object, found := t.dryRun.objects[path]
+	deleted := t.dryRun.isDeleted(path)
+	t.dryRun.lock.Unlock()
+	switch {
+	case found:
+		return jsonResponse(request, http.StatusOK, object)
+	case deleted:
+		return jsonResponse(request, http.StatusNotFound, map[string]interface{}{
+			"kind":   "Error",
+			"id":     "404",
+			"reason": fmt.Sprintf("Object '%s' was deleted in dry run mode", path),
+		})
+	default:
+		return t.transport.RoundTrip(request)
+	}
+}
+
+func (t *dryRunRoundTripper) mutate(request *http.Request, path string) (*http.Response, error) {
+	ctx := request.Context()
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var sent map[string]interface{}
+	if len(body) > 0 {
+		_ = json.Unmarshal(body, &sent)
+	}
+
+	fields := map[string]interface{}{
+		"method": request.Method,
+		"path":   path,
+	}
+	if sent != nil {
+		redacted, _ := json.Marshal(RedactValue(sent))
+		fields["body"] = string(redacted)
+	} else if len(body) > 0 {
+		fields["body"] = string(body)
+	}
+	tflog.Info(ctx, "Dry run, the request isn't sent to OCM", fields)
+
+	switch request.Method {
+	case http.MethodDelete:
+		t.dryRun.lock.Lock()
+		t.dryRun.remove(path)
+		t.dryRun.lock.Unlock()
+		return jsonResponse(request, http.StatusNoContent, nil)
+	case http.MethodPost:
+		object := t.dryRun.create(path, sent)
+		return jsonResponse(request, http.StatusCreated, object)
+	default:
+		current := t.current(request, path)
+		object := mergeObjects(current, sent)
+		t.dryRun.lock.Lock()
+		t.dryRun.objects[path] = object
+		t.dryRun.lock.Unlock()
+		return jsonResponse(request, http.StatusOK, object)
+	}
+}
+
+// current returns the object that a request changes, either the one remembered or the one
+// returned by the server.
+func (t *dryRunRoundTripper) current(request *http.Request, path string) map[string]interface{} {
+	t.dryRun.lock.Lock()
+	object, found := t.dryRun.objects[path]
+	t.dryRun.lock.Unlock()
+	if found {
+		return object
+	}
+	get, err := http.NewRequestWithContext(request.Context(), http.MethodGet, request.URL.String(), nil)
+	if err != nil {
+		return nil
+	}
+	get.Header = request.Header.Clone()
+	get.Header.Del("Content-Type")
+	get.Header.Del("Content-Length")
+	response, err := t.transport.RoundTrip(get)
+	if err != nil {
+		return nil
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, response.Body)
+		return nil
+	}
+	var result map[string]interface{}
+	if json.NewDecoder(response.Body).Decode(&result) != nil {
+		return nil
+	}
+	return result
+}
+
+// create remembers the object sent to a collection, completing it with the fields that the
+// server would add.
+func (d *DryRun) create(collection string, sent map[string]interface{}) map[string]interface{} {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	object := mergeObjects(nil, sent)
+	id, _ := object["id"].(string)
+	if id == "" {
+		d.counter++
+		id = fmt.Sprintf("dry-run-%d", d.counter)
+		object["id"] = id
+	}
+	path := collection + "/" + id
+	object["href"] = path
+	if _, ok := object["kind"]; !ok {
+		object["kind"] = dryRunKind(collection)
+	}
+	// Clusters are reported as ready, so that the waiters finish:
+	if strings.HasSuffix(collection, "/clusters") {
+		object["state"] = "ready"
+	}
+	delete(d.deleted, path)
+	d.objects[path] = object
+	return object
+}
+
+// remove forgets the object and everything inside it, and remembers that it was deleted. The
+// lock must be held.
+func (d *DryRun) remove(path string) {
+	for key := range d.objects {
+		if key == path || strings.HasPrefix(key, path+"/") {
+			delete(d.objects, key)
+		}
+	}
+	d.deleted[path] = true
+}
+
+// isDeleted checks if the object or any of its parents was deleted. The lock must be held.
+func (d *DryRun) isDeleted(path string) bool {
+	for deleted := range d.deleted {
+		if path == deleted || strings.HasPrefix(path, deleted+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunKind calculates the kind of the objects of a collection, for example `MachinePool` for
+// `/api/clusters_mgmt/v1/clusters/123/machine_pools`.
+func dryRunKind(collection string) string {
+	name := singular(collection[strings.LastIndex(collection, "/")+1:])
+	result := ""
+	for _, word := range strings.Split(name, "_") {
+		if word != "" {
+			result += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return result
+}
+
+// mergeObjects returns a new object with the fields of the current object replaced by the ones
+// that were sent. Nested objects are merged field by field.
+func mergeObjects(current, sent map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(current)+len(sent))
+	for k, v := range current {
+		result[k] = v
+	}
+	for k, v := range sent {
+		sentMap, sentIsMap := v.(map[string]interface{})
+		currentMap, currentIsMap := result[k].(map[string]interface{})
+		if sentIsMap && currentIsMap {
+			result[k] = mergeObjects(currentMap, sentMap)
+		} else if sentIsMap {
+			result[k] = mergeObjects(nil, sentMap)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func jsonResponse(request *http.Request, status int, object interface{}) (*http.Response, error) {
+	var body []byte
+	if object != nil {
+		var err error
+		body, err = json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+	}
+	header := http.Header{}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dry run", func() {
+	var (
+		server   *httptest.Server
+		received []string
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = append(received, r.Method+" "+r.URL.RequestURI())
+			switch r.URL.Path {
+			case "/api/clusters_mgmt/v1/clusters/123":
+				_, _ = io.WriteString(w, `{"id": "123", "name": "mycluster", "aws": {"tags": {"a": "b"}}}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		client = &http.Client{Transport: NewDryRun().Wrap(http.DefaultTransport)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(method, path, body string) (int, map[string]interface{}) {
+		request, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+		data, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		var object map[string]interface{}
+		if len(data) > 0 {
+			Expect(json.Unmarshal(data, &object)).To(Succeed())
+		}
+		return response.StatusCode, object
+	}
+
+	It("Synthesizes the created objects and returns them when read", func() {
+		status, object := send(http.MethodPost, "/api/clusters_mgmt/v1/clusters", `{"name": "new"}`)
+		Expect(status).To(Equal(http.StatusCreated))
+		Expect(object).To(HaveKeyWithValue("id", "dry-run-1"))
+		Expect(object).To(HaveKeyWithValue("kind", "Cluster"))
+		Expect(object).To(HaveKeyWithValue("state", "ready"))
+
+		status, object = send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/dry-run-1", "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(object).To(HaveKeyWithValue("name", "new"))
+		Expect(received).To(BeEmpty())
+	})
+
+	It("Keeps the identifiers sent by the provider", func() {
+		_, object := send(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/machine_pools", `{"id": "workers"}`)
+		Expect(object).To(HaveKeyWithValue("id", "workers"))
+		Expect(object).To(HaveKeyWithValue("kind", "MachinePool"))
+		Expect(object).To(HaveKeyWithValue("href", "/api/clusters_mgmt/v1/clusters/123/machine_pools/workers"))
+	})
+
+	It("Merges the changes into the object of the server", func() {
+		status, object := send(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/123", `{"aws": {"tags": {"c": "d"}}}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(object).To(HaveKeyWithValue("name", "mycluster"))
+		Expect(object["aws"]).To(Equal(map[string]interface{}{
+			"tags": map[string]interface{}{"a": "b", "c": "d"},
+		}))
+		Expect(received).To(Equal([]string{"GET /api/clusters_mgmt/v1/clusters/123"}))
+	})
+
+	It("Reports the deleted objects as not found", func() {
+		status, _ := send(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123", "")
+		Expect(status).To(Equal(http.StatusNoContent))
+		status, _ = send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+		status, _ = send(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/machine_pools/workers", "")
+		Expect(status).To(Equal(http.StatusNotFound))
+		Expect(received).To(BeEmpty())
+	})
+
+	It("Sends the requests that don't change anything", func() {
+		send(http.MethodPost, "/api/clusters_mgmt/v1/clusters/123/upgrade_policies?dryRun=true", `{}`)
+		send(http.MethodPost, "/auth/realms/redhat-external/protocol/openid-connect/token", "")
+		send(http.MethodGet, "/api/clusters_mgmt/v1/versions", "")
+		Expect(received).To(Equal([]string{
+			"POST /api/clusters_mgmt/v1/clusters/123/upgrade_policies?dryRun=true",
+			"POST /auth/realms/redhat-external/protocol/openid-connect/token",
+			"GET /api/clusters_mgmt/v1/versions",
+		}))
+	})
+})
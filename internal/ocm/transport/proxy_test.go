@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proxy", func() {
+	It("Rejects invalid settings", func() {
+		Expect(Proxy{URL: "socks5://proxy.example.com"}.Validate()).To(
+			MatchError(ContainSubstring("'http' or 'https' scheme")))
+		Expect(Proxy{URL: "http://"}.Validate()).To(
+			MatchError(ContainSubstring("doesn't contain a host name")))
+		Expect(Proxy{CABundle: "junk"}.Validate()).To(
+			MatchError(ContainSubstring("doesn't contain any certificate")))
+		Expect(Proxy{URL: "https://proxy.example.com:3128"}.Validate()).To(Succeed())
+	})
+
+	It("Sends the requests through the proxy with its credentials", func() {
+		var host, authorization string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host = r.URL.Host
+			authorization = r.Header.Get("Proxy-Authorization")
+		}))
+		defer proxy.Close()
+
+		client, err := NewProxyClient(Proxy{URL: "http://user:secret@" + proxy.Listener.Addr().String()})
+		Expect(err).ToNot(HaveOccurred())
+		response, err := client.Get("http://api.openshift.example.com/api")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(host).To(Equal("api.openshift.example.com"))
+		Expect(authorization).To(Equal("Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))))
+	})
+
+	It("Skips the proxy for the hosts in the no proxy list", func() {
+		proxy := Proxy{
+			URL:     "http://proxy.example.com:3128",
+			NoProxy: []string{".internal.example.com", "10.0.0.0/8"},
+		}
+		proxyFunc := proxy.proxyFunc()
+		selected := func(address string) string {
+			request, err := http.NewRequest(http.MethodGet, address, nil)
+			Expect(err).ToNot(HaveOccurred())
+			proxyURL, err := proxyFunc(request)
+			Expect(err).ToNot(HaveOccurred())
+			if proxyURL == nil {
+				return ""
+			}
+			return proxyURL.Host
+		}
+		Expect(selected("https://api.openshift.com")).To(Equal("proxy.example.com:3128"))
+		Expect(selected("https://sso.internal.example.com")).To(BeEmpty())
+		Expect(selected("https://10.1.2.3")).To(BeEmpty())
+	})
+
+	It("Trusts the certificates of the CA bundle", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		client, err := NewProxyClient(Proxy{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = client.Get(server.URL)
+		Expect(err).To(HaveOccurred())
+
+		client, err = NewProxyClient(Proxy{CABundle: string(bundle)})
+		Expect(err).ToNot(HaveOccurred())
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("Leaves transports that it doesn't know unchanged", func() {
+		wrapped := &failingRoundTripper{}
+		wrapper := NewProxyWrapper(Proxy{URL: "http://proxy.example.com:3128"})
+		Expect(wrapper(wrapped)).To(BeIdenticalTo(wrapped))
+
+		transport := &http.Transport{}
+		result := wrapper(transport)
+		Expect(result).ToNot(BeIdenticalTo(transport))
+		Expect(result.(*http.Transport).Proxy).ToNot(BeNil())
+		Expect(transport.Proxy).To(BeNil())
+	})
+})
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit describes the client side limits applied to the requests sent to OCM. Zero values
+// disable the corresponding limit.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the token bucket is refilled.
+	RequestsPerSecond float64
+
+	// Burst is the capacity of the token bucket. It defaults to one when the rate is set.
+	Burst int
+
+	// MaxInFlight is the maximum number of requests waiting for a response at the same time.
+	MaxInFlight int
+}
+
+// Validate checks that the values of the limits are acceptable.
+func (l RateLimit) Validate() error {
+	if l.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests per second must not be negative, got %v", l.RequestsPerSecond)
+	}
+	if l.Burst < 0 {
+		return fmt.Errorf("burst must not be negative, got %d", l.Burst)
+	}
+	if l.MaxInFlight < 0 {
+		return fmt.Errorf("max in flight requests must not be negative, got %d", l.MaxInFlight)
+	}
+	return nil
+}
+
+// NewRateLimitWrapper returns a transport wrapper that delays requests so that they don't exceed
+// the given limits. All the round trippers created by the wrapper share the same limits.
+func NewRateLimitWrapper(limit RateLimit) func(http.RoundTripper) http.RoundTripper {
+	var bucket *tokenBucket
+	if limit.RequestsPerSecond > 0 {
+		burst := limit.Burst
+		if burst < 1 {
+			burst = 1
+		}
+		bucket = newTokenBucket(limit.RequestsPerSecond, burst)
+	}
+	var slots chan struct{}
+	if limit.MaxInFlight > 0 {
+		slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return func(transport http.RoundTripper) http.RoundTripper {
+		return &rateLimitRoundTripper{
+			bucket:    bucket,
+			slots:     slots,
+			transport: transport,
+		}
+	}
+}
+
+type rateLimitRoundTripper struct {
+	bucket    *tokenBucket
+	slots     chan struct{}
+	transport http.RoundTripper
+}
+
+func (t *rateLimitRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if t.slots != nil {
+			<-t.slots
+		}
+	}
+	if t.bucket != nil {
+		if err := t.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	response, err := t.transport.RoundTrip(request)
+	if err != nil || response.Body == nil {
+		release()
+		return response, err
+	}
+
+	// The slot is kept till the caller is done reading the response:
+	response.Body = &releaseOnClose{ReadCloser: response.Body, release: release}
+	return response, nil
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// tokenBucket is a token bucket that is refilled at a constant rate.
+type tokenBucket struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// wait blocks till a token is available or the context is cancelled.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// take removes a token from the bucket and returns zero, or returns how long to wait till the
+// next token is available.
+func (b *tokenBucket) take() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate limit wrapper", func() {
+	It("Refills the token bucket at the configured rate", func() {
+		now := time.Unix(0, 0)
+		bucket := newTokenBucket(2, 2)
+		bucket.now = func() time.Time { return now }
+		bucket.last = now
+
+		Expect(bucket.take()).To(BeZero())
+		Expect(bucket.take()).To(BeZero())
+		Expect(bucket.take()).To(Equal(500 * time.Millisecond))
+
+		now = now.Add(500 * time.Millisecond)
+		Expect(bucket.take()).To(BeZero())
+		Expect(bucket.take()).To(Equal(500 * time.Millisecond))
+
+		// The bucket never holds more than the burst:
+		now = now.Add(time.Hour)
+		Expect(bucket.take()).To(BeZero())
+		Expect(bucket.take()).To(BeZero())
+		Expect(bucket.take()).ToNot(BeZero())
+	})
+
+	It("Stops waiting for a token when the context is cancelled", func() {
+		bucket := newTokenBucket(0.001, 1)
+		Expect(bucket.take()).To(BeZero())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(bucket.wait(ctx)).To(MatchError(context.Canceled))
+	})
+
+	It("Limits the number of requests in flight", func() {
+		var current, highest int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := atomic.AddInt32(&current, 1)
+			for {
+				old := atomic.LoadInt32(&highest)
+				if value <= old || atomic.CompareAndSwapInt32(&highest, old, value) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&current, -1)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewRateLimitWrapper(RateLimit{MaxInFlight: 2})(http.DefaultTransport),
+		}
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				response, err := client.Get(server.URL)
+				Expect(err).ToNot(HaveOccurred())
+				io.Copy(io.Discard, response.Body)
+				response.Body.Close()
+			}()
+		}
+		Eventually(func() int32 { return atomic.LoadInt32(&current) }).Should(Equal(int32(2)))
+		Consistently(func() int32 { return atomic.LoadInt32(&current) }, 100*time.Millisecond).Should(Equal(int32(2)))
+		close(release)
+		wg.Wait()
+		Expect(atomic.LoadInt32(&highest)).To(Equal(int32(2)))
+	})
+
+	It("Validates the limits", func() {
+		Expect(RateLimit{}.Validate()).To(Succeed())
+		Expect(RateLimit{RequestsPerSecond: -1}.Validate()).To(HaveOccurred())
+		Expect(RateLimit{MaxInFlight: -1}.Validate()).To(HaveOccurred())
+	})
+})
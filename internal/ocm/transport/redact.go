@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"strings"
+)
+
+// Redacted is the value that replaces secrets in the records written by the provider.
+const Redacted = "REDACTED"
+
+// secretKeySuffixes are the endings of the names of the JSON fields that contain secrets, like
+// `password`, `client_secret`, `pull_secret`, `access_token` or `private_key`.
+var secretKeySuffixes = []string{
+	"password",
+	"secret",
+	"token",
+	"private_key",
+	"kubeconfig",
+}
+
+// IsSecretKey returns true if the given JSON field name is known to contain secrets.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range secretKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue returns a copy of the given decoded JSON value where the values of the fields that
+// contain secrets have been replaced.
+func RedactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			if IsSecretKey(k) && v != nil {
+				result[k] = Redacted
+			} else {
+				result[k] = RedactValue(v)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, v := range typed {
+			result[i] = RedactValue(v)
+		}
+		return result
+	default:
+		return value
+	}
+}
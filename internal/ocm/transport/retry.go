@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry policy, equivalent to the one that the OCM SDK applies by itself:
+const (
+	DefaultRetryMaxAttempts     = 3
+	DefaultRetryInitialInterval = 1 * time.Second
+	DefaultRetryMaxInterval     = 30 * time.Second
+	DefaultRetryJitter          = 0.2
+)
+
+// DefaultRetryableStatusCodes are the HTTP status codes that are retried by default.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy describes how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a request is sent, including the first one.
+	MaxAttempts int
+
+	// InitialInterval is the time to wait before the first retry. It is doubled for each of the
+	// following retries, up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+
+	// Jitter is the factor used to randomize the intervals, between zero and one.
+	Jitter float64
+
+	// RetryableStatusCodes are the response codes that trigger a retry. Codes 429 and 503 mean
+	// that the server didn't process the request, so they are retried for any method, the rest
+	// only for idempotent methods.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          DefaultRetryMaxAttempts,
+		InitialInterval:      DefaultRetryInitialInterval,
+		MaxInterval:          DefaultRetryMaxInterval,
+		Jitter:               DefaultRetryJitter,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// Validate checks that the values of the policy are acceptable.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return fmt.Errorf("retry max attempts must be at least 1, got %d", p.MaxAttempts)
+	}
+	if p.InitialInterval <= 0 {
+		return fmt.Errorf("retry initial interval must be greater than zero, got %s", p.InitialInterval)
+	}
+	if p.MaxInterval < p.InitialInterval {
+		return fmt.Errorf("retry max interval %s must not be less than the initial interval %s",
+			p.MaxInterval, p.InitialInterval)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1, got %v", p.Jitter)
+	}
+	return nil
+}
+
+// NewRetryWrapper returns a transport wrapper that retries the requests according to the given
+// policy.
+func NewRetryWrapper(policy RetryPolicy) func(http.RoundTripper) http.RoundTripper {
+	return func(transport http.RoundTripper) http.RoundTripper {
+		return &retryRoundTripper{
+			policy:    policy,
+			transport: transport,
+			sleep:     sleepContext,
+		}
+	}
+}
+
+type retryRoundTripper struct {
+	policy    RetryPolicy
+	transport http.RoundTripper
+	sleep     func(ctx context.Context, duration time.Duration) error
+}
+
+func (t *retryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	// Keep a copy of the body so that it can be sent again:
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		if body != nil {
+			request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		response, err := t.transport.RoundTrip(request)
+		if attempt >= t.policy.MaxAttempts || !t.shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		interval := t.interval(attempt, response)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Request %s %s failed, will try again in %s: %v",
+				request.Method, request.URL.Path, interval, err))
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("Request %s %s failed with code %d, will try again in %s",
+				request.Method, request.URL.Path, response.StatusCode, interval))
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		if err := t.sleep(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *retryRoundTripper) shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if err != nil {
+		// A refused stream is never processed by the server, so it is safe to send it again
+		// whatever the method:
+		message := err.Error()
+		if strings.Contains(message, "REFUSED_STREAM") {
+			return true
+		}
+
+		// The rest of these errors may happen after the server received the request, for
+		// example when the connection is dropped before the response is sent, so sending a
+		// create again could create a duplicate object:
+		for _, transient := range []string{"EOF", "connection reset by peer", "PROTOCOL_ERROR"} {
+			if strings.Contains(message, transient) {
+				return isIdempotent(request)
+			}
+		}
+		return false
+	}
+	code := response.StatusCode
+	if !containsInt(t.policy.RetryableStatusCodes, code) {
+		return false
+	}
+	if code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable {
+		return true
+	}
+	return isIdempotent(request)
+}
+
+// isIdempotent checks if the request can be sent again without changing the result.
+func isIdempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// interval calculates the time to wait before the next attempt. The `Retry-After` header sent by
+// the server is honored when it asks for a longer wait, but never beyond the maximum interval.
+func (t *retryRoundTripper) interval(attempt int, response *http.Response) time.Duration {
+	interval := t.policy.InitialInterval << (attempt - 1)
+	if interval <= 0 || interval > t.policy.MaxInterval {
+		interval = t.policy.MaxInterval
+	}
+	factor := t.policy.Jitter * (1 - 2*rand.Float64())
+	interval += time.Duration(float64(interval) * factor)
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok && retryAfter > interval {
+			interval = retryAfter
+		}
+	}
+	if interval > t.policy.MaxInterval {
+		interval = t.policy.MaxInterval
+	}
+	return interval
+}
+
+// parseRetryAfter parses the value of the `Retry-After` header, that can contain either a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry wrapper", func() {
+	var (
+		server   *httptest.Server
+		codes    []int
+		requests []string
+		sleeps   []time.Duration
+		client   *http.Client
+		policy   RetryPolicy
+	)
+
+	BeforeEach(func() {
+		codes = nil
+		requests = nil
+		sleeps = nil
+		policy = DefaultRetryPolicy()
+		policy.Jitter = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, string(body))
+			code := http.StatusOK
+			if len(requests) <= len(codes) {
+				code = codes[len(requests)-1]
+			}
+			if code == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "7")
+			}
+			w.WriteHeader(code)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func() *http.Client {
+		wrapper := NewRetryWrapper(policy)(http.DefaultTransport).(*retryRoundTripper)
+		wrapper.sleep = func(ctx context.Context, duration time.Duration) error {
+			sleeps = append(sleeps, duration)
+			return nil
+		}
+		return &http.Client{Transport: wrapper}
+	}
+
+	It("Retries retryable codes with exponential backoff", func() {
+		codes = []int{http.StatusBadGateway, http.StatusGatewayTimeout}
+		client = newClient()
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(HaveLen(3))
+		Expect(sleeps).To(Equal([]time.Duration{1 * time.Second, 2 * time.Second}))
+	})
+
+	It("Gives up after the maximum number of attempts", func() {
+		codes = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+		client = newClient()
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(requests).To(HaveLen(3))
+	})
+
+	It("Honors the Retry-After header and resends the body", func() {
+		codes = []int{http.StatusTooManyRequests}
+		client = newClient()
+		response, err := client.Post(server.URL, "application/json", strings.NewReader(`{"a":1}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(Equal([]string{`{"a":1}`, `{"a":1}`}))
+		Expect(sleeps).To(Equal([]time.Duration{7 * time.Second}))
+	})
+
+	It("Doesn't retry non idempotent requests on gateway errors", func() {
+		codes = []int{http.StatusBadGateway}
+		client = newClient()
+		response, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	Context("Transport errors", func() {
+		var attempts int
+
+		newFailingClient := func(err error) *http.Client {
+			attempts = 0
+			failing := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				attempts++
+				return nil, err
+			})
+			wrapper := NewRetryWrapper(policy)(failing).(*retryRoundTripper)
+			wrapper.sleep = func(ctx context.Context, duration time.Duration) error {
+				return nil
+			}
+			return &http.Client{Transport: wrapper}
+		}
+
+		It("Retries idempotent requests when the connection is dropped", func() {
+			client = newFailingClient(errors.New("read: connection reset by peer"))
+			_, err := client.Get(server.URL)
+			Expect(err).To(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("Doesn't retry non idempotent requests when the connection is dropped", func() {
+			client = newFailingClient(io.EOF)
+			_, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+			Expect(err).To(HaveOccurred())
+			Expect(attempts).To(Equal(1))
+		})
+
+		It("Retries non idempotent requests when the stream is refused", func() {
+			client = newFailingClient(errors.New("stream error: stream ID 1; REFUSED_STREAM"))
+			_, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+			Expect(err).To(HaveOccurred())
+			Expect(attempts).To(Equal(3))
+		})
+	})
+
+	It("Doesn't retry codes that aren't configured", func() {
+		codes = []int{http.StatusInternalServerError}
+		client = newClient()
+		response, err := client.Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("Caps the interval at the maximum", func() {
+		policy.MaxInterval = 3 * time.Second
+		wrapper := NewRetryWrapper(policy)(http.DefaultTransport).(*retryRoundTripper)
+		Expect(wrapper.interval(5, nil)).To(Equal(3 * time.Second))
+		response := &http.Response{Header: http.Header{"Retry-After": []string{"60"}}}
+		Expect(wrapper.interval(1, response)).To(Equal(3 * time.Second))
+	})
+
+	It("Stops waiting when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(sleepContext(ctx, time.Hour)).To(MatchError(context.Canceled))
+	})
+
+	It("Validates the policy", func() {
+		Expect(DefaultRetryPolicy().Validate()).To(Succeed())
+		policy.MaxAttempts = 0
+		Expect(policy.Validate()).To(MatchError(ContainSubstring("max attempts")))
+		policy = DefaultRetryPolicy()
+		policy.MaxInterval = time.Millisecond
+		Expect(policy.Validate()).To(MatchError(ContainSubstring("max interval")))
+		policy = DefaultRetryPolicy()
+		policy.Jitter = 2
+		Expect(policy.Validate()).To(MatchError(ContainSubstring("jitter")))
+	})
+})
+
+// roundTripperFunc is an adapter that allows the use of ordinary functions as HTTP transports.
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
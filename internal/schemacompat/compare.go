@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Change is a backward incompatible change of a schema.
+type Change struct {
+	Kind   Kind
+	Schema string
+
+	// Path is the dot separated path of the changed attribute or block, for example
+	// `autoscaling.max_replicas`. It is empty for the changes of the schema itself, like its
+	// removal.
+	Path string
+
+	Message string
+}
+
+func (c Change) String() string {
+	if c.Path == "" {
+		return fmt.Sprintf("%s %s: %s", c.Kind, c.Schema, c.Message)
+	}
+	return fmt.Sprintf("%s %s: '%s' %s", c.Kind, c.Schema, c.Path, c.Message)
+}
+
+// AllowlistEntry accepts a backward incompatible change. The reason is mandatory, so that
+// reviewers know why breaking users is acceptable.
+type AllowlistEntry struct {
+	Kind   Kind   `json:"kind"`
+	Schema string `json:"schema"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// Allowlist is the list of backward incompatible changes that are accepted.
+type Allowlist []AllowlistEntry
+
+// LoadAllowlist loads the allowlist from the given JSON file. A missing file is an empty
+// allowlist.
+func LoadAllowlist(file string) (Allowlist, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return Allowlist{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := Allowlist{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist '%s': %v", file, err)
+	}
+	for i, entry := range result {
+		if entry.Kind != ResourceKind && entry.Kind != DataSourceKind {
+			return nil, fmt.Errorf("entry %d of allowlist '%s' has invalid kind '%s', should be '%s' or '%s'",
+				i, file, entry.Kind, ResourceKind, DataSourceKind)
+		}
+		if entry.Schema == "" || strings.TrimSpace(entry.Reason) == "" {
+			return nil, fmt.Errorf("entry %d of allowlist '%s' should have a schema and a reason", i, file)
+		}
+	}
+	return result, nil
+}
+
+func (a Allowlist) allows(change Change) bool {
+	for _, entry := range a {
+		if entry.Kind == change.Kind && entry.Schema == change.Schema && entry.Path == change.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns the backward incompatible changes from the old snapshot to the current one that
+// aren't accepted. A change is accepted when it is in the allowlist, or when the version of the
+// changed schema was increased, as that means that the resource comes with a state upgrader.
+func Check(old, current Snapshot, allowlist Allowlist) []Change {
+	result := []Change{}
+	for _, kind := range Kinds {
+		names := make([]string, 0, len(old[kind]))
+		for name := range old[kind] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			oldSchema := old[kind][name]
+			currentSchema, ok := current[kind][name]
+			changes := []Change{}
+			switch {
+			case !ok:
+				changes = append(changes, Change{Message: "was removed"})
+			case currentSchema.Version < oldSchema.Version:
+				changes = append(changes, Change{Message: fmt.Sprintf(
+					"version was decreased from %d to %d", oldSchema.Version, currentSchema.Version,
+				)})
+			case currentSchema.Version > oldSchema.Version:
+				// The state upgraders take care of the changes.
+			default:
+				changes = compareContent(oldSchema.Attributes, currentSchema.Attributes,
+					oldSchema.Blocks, currentSchema.Blocks, "")
+			}
+			for _, change := range changes {
+				change.Kind = kind
+				change.Schema = name
+				if !allowlist.allows(change) {
+					result = append(result, change)
+				}
+			}
+		}
+	}
+	return result
+}
+
+func compareContent(oldAttributes, currentAttributes map[string]*Attribute,
+	oldBlocks, currentBlocks map[string]*Block, prefix string) []Change {
+	result := []Change{}
+	for _, name := range sortedKeys(oldAttributes, currentAttributes) {
+		path := prefix + name
+		oldAttribute, oldOk := oldAttributes[name]
+		currentAttribute, currentOk := currentAttributes[name]
+		switch {
+		case !currentOk:
+			result = append(result, Change{Path: path, Message: "was removed"})
+		case !oldOk:
+			if currentAttribute.Required {
+				result = append(result, Change{Path: path, Message: "was added as required"})
+			}
+		default:
+			result = append(result, compareAttribute(oldAttribute, currentAttribute, path)...)
+		}
+	}
+	for _, name := range sortedKeys(oldBlocks, currentBlocks) {
+		path := prefix + name
+		oldBlock, oldOk := oldBlocks[name]
+		currentBlock, currentOk := currentBlocks[name]
+		switch {
+		case !currentOk:
+			result = append(result, Change{Path: path, Message: "was removed"})
+		case !oldOk:
+			if currentBlock.MinItems > 0 {
+				result = append(result, Change{Path: path, Message: "was added as required"})
+			}
+		default:
+			result = append(result, compareBlock(oldBlock, currentBlock, path)...)
+		}
+	}
+	return result
+}
+
+func compareAttribute(old, current *Attribute, path string) []Change {
+	result := []Change{}
+	if typeText(old.Type) != typeText(current.Type) {
+		result = append(result, Change{Path: path, Message: fmt.Sprintf(
+			"type was changed from %s to %s", describeType(old), describeType(current),
+		)})
+	}
+	if !old.Required && current.Required {
+		result = append(result, Change{Path: path, Message: "became required"})
+	}
+	if (old.Required || old.Optional) && !current.Required && !current.Optional {
+		result = append(result, Change{Path: path, Message: "can no longer be set"})
+	}
+	if old.Computed && !current.Computed {
+		result = append(result, Change{Path: path, Message: "is no longer computed"})
+	}
+	if !old.Sensitive && current.Sensitive {
+		result = append(result, Change{Path: path, Message: "became sensitive"})
+	}
+	if old.Nested != nil && current.Nested != nil {
+		if old.Nested.Nesting != current.Nested.Nesting {
+			result = append(result, Change{Path: path, Message: fmt.Sprintf(
+				"nesting was changed from %s to %s", old.Nested.Nesting, current.Nested.Nesting,
+			)})
+		} else {
+			result = append(result, compareContent(old.Nested.Attributes, current.Nested.Attributes,
+				nil, nil, path+".")...)
+		}
+	}
+	return result
+}
+
+func compareBlock(old, current *Block, path string) []Change {
+	result := []Change{}
+	if old.Nesting != current.Nesting {
+		result = append(result, Change{Path: path, Message: fmt.Sprintf(
+			"nesting was changed from %s to %s", old.Nesting, current.Nesting,
+		)})
+		return result
+	}
+	if current.MinItems > old.MinItems {
+		result = append(result, Change{Path: path, Message: fmt.Sprintf(
+			"minimum number of items was increased from %d to %d", old.MinItems, current.MinItems,
+		)})
+	}
+	if current.MaxItems > 0 && (old.MaxItems == 0 || current.MaxItems < old.MaxItems) {
+		result = append(result, Change{Path: path, Message: fmt.Sprintf(
+			"maximum number of items was decreased to %d", current.MaxItems,
+		)})
+	}
+	result = append(result, compareContent(old.Attributes, current.Attributes,
+		old.Blocks, current.Blocks, path+".")...)
+	return result
+}
+
+func describeType(attribute *Attribute) string {
+	if attribute.Nested != nil {
+		return "nested " + attribute.Nested.Nesting
+	}
+	return typeText(attribute.Type)
+}
+
+// typeText returns the compact JSON text of a type, as the types loaded from the snapshots are
+// indented.
+func typeText(data json.RawMessage) string {
+	buffer := &bytes.Buffer{}
+	if json.Compact(buffer, data) != nil {
+		return string(data)
+	}
+	return buffer.String()
+}
+
+func sortedKeys[T any](maps ...map[string]T) []string {
+	keys := map[string]bool{}
+	for _, m := range maps {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
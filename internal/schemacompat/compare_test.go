@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemacompat
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Check", func() {
+	var old, current Snapshot
+
+	stringType := json.RawMessage(`"string"`)
+	numberType := json.RawMessage(`"number"`)
+
+	BeforeEach(func() {
+		old = Snapshot{
+			ResourceKind: {
+				"rhcs_pool": {
+					Attributes: map[string]*Attribute{
+						"name":     {Type: stringType, Required: true},
+						"replicas": {Type: numberType, Optional: true, Computed: true},
+						"state":    {Type: stringType, Computed: true},
+						"autoscaling": {
+							Nested: &Object{
+								Nesting: "single",
+								Attributes: map[string]*Attribute{
+									"max": {Type: numberType, Optional: true},
+								},
+							},
+							Optional: true,
+						},
+					},
+					Blocks: map[string]*Block{
+						"taint": {
+							Nesting: "list",
+							Attributes: map[string]*Attribute{
+								"key": {Type: stringType, Required: true},
+							},
+						},
+					},
+				},
+			},
+			DataSourceKind: {
+				"rhcs_pools": {
+					Attributes: map[string]*Attribute{
+						"cluster": {Type: stringType, Required: true},
+					},
+				},
+			},
+		}
+		current = copySnapshot(old)
+	})
+
+	It("Accepts identical snapshots", func() {
+		Expect(Check(old, current, nil)).To(BeEmpty())
+	})
+
+	It("Accepts compatible changes", func() {
+		pool := current[ResourceKind]["rhcs_pool"]
+		pool.Attributes["name"].Required = false
+		pool.Attributes["name"].Optional = true
+		pool.Attributes["labels"] = &Attribute{Type: stringType, Optional: true}
+		pool.Attributes["autoscaling"].Nested.Attributes["min"] = &Attribute{Type: numberType, Optional: true}
+		pool.Blocks["toleration"] = &Block{Nesting: "list"}
+		current[ResourceKind]["rhcs_other"] = &Schema{}
+
+		Expect(Check(old, current, nil)).To(BeEmpty())
+	})
+
+	It("Detects the incompatible changes", func() {
+		pool := current[ResourceKind]["rhcs_pool"]
+		pool.Attributes["replicas"].Type = stringType
+		pool.Attributes["replicas"].Optional = false
+		pool.Attributes["replicas"].Required = true
+		pool.Attributes["state"].Computed = false
+		pool.Attributes["state"].Optional = true
+		pool.Attributes["zone"] = &Attribute{Type: stringType, Required: true}
+		delete(pool.Attributes["autoscaling"].Nested.Attributes, "max")
+		pool.Blocks["taint"].MaxItems = 1
+		delete(current[DataSourceKind], "rhcs_pools")
+
+		Expect(Check(old, current, nil)).To(ConsistOf(
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "autoscaling.max", Message: "was removed"},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "replicas",
+				Message: `type was changed from "number" to "string"`},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "replicas", Message: "became required"},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "state", Message: "is no longer computed"},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "zone", Message: "was added as required"},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "taint",
+				Message: "maximum number of items was decreased to 1"},
+			Change{Kind: DataSourceKind, Schema: "rhcs_pools", Message: "was removed"},
+		))
+	})
+
+	It("Detects the changes of nesting", func() {
+		pool := current[ResourceKind]["rhcs_pool"]
+		pool.Attributes["autoscaling"].Nested.Nesting = "list"
+		pool.Blocks["taint"].Nesting = "set"
+
+		Expect(Check(old, current, nil)).To(ConsistOf(
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "autoscaling",
+				Message: "nesting was changed from single to list"},
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "taint",
+				Message: "nesting was changed from list to set"},
+		))
+	})
+
+	It("Accepts the incompatible changes in the allowlist", func() {
+		delete(current[ResourceKind]["rhcs_pool"].Attributes, "state")
+		delete(current[ResourceKind]["rhcs_pool"].Attributes, "replicas")
+		allowlist := Allowlist{{
+			Kind:   ResourceKind,
+			Schema: "rhcs_pool",
+			Path:   "state",
+			Reason: "The state is now in the status.",
+		}}
+
+		Expect(Check(old, current, allowlist)).To(ConsistOf(
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Path: "replicas", Message: "was removed"},
+		))
+	})
+
+	It("Accepts the incompatible changes when the version is increased", func() {
+		delete(current[ResourceKind]["rhcs_pool"].Attributes, "state")
+		current[ResourceKind]["rhcs_pool"].Version = 1
+
+		Expect(Check(old, current, nil)).To(BeEmpty())
+	})
+
+	It("Rejects decreasing the version", func() {
+		old[ResourceKind]["rhcs_pool"].Version = 2
+		current[ResourceKind]["rhcs_pool"].Version = 1
+
+		Expect(Check(old, current, nil)).To(ConsistOf(
+			Change{Kind: ResourceKind, Schema: "rhcs_pool", Message: "version was decreased from 2 to 1"},
+		))
+	})
+})
+
+var _ = Describe("Outdated", func() {
+	It("Reports the schemas that changed, were added or were removed", func() {
+		old := Snapshot{
+			ResourceKind: {
+				"rhcs_same":    {},
+				"rhcs_changed": {},
+				"rhcs_removed": {},
+			},
+		}
+		current := Snapshot{
+			ResourceKind: {
+				"rhcs_same":    {},
+				"rhcs_changed": {Version: 1},
+				"rhcs_added":   {},
+			},
+		}
+
+		outdated, err := Outdated(old, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outdated).To(Equal([]string{
+			"resource rhcs_added",
+			"resource rhcs_changed",
+			"resource rhcs_removed",
+		}))
+	})
+})
+
+func copySnapshot(snapshot Snapshot) Snapshot {
+	data, err := json.Marshal(snapshot)
+	Expect(err).NotTo(HaveOccurred())
+	result := Snapshot{}
+	Expect(json.Unmarshal(data, &result)).To(Succeed())
+	return result
+}
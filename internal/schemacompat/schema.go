@@ -0,0 +1,310 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schemacompat renders the schemas of the resources and data sources of a provider to
+// canonical JSON snapshots, and checks that the changes between two snapshots are backward
+// compatible, so that upgrading the provider doesn't break existing configurations.
+package schemacompat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Kind is the kind of a schema: resource or data source.
+type Kind string
+
+const (
+	ResourceKind   Kind = "resource"
+	DataSourceKind Kind = "data_source"
+)
+
+// Kinds are all the kinds of schemas, in the order used for reports.
+var Kinds = []Kind{ResourceKind, DataSourceKind}
+
+// dirs are the names of the snapshot directories of each kind.
+var dirs = map[Kind]string{
+	ResourceKind:   "resources",
+	DataSourceKind: "data-sources",
+}
+
+// Schema is the canonical representation of the schema of a resource or data source. It only
+// contains the details that matter for compatibility, descriptions are ignored.
+type Schema struct {
+	Version    int64                 `json:"version"`
+	Attributes map[string]*Attribute `json:"attributes,omitempty"`
+	Blocks     map[string]*Block     `json:"blocks,omitempty"`
+}
+
+// Attribute is the canonical representation of an attribute. Nested attributes have no type, only
+// the nested object.
+type Attribute struct {
+	Type       json.RawMessage `json:"type,omitempty"`
+	Nested     *Object         `json:"nested,omitempty"`
+	Required   bool            `json:"required,omitempty"`
+	Optional   bool            `json:"optional,omitempty"`
+	Computed   bool            `json:"computed,omitempty"`
+	Sensitive  bool            `json:"sensitive,omitempty"`
+	Deprecated bool            `json:"deprecated,omitempty"`
+}
+
+// Object is the canonical representation of the object of a nested attribute.
+type Object struct {
+	Nesting    string                `json:"nesting"`
+	Attributes map[string]*Attribute `json:"attributes,omitempty"`
+}
+
+// Block is the canonical representation of a nested block.
+type Block struct {
+	Nesting    string                `json:"nesting"`
+	MinItems   int64                 `json:"min_items,omitempty"`
+	MaxItems   int64                 `json:"max_items,omitempty"`
+	Attributes map[string]*Attribute `json:"attributes,omitempty"`
+	Blocks     map[string]*Block     `json:"blocks,omitempty"`
+}
+
+// Snapshot contains the schemas of all the resources and data sources of a provider, indexed by
+// kind and type name.
+type Snapshot map[Kind]map[string]*Schema
+
+// Render renders the schemas of all the resources and data sources of the given provider. The
+// schemas are obtained from the provider server, exactly like Terraform does, so they reflect the
+// resources and data sources returned by the provider and not only the ones that have snapshots.
+func Render(ctx context.Context, provider tfprovider.Provider) (Snapshot, error) {
+	server := providerserver.NewProtocol6(provider)()
+	response, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, diagnostic := range response.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			return nil, fmt.Errorf("failed to get the provider schema: %s: %s", diagnostic.Summary,
+				diagnostic.Detail)
+		}
+	}
+	result := Snapshot{
+		ResourceKind:   map[string]*Schema{},
+		DataSourceKind: map[string]*Schema{},
+	}
+	for name, schema := range response.ResourceSchemas {
+		result[ResourceKind][name], err = renderSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render schema of resource '%s': %v", name, err)
+		}
+	}
+	for name, schema := range response.DataSourceSchemas {
+		result[DataSourceKind][name], err = renderSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render schema of data source '%s': %v", name, err)
+		}
+	}
+	return result, nil
+}
+
+func renderSchema(schema *tfprotov6.Schema) (*Schema, error) {
+	result := &Schema{
+		Version: schema.Version,
+	}
+	if schema.Block == nil {
+		return result, nil
+	}
+	var err error
+	result.Attributes, err = renderAttributes(schema.Block.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	result.Blocks, err = renderBlocks(schema.Block.BlockTypes)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func renderAttributes(attributes []*tfprotov6.SchemaAttribute) (map[string]*Attribute, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	result := map[string]*Attribute{}
+	for _, attribute := range attributes {
+		rendered := &Attribute{
+			Required:   attribute.Required,
+			Optional:   attribute.Optional,
+			Computed:   attribute.Computed,
+			Sensitive:  attribute.Sensitive,
+			Deprecated: attribute.Deprecated,
+		}
+		if attribute.NestedType != nil {
+			nested, err := renderAttributes(attribute.NestedType.Attributes)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%v", attribute.Name, err)
+			}
+			rendered.Nested = &Object{
+				Nesting:    strings.ToLower(attribute.NestedType.Nesting.String()),
+				Attributes: nested,
+			}
+		} else if attribute.Type != nil {
+			data, err := json.Marshal(attribute.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", attribute.Name, err)
+			}
+			rendered.Type = data
+		}
+		result[attribute.Name] = rendered
+	}
+	return result, nil
+}
+
+func renderBlocks(blocks []*tfprotov6.SchemaNestedBlock) (map[string]*Block, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	result := map[string]*Block{}
+	for _, block := range blocks {
+		rendered := &Block{
+			Nesting:  strings.ToLower(block.Nesting.String()),
+			MinItems: block.MinItems,
+			MaxItems: block.MaxItems,
+		}
+		if block.Block != nil {
+			var err error
+			rendered.Attributes, err = renderAttributes(block.Block.Attributes)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%v", block.TypeName, err)
+			}
+			rendered.Blocks, err = renderBlocks(block.Block.BlockTypes)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%v", block.TypeName, err)
+			}
+		}
+		result[block.TypeName] = rendered
+	}
+	return result, nil
+}
+
+// Marshal renders a schema to its canonical JSON text.
+func Marshal(schema *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Load loads the snapshot stored in the given directory. A missing directory is an empty
+// snapshot.
+func Load(dir string) (Snapshot, error) {
+	result := Snapshot{}
+	for _, kind := range Kinds {
+		result[kind] = map[string]*Schema{}
+		files, err := filepath.Glob(filepath.Join(dir, dirs[kind], "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			schema := &Schema{}
+			err = json.Unmarshal(data, schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse schema snapshot '%s': %v", file, err)
+			}
+			result[kind][strings.TrimSuffix(filepath.Base(file), ".json")] = schema
+		}
+	}
+	return result, nil
+}
+
+// Write replaces the snapshot stored in the given directory with the given one, removing the
+// files of the schemas that no longer exist.
+func Write(dir string, snapshot Snapshot) error {
+	for _, kind := range Kinds {
+		kindDir := filepath.Join(dir, dirs[kind])
+		err := os.MkdirAll(kindDir, 0755)
+		if err != nil {
+			return err
+		}
+		files, err := filepath.Glob(filepath.Join(kindDir, "*.json"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if _, ok := snapshot[kind][strings.TrimSuffix(filepath.Base(file), ".json")]; !ok {
+				err = os.Remove(file)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		for name, schema := range snapshot[kind] {
+			data, err := Marshal(schema)
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(filepath.Join(kindDir, name+".json"), data, 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Outdated returns the names of the schemas, prefixed with the kind, whose canonical JSON text is
+// different in the two snapshots, including the ones that exist only in one of them.
+func Outdated(old, current Snapshot) ([]string, error) {
+	result := []string{}
+	for _, kind := range Kinds {
+		names := map[string]bool{}
+		for name := range old[kind] {
+			names[name] = true
+		}
+		for name := range current[kind] {
+			names[name] = true
+		}
+		for name := range names {
+			oldSchema, oldOk := old[kind][name]
+			currentSchema, currentOk := current[kind][name]
+			if oldOk != currentOk {
+				result = append(result, fmt.Sprintf("%s %s", kind, name))
+				continue
+			}
+			oldData, err := Marshal(oldSchema)
+			if err != nil {
+				return nil, err
+			}
+			currentData, err := Marshal(currentSchema)
+			if err != nil {
+				return nil, err
+			}
+			if string(oldData) != string(currentData) {
+				result = append(result, fmt.Sprintf("%s %s", kind, name))
+			}
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
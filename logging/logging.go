@@ -3,14 +3,62 @@ package logging
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tflogging "github.com/hashicorp/terraform-plugin-sdk/helper/logging"
 	ocmlogging "github.com/openshift-online/ocm-sdk-go/logging"
 )
 
-// TfLogger is a logger that uses the Go `log` package.
+// Subsystem is the name of the tflog subsystem that receives the messages of the OCM SDK and the
+// details of the requests sent to OCM. Its level can be changed independently of the rest of the
+// provider with the `TF_LOG_PROVIDER_RHCS_OCM` environment variable.
+const Subsystem = "ocm"
+
+const subsystemLevelEnv = "TF_LOG_PROVIDER_RHCS_OCM"
+
+// Names of the fields attached to the messages that describe requests:
+const (
+	FieldMethod      = "ocm_method"
+	FieldURL         = "ocm_url"
+	FieldStatus      = "ocm_status"
+	FieldOperationID = "ocm_operation_id"
+	FieldDuration    = "ocm_duration_ms"
+	FieldError       = "ocm_error"
+)
+
+// secretFieldKeys are the names of the fields whose values are always masked.
+var secretFieldKeys = []string{
+	"authorization",
+	"token",
+	"access_token",
+	"refresh_token",
+	"client_secret",
+	"password",
+	"private_key",
+}
+
+// secretREs match the shapes of the secrets that can appear in the messages of the SDK, like
+// the dumps of the request and response bodies.
+var secretREs = []*regexp.Regexp{
+	// Authorization headers:
+	regexp.MustCompile(`(?i)(bearer|basic)\s+[A-Za-z0-9\-_.~+/]+=*`),
+	// JSON web tokens, like access and refresh tokens:
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	// PEM encoded private keys, like the ones of the OIDC configurations:
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	// JSON fields that contain secrets, like the passwords of the htpasswd identity providers:
+	regexp.MustCompile(`(?i)"[a-z_]*(password|secret|token|private_key)"\s*:\s*"(?:[^"\\]|\\.)*"`),
+	// Form fields sent to obtain tokens:
+	regexp.MustCompile(`(?i)\b(password|client_secret|refresh_token|access_token)=[^&\s]*`),
+}
+
+// TfLogger is the bridge that sends the messages of the OCM SDK to the Terraform logs. The
+// messages go to the `ocm` subsystem, with the secrets masked.
 type TfLogger struct {
 	debugEnabled bool
 	infoEnabled  bool
@@ -18,10 +66,14 @@ type TfLogger struct {
 	errorEnabled bool
 }
 
-// New creates the provider.
-func New() ocmlogging.Logger {
+// New creates the logger. The enabled levels are taken from the `TF_LOG_PROVIDER_RHCS_OCM`
+// environment variable, or from `TF_LOG` when it isn't set.
+func New() *TfLogger {
 	tfLogger := &TfLogger{}
 	logLevel := tflogging.LogLevel()
+	if level := os.Getenv(subsystemLevelEnv); level != "" {
+		logLevel = strings.ToUpper(level)
+	}
 	switch logLevel {
 	case "TRACE", "DEBUG":
 		tfLogger.debugEnabled = true
@@ -41,6 +93,19 @@ func New() ocmlogging.Logger {
 	return tfLogger
 }
 
+var _ ocmlogging.Logger = &TfLogger{}
+
+// WithSubsystem returns a context where the `ocm` subsystem is registered with the masking of the
+// secrets. It is called for each message because the SDK passes the contexts of the requests,
+// which are created by Terraform and don't contain the subsystem.
+func WithSubsystem(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, Subsystem, tflog.WithLevelFromEnv(subsystemLevelEnv))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, Subsystem, secretFieldKeys...)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, Subsystem, secretREs...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, Subsystem, secretREs...)
+	return ctx
+}
+
 // DebugEnabled returns true iff the debug level is enabled.
 func (l *TfLogger) DebugEnabled() bool {
 	return l.debugEnabled
@@ -66,7 +131,7 @@ func (l *TfLogger) ErrorEnabled() bool {
 func (l *TfLogger) Debug(ctx context.Context, format string, args ...interface{}) {
 	if l.debugEnabled {
 		msg := fmt.Sprintf(format, args...)
-		tflog.Debug(ctx, msg)
+		tflog.SubsystemDebug(WithSubsystem(ctx), Subsystem, msg)
 	}
 }
 
@@ -75,7 +140,7 @@ func (l *TfLogger) Debug(ctx context.Context, format string, args ...interface{}
 func (l *TfLogger) Info(ctx context.Context, format string, args ...interface{}) {
 	if l.infoEnabled {
 		msg := fmt.Sprintf(format, args...)
-		tflog.Info(ctx, msg)
+		tflog.SubsystemInfo(WithSubsystem(ctx), Subsystem, msg)
 	}
 }
 
@@ -84,7 +149,7 @@ func (l *TfLogger) Info(ctx context.Context, format string, args ...interface{})
 func (l *TfLogger) Warn(ctx context.Context, format string, args ...interface{}) {
 	if l.warnEnabled {
 		msg := fmt.Sprintf(format, args...)
-		tflog.Warn(ctx, msg)
+		tflog.SubsystemWarn(WithSubsystem(ctx), Subsystem, msg)
 	}
 }
 
@@ -93,7 +158,7 @@ func (l *TfLogger) Warn(ctx context.Context, format string, args ...interface{})
 func (l *TfLogger) Error(ctx context.Context, format string, args ...interface{}) {
 	if l.errorEnabled {
 		msg := fmt.Sprintf(format, args...)
-		tflog.Error(ctx, msg)
+		tflog.SubsystemError(WithSubsystem(ctx), Subsystem, msg)
 	}
 }
 
@@ -102,6 +167,43 @@ func (l *TfLogger) Error(ctx context.Context, format string, args ...interface{}
 // This level is always enabled
 func (l *TfLogger) Fatal(ctx context.Context, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	tflog.Error(ctx, msg)
+	tflog.SubsystemError(WithSubsystem(ctx), Subsystem, msg)
 	os.Exit(1)
 }
+
+// Wrap returns a round tripper that writes a debug message for each request sent to OCM, with the
+// method, URL, status, operation identifier and duration as fields.
+func (l *TfLogger) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &loggingRoundTripper{
+		logger:    l,
+		transport: transport,
+	}
+}
+
+type loggingRoundTripper struct {
+	logger    *TfLogger
+	transport http.RoundTripper
+}
+
+func (t *loggingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !t.logger.debugEnabled {
+		return t.transport.RoundTrip(request)
+	}
+	start := time.Now()
+	response, err := t.transport.RoundTrip(request)
+	fields := map[string]interface{}{
+		FieldMethod:   request.Method,
+		FieldURL:      request.URL.Redacted(),
+		FieldDuration: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields[FieldError] = err.Error()
+	} else {
+		fields[FieldStatus] = response.StatusCode
+		if operationID := response.Header.Get("X-Operation-ID"); operationID != "" {
+			fields[FieldOperationID] = operationID
+		}
+	}
+	tflog.SubsystemDebug(WithSubsystem(request.Context()), Subsystem, "Sent OCM request", fields)
+	return response, err
+}
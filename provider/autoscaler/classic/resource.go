@@ -32,7 +32,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/autoscaler"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -61,6 +60,7 @@ func (r *ClusterAutoscalerResource) Metadata(ctx context.Context, req resource.M
 func (r *ClusterAutoscalerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Cluster-wide autoscaling configuration.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
 				Description: "Identifier of the cluster." + common.ValueCannotBeChangedStringDescription,
@@ -190,6 +190,9 @@ func (r *ClusterAutoscalerResource) Schema(ctx context.Context, req resource.Sch
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 	return
 }
@@ -198,16 +201,18 @@ func (r *ClusterAutoscalerResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
-	r.clusterWait = common.NewClusterWait(r.collection, connection)
+	r.clusterWait = common.NewClusterWait(r.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func (r *ClusterAutoscalerResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
@@ -219,23 +224,29 @@ func (r *ClusterAutoscalerResource) Create(ctx context.Context, request resource
 	}
 
 	// Wait till the cluster is ready:
-	waitTimeoutInMinutes := int64(60)
-	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), waitTimeoutInMinutes)
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), timeout)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Cannot poll cluster state",
 			fmt.Sprintf(
 				"Cannot poll state of cluster with identifier '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	autoscaler, err := r.collection.Cluster(plan.Cluster.ValueString()).Autoscaler().Get().Send()
 	if err != nil && autoscaler.Status() != http.StatusNotFound {
-		response.Diagnostics.AddError("Can't create autoscaler", fmt.Sprintf("Autoscaler for cluster '%s' might already exists. Error: %s",
-			plan.Cluster.ValueString(), err.Error()))
+		common.AddError(&response.Diagnostics, "Can't create autoscaler", fmt.Sprintf("Autoscaler for cluster '%s' might already exists. Error: %s",
+			plan.Cluster.ValueString(), err.Error()), err)
 		return
 	}
 
@@ -243,24 +254,28 @@ func (r *ClusterAutoscalerResource) Create(ctx context.Context, request resource
 
 	object, err := clusterAutoscalerStateToObject(plan)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed building cluster autoscaler",
 			fmt.Sprintf(
 				"Failed building autoscaler for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	_, err = resource.Autoscaler().Post().Request(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed creating cluster autoscaler",
 			fmt.Sprintf(
 				"Failed creating autoscaler for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -286,12 +301,14 @@ func (r *ClusterAutoscalerResource) Read(ctx context.Context, request resource.R
 		return
 
 	} else if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed getting cluster autoscaler",
 			fmt.Sprintf(
 				"Failed getting autoscaler for cluster '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -326,6 +343,12 @@ func (r *ClusterAutoscalerResource) Update(ctx context.Context, request resource
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	diags = validateNoImmutableAttChange(state, plan)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
@@ -334,24 +357,28 @@ func (r *ClusterAutoscalerResource) Update(ctx context.Context, request resource
 
 	_, err := r.collection.Cluster(plan.Cluster.ValueString()).Autoscaler().Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed getting cluster autoscaler",
 			fmt.Sprintf(
 				"Failed getting autoscaler for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	autoscaler, err := clusterAutoscalerStateToObject(plan)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed updating cluster autoscaler",
 			fmt.Sprintf(
 				"Failed updating autoscaler for cluster '%s: %v ",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -359,18 +386,22 @@ func (r *ClusterAutoscalerResource) Update(ctx context.Context, request resource
 	update, err := r.collection.Cluster(plan.Cluster.ValueString()).
 		Autoscaler().Update().Body(autoscaler).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed updating cluster autoscaler",
 			fmt.Sprintf(
 				"Failed updating autoscaler for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	object := update.Body()
-	state = &ClusterAutoscalerState{}
+	state = &ClusterAutoscalerState{
+		Timeouts: plan.Timeouts,
+	}
 	populateAutoscalerState(object, plan.Cluster.ValueString(), state)
 
 	diags = response.State.Set(ctx, state)
@@ -395,15 +426,23 @@ func (r *ClusterAutoscalerResource) Delete(ctx context.Context, request resource
 		return
 	}
 
+	ctx, cancel := common.WithDeleteTimeout(ctx, state.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	resource := r.collection.Cluster(state.Cluster.ValueString()).Autoscaler()
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Failed deleting cluster autoscaler",
 			fmt.Sprintf(
 				"Failed deleting autoscaler for cluster '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
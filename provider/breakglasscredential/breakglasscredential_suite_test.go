@@ -0,0 +1,13 @@
+package breakglasscredential
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBreakGlassCredential(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Break Glass Credential Suite")
+}
@@ -0,0 +1,129 @@
+package breakglasscredential
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+type BreakGlassCredentialsDataSource struct {
+	credentials common.BreakGlassCredentialsClient
+}
+
+var _ datasource.DataSource = &BreakGlassCredentialsDataSource{}
+var _ datasource.DataSourceWithConfigure = &BreakGlassCredentialsDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &BreakGlassCredentialsDataSource{}
+}
+
+func (d *BreakGlassCredentialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_break_glass_credentials"
+}
+
+func (d *BreakGlassCredentialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List of the break glass credentials of a hosted control plane cluster that uses " +
+			"external authentication providers. The kubeconfigs of the credentials aren't included.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "cluster ID may not be empty/blank string"),
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "Content of the list.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the credential.",
+							Computed:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "Name of the user of the credential.",
+							Computed:    true,
+						},
+						"expiration_timestamp": schema.StringAttribute{
+							Description: "Time when the credential expires, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"revocation_timestamp": schema.StringAttribute{
+							Description: "Time when the credential was revoked, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the credential, one of 'created', 'issued', 'failed', " +
+								"'awaiting_revocation', 'revoked' and 'expired'.",
+							Computed: true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *BreakGlassCredentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.credentials = common.NewBreakGlassCredentialsClient(providerData.Connection.ClustersMgmt().V1().Clusters())
+}
+
+func (d *BreakGlassCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+	state := &BreakGlassCredentialsState{}
+	resp.Diagnostics.Append(req.Config.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, err := d.credentials.List(ctx, state.Cluster.ValueString())
+	if err != nil {
+		common.AddError(
+			&resp.Diagnostics,
+			"Can't list break glass credentials",
+			fmt.Sprintf("Can't list the break glass credentials of cluster '%s': %v",
+				state.Cluster.ValueString(), err),
+			err,
+		)
+		return
+	}
+
+	state.Items = make([]*BreakGlassCredentialItem, len(credentials))
+	for i, credential := range credentials {
+		state.Items[i] = &BreakGlassCredentialItem{
+			ID:                  types.StringValue(credential.ID()),
+			Username:            types.StringValue(credential.Username()),
+			ExpirationTimestamp: timestampToString(credential.ExpirationTimestamp()),
+			RevocationTimestamp: timestampToString(credential.RevocationTimestamp()),
+			Status:              types.StringValue(string(credential.Status())),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
@@ -0,0 +1,47 @@
+package breakglasscredential
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Break glass credentials data source", func() {
+	It("Lists the credentials of the cluster", func() {
+		ctx := context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		credentials := common.NewMockBreakGlassCredentialsClient(ctrl)
+		d := &BreakGlassCredentialsDataSource{credentials: credentials}
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+		config := tfsdk.Config{Schema: schemaResp.Schema}
+		configState := tfsdk.State{Schema: schemaResp.Schema}
+		Expect(configState.Set(ctx, &BreakGlassCredentialsState{
+			Cluster: types.StringValue(clusterId),
+		}).HasError()).To(BeFalse())
+		config.Raw = configState.Raw
+
+		credentials.EXPECT().List(gomock.Any(), clusterId).Return([]*cmv1.BreakGlassCredential{
+			newCredential(cmv1.BreakGlassCredentialStatusIssued, ""),
+		}, nil)
+
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+		Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+		state := &BreakGlassCredentialsState{}
+		Expect(resp.State.Get(ctx, state).HasError()).To(BeFalse())
+		Expect(state.Items).To(HaveLen(1))
+		Expect(state.Items[0].ID.ValueString()).To(Equal(credentialId))
+		Expect(state.Items[0].Username.ValueString()).To(Equal("admin"))
+		Expect(state.Items[0].Status.ValueString()).To(Equal("issued"))
+		Expect(state.Items[0].ExpirationTimestamp.ValueString()).To(Equal("2024-01-01T12:00:00Z"))
+	})
+})
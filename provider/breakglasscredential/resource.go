@@ -0,0 +1,473 @@
+package breakglasscredential
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
+)
+
+const (
+	failedToCreateSummary = "Failed to create break glass credential"
+	failedToReadSummary   = "Failed to read break glass credential"
+	failedToRevokeSummary = "Failed to revoke break glass credentials"
+)
+
+// Limits of the expiration that OCM accepts for the break glass credentials.
+const (
+	minExpiration = 10 * time.Minute
+	maxExpiration = 24 * time.Hour
+)
+
+// defaultIssuedPollInterval is the time between the checks of a new credential until OCM issues
+// its kubeconfig, which usually takes less than a minute.
+const defaultIssuedPollInterval = 5 * time.Second
+
+type BreakGlassCredentialResource struct {
+	credentials        common.BreakGlassCredentialsClient
+	clusterWait        common.ClusterWait
+	issuedPollInterval time.Duration
+}
+
+var _ resource.Resource = &BreakGlassCredentialResource{}
+var _ resource.ResourceWithConfigure = &BreakGlassCredentialResource{}
+var _ resource.ResourceWithImportState = &BreakGlassCredentialResource{}
+
+func New() resource.Resource {
+	return &BreakGlassCredentialResource{}
+}
+
+func (r *BreakGlassCredentialResource) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_break_glass_credential"
+}
+
+func (r *BreakGlassCredentialResource) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Break glass credential of a hosted control plane cluster that uses external " +
+			"authentication providers. It gives emergency administrator access to the cluster with a " +
+			"kubeconfig issued by OCM, that doesn't depend on the external providers.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster. " + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "cluster ID may not be empty/blank string"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the credential.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Name of the user of the credential. If not set, OCM generates one. " +
+					common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expiration": schema.StringAttribute{
+				Description: "Duration after which the credential expires, for example `2h`. It must be " +
+					"between 10 minutes and 24 hours. If not set, OCM uses 24 hours. " +
+					common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				Validators: []validator.String{
+					expirationValidator(),
+				},
+			},
+			"expiration_timestamp": schema.StringAttribute{
+				Description: "Time when the credential expires, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revocation_timestamp": schema.StringAttribute{
+				Description: "Time when the credential was revoked, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the credential, one of 'created', 'issued', 'failed', " +
+					"'awaiting_revocation', 'revoked' and 'expired'.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Kubeconfig that authenticates with the credential.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"revoke_on_destroy": schema.BoolAttribute{
+				Description: "Revoke the credential when the resource is destroyed. OCM revokes all the " +
+					"break glass credentials of the cluster at once, so the destroy fails when other " +
+					"credentials of the cluster are still issued, including the ones created with " +
+					"'create_before_destroy' to replace this one, unless 'revoke_all_on_destroy' is " +
+					"true. Default value is false, and so the credential stays valid until it expires.",
+				Optional: true,
+			},
+			"revoke_all_on_destroy": schema.BoolAttribute{
+				Description: "Revoke the other credentials of the cluster that are still issued too, " +
+					"when the resource is destroyed with 'revoke_on_destroy'. Default value is false, " +
+					"and so the destroy fails if there are any.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.CreateTimeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BreakGlassCredentialResource) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	collection := providerData.Connection.ClustersMgmt().V1().Clusters()
+	r.credentials = common.NewBreakGlassCredentialsClient(collection)
+	r.clusterWait = common.NewClusterWait(collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.issuedPollInterval = defaultIssuedPollInterval
+}
+
+func (r *BreakGlassCredentialResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := plan.Cluster.ValueString()
+
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deadline := time.Now().Add(timeout)
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, timeout)
+	if err != nil {
+		common.AddError(
+			&resp.Diagnostics,
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+			err,
+		)
+		return
+	}
+	if !cluster.ExternalAuthConfig().Enabled() {
+		resp.Diagnostics.AddError(
+			failedToCreateSummary,
+			fmt.Sprintf("Cluster '%s' doesn't use external authentication providers. Break glass "+
+				"credentials can only be created for hosted control plane clusters created with "+
+				"'external_auth_providers_enabled' set to true.", clusterId),
+		)
+		return
+	}
+
+	builder := cmv1.NewBreakGlassCredential()
+	if common.HasValue(plan.Username) {
+		builder.Username(plan.Username.ValueString())
+	}
+	if common.HasValue(plan.Expiration) {
+		// The value was checked by the validator of the attribute:
+		expiration, _ := time.ParseDuration(plan.Expiration.ValueString())
+		builder.ExpirationTimestamp(time.Now().Add(expiration))
+	}
+	credential, err := builder.Build()
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to build break glass credential for cluster '%s': %v", clusterId, err), err)
+		return
+	}
+	created, err := r.credentials.Create(ctx, clusterId, credential)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to create break glass credential for cluster '%s': %v", clusterId, err), err)
+		return
+	}
+
+	// Save the identifier before waiting, so that a credential that isn't issued in time is still
+	// tracked, and revoked on destroy if requested:
+	plan.ID = types.StringValue(created.ID())
+	populateState(created, plan)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issued, err := r.waitForIssued(ctx, clusterId, created.ID(), deadline)
+	if issued != nil {
+		populateState(issued, plan)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	}
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Break glass credential '%s' for cluster '%s' wasn't issued: %v",
+				created.ID(), clusterId, err), err)
+	}
+}
+
+// waitForIssued polls the credential until OCM issues its kubeconfig. It fails if the credential
+// reaches a status from which it will not be issued, or if the deadline passes.
+func (r *BreakGlassCredentialResource) waitForIssued(ctx context.Context, clusterId string,
+	credentialId string, deadline time.Time) (*cmv1.BreakGlassCredential, error) {
+	waitCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	var credential *cmv1.BreakGlassCredential
+	for {
+		var err error
+		credential, err = r.credentials.Get(waitCtx, clusterId, credentialId)
+		if err != nil {
+			if waitCtx.Err() != nil {
+				return credential, fmt.Errorf("timed out waiting for the credential to be issued")
+			}
+			return credential, err
+		}
+		switch credential.Status() {
+		case cmv1.BreakGlassCredentialStatusIssued:
+			return credential, nil
+		case cmv1.BreakGlassCredentialStatusCreated:
+			tflog.Info(ctx, fmt.Sprintf("Waiting for break glass credential '%s' of cluster '%s' to be issued",
+				credentialId, clusterId))
+		default:
+			return credential, fmt.Errorf("the credential is in status '%s'", credential.Status())
+		}
+		if err := common.Sleep(waitCtx, r.issuedPollInterval); err != nil {
+			if ctx.Err() != nil {
+				return credential, ctx.Err()
+			}
+			return credential, fmt.Errorf("timed out waiting for the credential to be issued, "+
+				"the last status is '%s'", credential.Status())
+		}
+	}
+}
+
+func (r *BreakGlassCredentialResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := state.Cluster.ValueString()
+
+	exists, credential, err := r.credentials.Exists(ctx, clusterId, state.ID.ValueString())
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToReadSummary,
+			fmt.Sprintf("Cannot read break glass credential '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, err), err)
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("Break glass credential '%s' for cluster '%s' not found, "+
+			"removing from state", state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	populateState(credential, state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update only saves the attributes that don't change the credential, the others can't be changed.
+func (r *BreakGlassCredentialResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	common.ValidateStateAndPlanEquals(state.Cluster, plan.Cluster, "cluster", &resp.Diagnostics)
+	common.ValidateStateAndPlanEquals(state.Username, plan.Username, "username", &resp.Diagnostics)
+	// The expiration isn't known after an import, so it can be added to the configuration:
+	if common.HasValue(state.Expiration) {
+		common.ValidateStateAndPlanEquals(state.Expiration, plan.Expiration, "expiration", &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *BreakGlassCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &BreakGlassCredentialState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := state.Cluster.ValueString()
+
+	if !common.BoolWithFalseDefault(state.RevokeOnDestroy) {
+		tflog.Info(ctx, fmt.Sprintf("Break glass credential '%s' for cluster '%s' isn't revoked, it stays "+
+			"valid until it expires", state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	switch cmv1.BreakGlassCredentialStatus(state.Status.ValueString()) {
+	case cmv1.BreakGlassCredentialStatusAwaitingRevocation, cmv1.BreakGlassCredentialStatusRevoked,
+		cmv1.BreakGlassCredentialStatusExpired:
+		tflog.Info(ctx, fmt.Sprintf("Break glass credential '%s' for cluster '%s' is already in status "+
+			"'%s'", state.ID.ValueString(), clusterId, state.Status.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !common.BoolWithFalseDefault(state.RevokeAllOnDestroy) {
+		others, err := r.issuedCredentials(ctx, clusterId, state.ID.ValueString())
+		if err != nil {
+			common.AddError(&resp.Diagnostics, failedToRevokeSummary,
+				fmt.Sprintf("Failed to list the break glass credentials of cluster '%s': %v", clusterId, err), err)
+			return
+		}
+		if len(others) > 0 {
+			resp.Diagnostics.AddError(failedToRevokeSummary,
+				fmt.Sprintf("Break glass credentials '%s' of cluster '%s' are still issued, and OCM revokes "+
+					"all the credentials of a cluster at once. Set 'revoke_all_on_destroy' to true to revoke "+
+					"them too", strings.Join(others, "', '"), clusterId))
+			return
+		}
+	}
+
+	err := r.credentials.RevokeAll(ctx, clusterId)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToRevokeSummary,
+			fmt.Sprintf("Failed to revoke the break glass credentials of cluster '%s': %v", clusterId, err), err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// issuedCredentials returns the identifiers of the credentials of the cluster, other than the given
+// one, that would be revoked with it.
+func (r *BreakGlassCredentialResource) issuedCredentials(ctx context.Context, clusterId string,
+	credentialId string) ([]string, error) {
+	credentials, err := r.credentials.List(ctx, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, credential := range credentials {
+		if credential.ID() == credentialId {
+			continue
+		}
+		switch credential.Status() {
+		case cmv1.BreakGlassCredentialStatusCreated, cmv1.BreakGlassCredentialStatusIssued:
+			result = append(result, credential.ID())
+		}
+	}
+	return result, nil
+}
+
+func (r *BreakGlassCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"Break glass credential to import should be specified as <cluster_id>,<credential_id>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+}
+
+// populateState copies the credential returned by OCM into the state. OCM only returns the
+// kubeconfig while the credential is issued, so the one of the state is kept otherwise.
+func populateState(credential *cmv1.BreakGlassCredential, state *BreakGlassCredentialState) {
+	state.ID = types.StringValue(credential.ID())
+	state.Username = types.StringValue(credential.Username())
+	state.ExpirationTimestamp = timestampToString(credential.ExpirationTimestamp())
+	state.RevocationTimestamp = timestampToString(credential.RevocationTimestamp())
+	state.Status = types.StringValue(string(credential.Status()))
+	if credential.Kubeconfig() != "" {
+		state.Kubeconfig = types.StringValue(credential.Kubeconfig())
+	} else if state.Kubeconfig.IsUnknown() {
+		state.Kubeconfig = types.StringNull()
+	}
+}
+
+func timestampToString(timestamp time.Time) types.String {
+	if timestamp.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(timestamp.UTC().Format(time.RFC3339))
+}
+
+func expirationValidator() validator.String {
+	desc := fmt.Sprintf("Duration between %s and %s", minExpiration, maxExpiration)
+	return attrvalidators.NewStringValidator(desc, func(ctx context.Context, req validator.StringRequest,
+		resp *validator.StringResponse) {
+		if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+			return
+		}
+		expiration, err := time.ParseDuration(req.ConfigValue.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Value cannot be parsed to a duration string",
+				fmt.Sprintf("Value '%s' cannot be parsed to a duration string. A duration string is a "+
+					"sequence of decimal numbers and a time unit suffix such as \"30m\" or \"2h\"",
+					req.ConfigValue.ValueString()),
+			)
+			return
+		}
+		if expiration < minExpiration || expiration > maxExpiration {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid expiration",
+				fmt.Sprintf("Expiration must be between %s and %s, got '%s'", minExpiration, maxExpiration,
+					req.ConfigValue.ValueString()),
+			)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package breakglasscredential
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	clusterId    = "123"
+	credentialId = "456"
+	kubeconfig   = "apiVersion: v1\nkind: Config\n"
+)
+
+var expirationTimestamp = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newCredential(status cmv1.BreakGlassCredentialStatus, kubeconfig string) *cmv1.BreakGlassCredential {
+	credential, err := cmv1.NewBreakGlassCredential().
+		ID(credentialId).
+		Username("admin").
+		ExpirationTimestamp(expirationTimestamp).
+		Status(status).
+		Kubeconfig(kubeconfig).
+		Build()
+	Expect(err).ToNot(HaveOccurred())
+	return credential
+}
+
+var _ = Describe("Break glass credential resource", func() {
+	var (
+		ctx         context.Context
+		credentials *common.MockBreakGlassCredentialsClient
+		clusterWait *common.MockClusterWait
+		r           *BreakGlassCredentialResource
+		schemaResp  *resource.SchemaResponse
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		credentials = common.NewMockBreakGlassCredentialsClient(ctrl)
+		clusterWait = common.NewMockClusterWait(ctrl)
+		r = &BreakGlassCredentialResource{
+			credentials:        credentials,
+			clusterWait:        clusterWait,
+			issuedPollInterval: time.Millisecond,
+		}
+		schemaResp = &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		Expect(schemaResp.Diagnostics.HasError()).To(BeFalse())
+	})
+
+	// newPlan returns the plan of a credential with the given expiration, where the computed
+	// attributes are unknown.
+	newPlan := func(expiration types.String) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		Expect(plan.Set(ctx, &BreakGlassCredentialState{
+			Cluster:             types.StringValue(clusterId),
+			ID:                  types.StringUnknown(),
+			Username:            types.StringValue("admin"),
+			Expiration:          expiration,
+			ExpirationTimestamp: types.StringUnknown(),
+			RevocationTimestamp: types.StringUnknown(),
+			Status:              types.StringUnknown(),
+			Kubeconfig:          types.StringUnknown(),
+			RevokeOnDestroy:     types.BoolNull(),
+			RevokeAllOnDestroy:  types.BoolNull(),
+			Timeouts: timeouts.Value{
+				Object: types.ObjectNull(schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type).AttrTypes),
+			},
+		}).HasError()).To(BeFalse())
+		return plan
+	}
+
+	// newState returns the state of an issued credential.
+	newState := func(status cmv1.BreakGlassCredentialStatus, revokeOnDestroy bool) tfsdk.State {
+		state := &BreakGlassCredentialState{
+			Cluster:            types.StringValue(clusterId),
+			Expiration:         types.StringNull(),
+			Kubeconfig:         types.StringValue(kubeconfig),
+			RevokeOnDestroy:    types.BoolValue(revokeOnDestroy),
+			RevokeAllOnDestroy: types.BoolNull(),
+			Timeouts: timeouts.Value{
+				Object: types.ObjectNull(schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type).AttrTypes),
+			},
+		}
+		populateState(newCredential(status, ""), state)
+		tfState := tfsdk.State{Schema: schemaResp.Schema}
+		Expect(tfState.Set(ctx, state).HasError()).To(BeFalse())
+		return tfState
+	}
+
+	emptyState := func() tfsdk.State {
+		return tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+	}
+
+	readState := func(tfState tfsdk.State) *BreakGlassCredentialState {
+		result := &BreakGlassCredentialState{}
+		Expect(tfState.Get(ctx, result).HasError()).To(BeFalse())
+		return result
+	}
+
+	readyCluster := func(enabled bool) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().ID(clusterId).
+			ExternalAuthConfig(cmv1.NewExternalAuthConfig().Enabled(enabled)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	Context("Create", func() {
+		It("Waits until the kubeconfig is issued", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(readyCluster(true), nil)
+			start := time.Now()
+			credentials.EXPECT().Create(gomock.Any(), clusterId, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, clusterId string,
+					credential *cmv1.BreakGlassCredential) (*cmv1.BreakGlassCredential, error) {
+					Expect(credential.Username()).To(Equal("admin"))
+					Expect(credential.ExpirationTimestamp()).To(BeTemporally("~", start.Add(2*time.Hour), time.Minute))
+					return newCredential(cmv1.BreakGlassCredentialStatusCreated, ""), nil
+				})
+			gomock.InOrder(
+				credentials.EXPECT().Get(gomock.Any(), clusterId, credentialId).
+					Return(newCredential(cmv1.BreakGlassCredentialStatusCreated, ""), nil),
+				credentials.EXPECT().Get(gomock.Any(), clusterId, credentialId).
+					Return(newCredential(cmv1.BreakGlassCredentialStatusIssued, kubeconfig), nil),
+			)
+
+			resp := &resource.CreateResponse{State: emptyState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(types.StringValue("2h"))}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			state := readState(resp.State)
+			Expect(state.ID.ValueString()).To(Equal(credentialId))
+			Expect(state.Status.ValueString()).To(Equal("issued"))
+			Expect(state.Kubeconfig.ValueString()).To(Equal(kubeconfig))
+			Expect(state.ExpirationTimestamp.ValueString()).To(Equal("2024-01-01T12:00:00Z"))
+			Expect(state.RevocationTimestamp.IsNull()).To(BeTrue())
+		})
+
+		It("Saves the credential when it fails to be issued", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(readyCluster(true), nil)
+			credentials.EXPECT().Create(gomock.Any(), clusterId, gomock.Any()).
+				Return(newCredential(cmv1.BreakGlassCredentialStatusCreated, ""), nil)
+			credentials.EXPECT().Get(gomock.Any(), clusterId, credentialId).
+				Return(newCredential(cmv1.BreakGlassCredentialStatusFailed, ""), nil)
+
+			resp := &resource.CreateResponse{State: emptyState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(types.StringNull())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+			Expect(resp.Diagnostics[0].Detail()).To(ContainSubstring("status 'failed'"))
+			state := readState(resp.State)
+			Expect(state.ID.ValueString()).To(Equal(credentialId))
+			Expect(state.Status.ValueString()).To(Equal("failed"))
+			Expect(state.Kubeconfig.IsNull()).To(BeTrue())
+		})
+
+		It("Fails when the cluster doesn't use external authentication", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(readyCluster(false), nil)
+
+			resp := &resource.CreateResponse{State: emptyState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(types.StringNull())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+			Expect(resp.Diagnostics[0].Detail()).To(ContainSubstring("external_auth_providers_enabled"))
+		})
+	})
+
+	Context("Read", func() {
+		It("Keeps the kubeconfig when it isn't returned anymore", func() {
+			credentials.EXPECT().Exists(gomock.Any(), clusterId, credentialId).
+				Return(true, newCredential(cmv1.BreakGlassCredentialStatusExpired, ""), nil)
+
+			resp := &resource.ReadResponse{State: emptyState()}
+			r.Read(ctx, resource.ReadRequest{State: newState(cmv1.BreakGlassCredentialStatusIssued, false)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			state := readState(resp.State)
+			Expect(state.Status.ValueString()).To(Equal("expired"))
+			Expect(state.Kubeconfig.ValueString()).To(Equal(kubeconfig))
+		})
+
+		It("Removes the credential from the state when it doesn't exist", func() {
+			credentials.EXPECT().Exists(gomock.Any(), clusterId, credentialId).Return(false, nil, nil)
+
+			resp := &resource.ReadResponse{State: newState(cmv1.BreakGlassCredentialStatusIssued, false)}
+			r.Read(ctx, resource.ReadRequest{State: newState(cmv1.BreakGlassCredentialStatusIssued, false)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+	})
+
+	Context("Delete", func() {
+		It("Doesn't revoke the credentials by default", func() {
+			resp := &resource.DeleteResponse{State: newState(cmv1.BreakGlassCredentialStatusIssued, false)}
+			r.Delete(ctx, resource.DeleteRequest{State: newState(cmv1.BreakGlassCredentialStatusIssued, false)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+
+		// otherCredential returns a credential of the same cluster with the given identifier.
+		otherCredential := func(id string, status cmv1.BreakGlassCredentialStatus) *cmv1.BreakGlassCredential {
+			credential, err := cmv1.NewBreakGlassCredential().ID(id).Status(status).Build()
+			Expect(err).ToNot(HaveOccurred())
+			return credential
+		}
+
+		It("Revokes the credentials when requested", func() {
+			credentials.EXPECT().List(gomock.Any(), clusterId).Return([]*cmv1.BreakGlassCredential{
+				newCredential(cmv1.BreakGlassCredentialStatusIssued, ""),
+				otherCredential("789", cmv1.BreakGlassCredentialStatusExpired),
+			}, nil)
+			credentials.EXPECT().RevokeAll(gomock.Any(), clusterId).Return(nil)
+
+			resp := &resource.DeleteResponse{State: newState(cmv1.BreakGlassCredentialStatusIssued, true)}
+			r.Delete(ctx, resource.DeleteRequest{State: newState(cmv1.BreakGlassCredentialStatusIssued, true)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+
+		It("Fails when other credentials of the cluster are still issued", func() {
+			credentials.EXPECT().List(gomock.Any(), clusterId).Return([]*cmv1.BreakGlassCredential{
+				newCredential(cmv1.BreakGlassCredentialStatusIssued, ""),
+				otherCredential("789", cmv1.BreakGlassCredentialStatusIssued),
+			}, nil)
+
+			resp := &resource.DeleteResponse{State: newState(cmv1.BreakGlassCredentialStatusIssued, true)}
+			r.Delete(ctx, resource.DeleteRequest{State: newState(cmv1.BreakGlassCredentialStatusIssued, true)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+			Expect(resp.Diagnostics[0].Detail()).To(ContainSubstring("'789'"))
+			Expect(resp.Diagnostics[0].Detail()).To(ContainSubstring("revoke_all_on_destroy"))
+			Expect(resp.State.Raw.IsNull()).To(BeFalse())
+		})
+
+		It("Revokes the other issued credentials too when requested", func() {
+			credentials.EXPECT().RevokeAll(gomock.Any(), clusterId).Return(nil)
+
+			state := newState(cmv1.BreakGlassCredentialStatusIssued, true)
+			Expect(state.SetAttribute(ctx, path.Root("revoke_all_on_destroy"), true).HasError()).To(BeFalse())
+			resp := &resource.DeleteResponse{State: state}
+			r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+
+		It("Doesn't revoke the credentials again when the credential is already revoked", func() {
+			resp := &resource.DeleteResponse{State: newState(cmv1.BreakGlassCredentialStatusRevoked, true)}
+			r.Delete(ctx, resource.DeleteRequest{State: newState(cmv1.BreakGlassCredentialStatusRevoked, true)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+	})
+
+	Context("Import", func() {
+		It("Sets the cluster and the identifier", func() {
+			resp := &resource.ImportStateResponse{State: emptyState()}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: clusterId + "," + credentialId}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			var cluster, id types.String
+			resp.State.GetAttribute(ctx, path.Root("cluster"), &cluster)
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			Expect(cluster.ValueString()).To(Equal(clusterId))
+			Expect(id.ValueString()).To(Equal(credentialId))
+		})
+	})
+
+	DescribeTable("Expiration validation",
+		func(value string, valid bool) {
+			resp := &validator.StringResponse{}
+			expirationValidator().ValidateString(ctx, validator.StringRequest{
+				Path:        path.Root("expiration"),
+				ConfigValue: types.StringValue(value),
+			}, resp)
+			Expect(resp.Diagnostics.HasError()).To(Equal(!valid))
+		},
+		Entry("Accepts a duration within the limits", "2h", true),
+		Entry("Accepts the maximum", "24h", true),
+		Entry("Rejects a duration that is too short", "5m", false),
+		Entry("Rejects a duration that is too long", "48h", false),
+		Entry("Rejects a value that isn't a duration", "tomorrow", false),
+	)
+})
@@ -0,0 +1,33 @@
+package breakglasscredential
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BreakGlassCredentialState struct {
+	Cluster             types.String   `tfsdk:"cluster"`
+	ID                  types.String   `tfsdk:"id"`
+	Username            types.String   `tfsdk:"username"`
+	Expiration          types.String   `tfsdk:"expiration"`
+	ExpirationTimestamp types.String   `tfsdk:"expiration_timestamp"`
+	RevocationTimestamp types.String   `tfsdk:"revocation_timestamp"`
+	Status              types.String   `tfsdk:"status"`
+	Kubeconfig          types.String   `tfsdk:"kubeconfig"`
+	RevokeOnDestroy     types.Bool     `tfsdk:"revoke_on_destroy"`
+	RevokeAllOnDestroy  types.Bool     `tfsdk:"revoke_all_on_destroy"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+type BreakGlassCredentialsState struct {
+	Cluster types.String                `tfsdk:"cluster"`
+	Items   []*BreakGlassCredentialItem `tfsdk:"items"`
+}
+
+type BreakGlassCredentialItem struct {
+	ID                  types.String `tfsdk:"id"`
+	Username            types.String `tfsdk:"username"`
+	ExpirationTimestamp types.String `tfsdk:"expiration_timestamp"`
+	RevocationTimestamp types.String `tfsdk:"revocation_timestamp"`
+	Status              types.String `tfsdk:"status"`
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	tfpschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/transport"
+)
+
+// CassetteConfig contains the settings used to record the requests sent to OCM, or to replay
+// them without network access.
+type CassetteConfig struct {
+	Path types.String `tfsdk:"path"`
+	Mode types.String `tfsdk:"mode"`
+}
+
+func cassetteSchema() map[string]tfpschema.Attribute {
+	return map[string]tfpschema.Attribute{
+		"path": tfpschema.StringAttribute{
+			Description: "Path of the cassette file. It can also be set with the " +
+				"`RHCS_CASSETTE_PATH` environment variable.",
+			Optional: true,
+		},
+		"mode": tfpschema.StringAttribute{
+			Description: fmt.Sprintf("Use `%s` to add the requests sent to OCM and their responses "+
+				"to the cassette, and `%s` to answer the requests with the recorded responses "+
+				"without sending them. It can also be set with the `RHCS_CASSETTE_MODE` "+
+				"environment variable.", transport.CassetteRecord, transport.CassetteReplay),
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(transport.CassetteModes...),
+			},
+		},
+	}
+}
+
+// buildCassette creates the cassette from the configuration and the environment. It returns nil
+// when no cassette is configured.
+func (p *Provider) buildCassette(config *CassetteConfig) (*transport.Cassette, error) {
+	if config == nil {
+		config = &CassetteConfig{
+			Path: types.StringNull(),
+			Mode: types.StringNull(),
+		}
+	}
+	path, pathExists := p.getAttrValueOrConfig(config.Path, "CASSETTE_PATH", "")
+	mode, modeExists := p.getAttrValueOrConfig(config.Mode, "CASSETTE_MODE", "")
+	if !pathExists && !modeExists {
+		return nil, nil
+	}
+	if path == "" || mode == "" {
+		return nil, fmt.Errorf("both the path and the mode of the cassette are required, "+
+			"the mode must be one of %s", strings.Join(transport.CassetteModes, ", "))
+	}
+	return transport.NewCassette(path, mode)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	// nolint:gosec
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/transport"
+)
+
+var _ = Describe("Cassette", func() {
+	var ctx context.Context
+	var server tfprotov6.ProviderServer
+	var providerSchema *tfprotov6.Schema
+	var schemas map[string]*tfprotov6.Schema
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = providerserver.NewProtocol6(New())()
+		response, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		providerSchema = response.Provider
+		schemas = response.ResourceSchemas
+	})
+
+	// object returns a value of the given object type where the attributes that aren't given are
+	// null.
+	object := func(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range typ.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		for name, value := range values {
+			attributes[name] = value
+		}
+		return tftypes.NewValue(typ, attributes)
+	}
+
+	dynamicValue := func(typ tftypes.Object, value tftypes.Value) *tfprotov6.DynamicValue {
+		result, err := tfprotov6.NewDynamicValue(typ, value)
+		Expect(err).NotTo(HaveOccurred())
+		return &result
+	}
+
+	// newCertificate returns a self signed certificate in DER format.
+	newCertificate := func() []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "oidc.example.com"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).NotTo(HaveOccurred())
+		return der
+	}
+
+	// newToken returns an unsigned access token that expires in an hour.
+	newToken := func() string {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+		claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(
+			`{"typ":"Bearer","exp":%d}`, time.Now().Add(time.Hour).Unix())))
+		return header + "." + claims + "."
+	}
+
+	It("Replays a cluster read without network access", func() {
+		certificate := newCertificate()
+		cluster, err := json.Marshal(map[string]interface{}{
+			"id":   "123",
+			"name": "my-cluster",
+			"hypershift": map[string]interface{}{
+				"enabled": true,
+			},
+			"region": map[string]interface{}{
+				"id": "us-east-1",
+			},
+			"aws": map[string]interface{}{
+				"sts": map[string]interface{}{
+					"oidc_endpoint_url": "https://oidc.example.com/123",
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		cassette, err := json.Marshal(map[string]interface{}{
+			"interactions": []*transport.Interaction{
+				{
+					Request: transport.RecordedRequest{
+						Method: "GET",
+						URL:    "/api/clusters_mgmt/v1/clusters/123",
+					},
+					Response: transport.RecordedResponse{
+						Status: 200,
+						Header: map[string]string{"Content-Type": "application/json"},
+						Body:   string(cluster),
+					},
+				},
+				{
+					Request: transport.RecordedRequest{
+						Method: "GET",
+						URL:    "https://oidc.example.com:443",
+					},
+					Response: transport.RecordedResponse{
+						Status:       200,
+						Certificates: []string{base64.StdEncoding.EncodeToString(certificate)},
+					},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		cassettePath := filepath.Join(GinkgoT().TempDir(), "cassette.json")
+		Expect(os.WriteFile(cassettePath, cassette, 0600)).To(Succeed())
+
+		// The URL doesn't resolve, so any request that isn't answered by the cassette fails:
+		providerType := providerSchema.ValueType().(tftypes.Object)
+		cassetteType := providerType.AttributeTypes["cassette"].(tftypes.Object)
+		configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+			Config: dynamicValue(providerType, object(providerType, map[string]tftypes.Value{
+				"url":   tftypes.NewValue(tftypes.String, "https://api.example.invalid"),
+				"token": tftypes.NewValue(tftypes.String, newToken()),
+				"cassette": object(cassetteType, map[string]tftypes.Value{
+					"path": tftypes.NewValue(tftypes.String, cassettePath),
+					"mode": tftypes.NewValue(tftypes.String, transport.CassetteReplay),
+				}),
+			})),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, diagnostic := range configured.Diagnostics {
+			Expect(diagnostic.Severity).NotTo(Equal(tfprotov6.DiagnosticSeverityError),
+				"%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+
+		clusterType := schemas["rhcs_cluster_rosa_hcp"].ValueType().(tftypes.Object)
+		read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+			TypeName: "rhcs_cluster_rosa_hcp",
+			CurrentState: dynamicValue(clusterType, object(clusterType, map[string]tftypes.Value{
+				"id": tftypes.NewValue(tftypes.String, "123"),
+			})),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, diagnostic := range read.Diagnostics {
+			Expect(diagnostic.Severity).NotTo(Equal(tfprotov6.DiagnosticSeverityError),
+				"%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+
+		value, err := read.NewState.Unmarshal(clusterType)
+		Expect(err).NotTo(HaveOccurred())
+		attributes := map[string]tftypes.Value{}
+		Expect(value.As(&attributes)).To(Succeed())
+		sts := map[string]tftypes.Value{}
+		Expect(attributes["sts"].As(&sts)).To(Succeed())
+		var thumbprint string
+		Expect(sts["thumbprint"].As(&thumbprint)).To(Succeed())
+		// nolint:gosec
+		expected := sha1.Sum(certificate)
+		Expect(thumbprint).To(Equal(hex.EncodeToString(expected[:])))
+	})
+})
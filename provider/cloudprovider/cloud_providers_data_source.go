@@ -21,8 +21,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type CloudProvidersDataSource struct {
@@ -97,7 +98,7 @@ func (s *CloudProvidersDataSource) Configure(ctx context.Context, req datasource
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	s.collection = connection.ClustersMgmt().V1().CloudProviders()
@@ -126,9 +127,11 @@ func (s *CloudProvidersDataSource) Read(ctx context.Context, req datasource.Read
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Can't list cloud providers",
 				err.Error(),
+				err,
 			)
 			return
 		}
@@ -33,7 +33,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/errors"
 
@@ -59,7 +58,8 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Description: "OpenShift managed cluster.",
 		DeprecationMessage: fmt.Sprintf(
-			"using cluster as a resource is deprecated; consider using the cluster_rosa_classic resource instead"),
+			"using cluster as a resource is deprecated; consider using the cluster_rosa_classic resource instead, " +
+				"ROSA clusters can be moved to it with a `moved` block"),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier of the cluster.",
@@ -242,14 +242,15 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
 	return
@@ -406,24 +407,28 @@ func (r *ClusterResource) Create(ctx context.Context, request resource.CreateReq
 
 	object, err := createClusterObject(ctx, state, diags)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't build cluster",
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	add, err := r.collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't create cluster",
 			fmt.Sprintf(
 				"Can't create cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -436,6 +441,7 @@ func (r *ClusterResource) Create(ctx context.Context, request resource.CreateReq
 		pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
 		defer cancel()
 		_, err := r.collection.Cluster(object.ID()).Poll().
+			Header(common.CacheControlHeader, common.NoCache).
 			Interval(30 * time.Second).
 			Predicate(func(get *cmv1.ClusterGetResponse) bool {
 				object = get.Body()
@@ -443,12 +449,14 @@ func (r *ClusterResource) Create(ctx context.Context, request resource.CreateReq
 			}).
 			StartContext(pollCtx)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't poll cluster state",
 				fmt.Sprintf(
 					"Can't poll state of cluster with identifier '%s': %v",
 					object.ID(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -457,11 +465,13 @@ func (r *ClusterResource) Create(ctx context.Context, request resource.CreateReq
 	// Save the state:
 	err = populateClusterState(object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -482,12 +492,14 @@ func (r *ClusterResource) Read(ctx context.Context, request resource.ReadRequest
 	// Find the cluster:
 	get, err := r.collection.Cluster(state.ID.ValueString()).Get().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -496,11 +508,13 @@ func (r *ClusterResource) Read(ctx context.Context, request resource.ReadRequest
 	// Save the state:
 	err = populateClusterState(object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -540,12 +554,14 @@ func (r *ClusterResource) Update(ctx context.Context, request resource.UpdateReq
 	}
 	patch, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't build cluster patch",
 			fmt.Sprintf(
 				"Can't build patch for cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -553,12 +569,14 @@ func (r *ClusterResource) Update(ctx context.Context, request resource.UpdateReq
 		Body(patch).
 		SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -567,11 +585,13 @@ func (r *ClusterResource) Update(ctx context.Context, request resource.UpdateReq
 	// Update the state:
 	err = populateClusterState(object, state)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -593,12 +613,14 @@ func (r *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 	resource := r.collection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't delete cluster",
 			fmt.Sprintf(
 				"Can't delete cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -608,6 +630,7 @@ func (r *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 		pollCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 		defer cancel()
 		_, err := resource.Poll().
+			Header(common.CacheControlHeader, common.NoCache).
 			Interval(30 * time.Second).
 			Status(http.StatusNotFound).
 			StartContext(pollCtx)
@@ -616,12 +639,14 @@ func (r *ClusterResource) Delete(ctx context.Context, request resource.DeleteReq
 			err = nil
 		}
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't poll cluster deletion",
 				fmt.Sprintf(
 					"Can't poll deletion of cluster with identifier '%s': %v",
 					state.ID.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -24,7 +24,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	rosaTypes "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
@@ -42,6 +41,7 @@ type ClusterRosaClassicDatasource struct {
 	clusterCollection *cmv1.ClustersClient
 	versionCollection *cmv1.VersionsClient
 	clusterWait       common.ClusterWait
+	httpClient        common.HttpClient
 }
 
 func NewDataSource() datasource.DataSource {
@@ -324,6 +324,7 @@ func (r *ClusterRosaClassicDatasource) Schema(ctx context.Context, req datasourc
 				Description: deprecatedMessage,
 				Computed:    true,
 			},
+			"timeouts": common.TimeoutsDataSourceAttribute(ctx, deprecatedMessage),
 		},
 	}
 }
@@ -334,18 +335,21 @@ func (r *ClusterRosaClassicDatasource) Configure(ctx context.Context, req dataso
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.versionCollection = connection.ClustersMgmt().V1().Versions()
-	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.clusterWait = common.NewClusterWait(r.clusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.httpClient = providerData.HttpClient
 }
 
 func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasource.ReadRequest,
@@ -353,7 +357,7 @@ func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasou
 	tflog.Debug(ctx, "begin Read()")
 	// Get the current state:
 	state := &ClusterRosaClassicState{}
-	diags := request.Config.Get(ctx, state)
+	diags := request.Config.Get(ctx, &state.clusterRosaClassicAttributes)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
@@ -369,12 +373,14 @@ func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasou
 			response.State.RemoveResource(ctx)
 			return
 		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -382,13 +388,15 @@ func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasou
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = populateRosaClassicClusterState(ctx, object, state, r.httpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -398,6 +406,7 @@ func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasou
 	state.ChannelGroup = types.StringNull()
 	state.Version = types.StringNull()
 	state.DestroyTimeout = types.Int64Null()
+	state.Timeouts = common.NullTimeouts()
 	state.UpgradeAcksFor = types.StringNull()
 	state.CreateAdminUser = types.BoolNull()
 	state.AdminCredentials = rosaTypes.AdminCredentialsNull()
@@ -410,6 +419,6 @@ func (r *ClusterRosaClassicDatasource) Read(ctx context.Context, request datasou
 	state.WorkerDiskSize = types.Int64Null()
 	state.DefaultMPLabels = types.MapNull(types.StringType)
 
-	diags = response.State.Set(ctx, state)
+	diags = response.State.Set(ctx, &state.clusterRosaClassicAttributes)
 	response.Diagnostics.Append(diags...)
 }
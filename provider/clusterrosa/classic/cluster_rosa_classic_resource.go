@@ -50,8 +50,6 @@ import (
 	"github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	ocmConsts "github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
-	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocm_errors "github.com/openshift-online/ocm-sdk-go/errors"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
@@ -79,6 +77,15 @@ type ClusterRosaClassicResource struct {
 
 var _ resource.ResourceWithConfigure = &ClusterRosaClassicResource{}
 var _ resource.ResourceWithImportState = &ClusterRosaClassicResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterRosaClassicResource{}
+
+// createErrorAttributes are the attributes that the errors returned by OCM when the cluster is
+// created can point to.
+var createErrorAttributes = []common.ErrorAttribute{
+	{Kind: common.SubnetsError, Path: path.Root("aws_subnet_ids")},
+	{Kind: common.VersionError, Path: path.Root("version")},
+	{Kind: common.RolesError, Path: path.Root("sts")},
+}
 
 func New() resource.Resource {
 	return &ClusterRosaClassicResource{}
@@ -91,6 +98,7 @@ func (r *ClusterRosaClassicResource) Metadata(ctx context.Context, req resource.
 func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "OpenShift managed cluster using rosa sts.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier of the cluster.",
@@ -174,6 +182,11 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"tags_all": schema.MapAttribute{
+				Description: common.TagsAllDescription,
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"ccs_enabled": schema.BoolAttribute{
 				Description: "Enables customer cloud subscription (Immutable with ROSA)",
 				Computed:    true,
@@ -370,8 +383,9 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 				Optional:    true,
 			},
 			"destroy_timeout": schema.Int64Attribute{
-				Description: "This value sets the maximum duration in minutes to allow for destroying resources. Default value is 60 minutes.",
-				Optional:    true,
+				Description:        "This value sets the maximum duration in minutes to allow for destroying resources. Default value is 60 minutes.",
+				Optional:           true,
+				DeprecationMessage: fmt.Sprintf(common.TimeoutsDeprecationFormat, "delete"),
 			},
 			"state": schema.StringAttribute{
 				Description: "State of the cluster.",
@@ -454,14 +468,18 @@ func (r *ClusterRosaClassicResource) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 			"wait_for_create_complete": schema.BoolAttribute{
-				Description: "Wait until the cluster is either in a ready state or in an error state. The waiter has a timeout of 60 minutes, with the default value set to false",
+				Description: "Wait until the cluster is either in a ready state or in an error state. The waiter has the `create` timeout of the `timeouts` block, 60 minutes by default, with the default value set to false",
 				Optional:    true,
 			},
 			"max_cluster_wait_timeout_in_minutes": schema.Int64Attribute{
-				Description: "This value sets the maximum duration in minutes to wait for the cluster to be in a ready state.",
-				Optional:    true,
+				Description:        "This value sets the maximum duration in minutes to wait for the cluster to be in a ready state.",
+				Optional:           true,
+				DeprecationMessage: fmt.Sprintf(common.TimeoutsDeprecationFormat, "create"),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -471,18 +489,23 @@ func (r *ClusterRosaClassicResource) Configure(ctx context.Context, req resource
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.ClusterCollection = connection.ClustersMgmt().V1().Clusters()
-	r.VersionCollection = connection.ClustersMgmt().V1().Versions()
-	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, connection)
+	r.VersionsClient = common.NewVersionsClient(connection.ClustersMgmt().V1().Versions())
+	r.UpgradePoliciesClient = common.NewUpgradePoliciesClient(r.ClusterCollection)
+	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.HttpClient = providerData.HttpClient
+	r.DefaultTags = providerData.DefaultTags
 }
 
 const (
@@ -651,7 +674,8 @@ func createClassicClusterObject(ctx context.Context,
 			state.Sts.OperatorRolePrefix.ValueString(), common.OptionalString(state.Sts.OIDCConfigID))
 	}
 
-	awsTags, err := common.OptionalMap(ctx, state.Tags)
+	// The plan contains the tags merged with the default tags of the provider:
+	awsTags, err := common.OptionalMap(ctx, state.TagsAll)
 	if err != nil {
 		return nil, err
 	}
@@ -827,79 +851,94 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 	}
 	version, err := r.GetAndValidateVersionInChannelGroup(ctx, rosaTypes.Classic, channelGroup, desiredVersion)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	err = validateHttpTokensVersion(ctx, state, version)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	object, err := createClassicClusterObject(ctx, state, diags)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	add, err := r.ClusterCollection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't create cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
+			createErrorAttributes...,
 		)
 		return
 	}
 	object = add.Body()
 
 	// Save initial state:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = populateRosaClassicClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
 
 	if common.HasValue(state.WaitForCreateComplete) && state.WaitForCreateComplete.ValueBool() {
-		timeOut := common.OptionalInt64(state.MaxClusterWaitTimeoutInMinutes)
-		timeOut, err = common.ValidateTimeout(timeOut, rosa.MaxClusterWaitTimeoutInMinutes)
+		timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, state.Timeouts.Create, state.MaxClusterWaitTimeoutInMinutes,
+			time.Duration(rosa.MaxClusterWaitTimeoutInMinutes)*time.Minute, &response.Diagnostics)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Waiting for cluster creation finished with error",
 				fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+				err,
 			)
 		}
-		object, err = r.ClusterWait.WaitForClusterToBeReady(ctx, object.ID(), *timeOut)
+		object, err = r.ClusterWait.WaitForClusterToBeReady(ctx, object.ID(), timeout)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Waiting for cluster creation finished with error",
 				fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+				err,
 			)
 			if object == nil {
 				diags = response.State.Set(ctx, state)
@@ -910,13 +949,15 @@ func (r *ClusterRosaClassicResource) Create(ctx context.Context, request resourc
 	}
 
 	// Save the state post wait completion:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = populateRosaClassicClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -946,12 +987,14 @@ func (r *ClusterRosaClassicResource) Read(ctx context.Context, request resource.
 			response.State.RemoveResource(ctx)
 			return
 		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -959,13 +1002,15 @@ func (r *ClusterRosaClassicResource) Read(ctx context.Context, request resource.
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaClassicClusterState(ctx, object, state, common.DefaultHttpClient{})
+	err = populateRosaClassicClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -1057,6 +1102,12 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	//assert no changes on specific attributes
 	diags = validateNoImmutableAttChange(state, plan)
 	if diags.HasError() {
@@ -1080,9 +1131,11 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 
 	// Schedule a cluster upgrade if a newer version is requested
 	if err := r.upgradeClusterIfNeeded(ctx, state, plan); err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't upgrade cluster",
 			fmt.Sprintf("Can't upgrade cluster version with identifier: `%s`, %v", state.ID.ValueString(), err),
+			err,
 		)
 		return
 	}
@@ -1091,12 +1144,14 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 
 	clusterBuilder, err := updateProxy(state, plan, clusterBuilder)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update proxy's configuration for cluster with identifier: `%s`, %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1125,12 +1180,14 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 
 	clusterSpec, err := clusterBuilder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't build cluster patch",
 			fmt.Sprintf(
 				"Can't build patch for cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1139,12 +1196,14 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 		Body(clusterSpec).
 		SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1152,13 +1211,15 @@ func (r *ClusterRosaClassicResource) Update(ctx context.Context, request resourc
 	object := update.Body()
 
 	// Update the state:
-	err = populateRosaClassicClusterState(ctx, object, plan, common.DefaultHttpClient{})
+	err = populateRosaClassicClusterState(ctx, object, plan, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -1222,13 +1283,13 @@ func (r *ClusterRosaClassicResource) upgradeClusterIfNeeded(ctx context.Context,
 	}
 
 	// Fetch existing upgrade policies
-	upgrades, err := upgrade.GetScheduledUpgrades(ctx, r.ClusterCollection, state.ID.ValueString())
+	upgrades, err := upgrade.GetScheduledUpgrades(ctx, r.UpgradePoliciesClient, state.ID.ValueString())
 	if err != nil {
 		return fmt.Errorf("failed to get upgrade policies: %v", err)
 	}
 
 	// Stop if an upgrade is already in progress
-	correctUpgradePending, err := upgrade.CheckAndCancelUpgrades(ctx, r.ClusterCollection, upgrades, desiredVersion)
+	correctUpgradePending, err := upgrade.CheckAndCancelUpgrades(ctx, r.UpgradePoliciesClient, upgrades, desiredVersion)
 	if err != nil {
 		return err
 	}
@@ -1236,7 +1297,7 @@ func (r *ClusterRosaClassicResource) upgradeClusterIfNeeded(ctx context.Context,
 	// Schedule a new upgrade
 	if !correctUpgradePending && !cancelingUpgradeOnly {
 		ackString := plan.UpgradeAcksFor.ValueString()
-		if err = scheduleUpgrade(ctx, r.ClusterCollection, state.ID.ValueString(), desiredVersion, ackString); err != nil {
+		if err = scheduleUpgrade(ctx, r.UpgradePoliciesClient, state.ID.ValueString(), desiredVersion, ackString); err != nil {
 			return err
 		}
 	}
@@ -1253,7 +1314,7 @@ func (r *ClusterRosaClassicResource) validateUpgrade(ctx context.Context, state,
 		channelGroup = state.ChannelGroup.ValueString()
 	}
 	versionId := ocmUtils.CreateVersionId(state.Version.ValueString(), channelGroup)
-	availableVersions, err := upgrade.GetAvailableUpgradeVersions(ctx, r.VersionCollection, versionId)
+	availableVersions, err := upgrade.GetAvailableUpgradeVersions(ctx, r.VersionsClient, versionId)
 	if err != nil {
 		return fmt.Errorf("failed to get available upgrades: %v", err)
 	}
@@ -1285,13 +1346,11 @@ func (r *ClusterRosaClassicResource) validateUpgrade(ctx context.Context, state,
 }
 
 // Ensure user has acked upgrade gates and schedule the upgrade
-func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID string, desiredVersion *semver.Version, userAckString string) error {
+func scheduleUpgrade(ctx context.Context, client common.UpgradePoliciesClient, clusterID string, desiredVersion *semver.Version, userAckString string) error {
 	// Gate agreements are checked when the upgrade is scheduled, resulting
 	// in an error return. ROSA cli does this by scheduling once w/ dryRun
 	// to look for un-acked agreements.
-	clusterClient := client.Cluster(clusterID)
-	upgradePoliciesClient := clusterClient.UpgradePolicies()
-	gates, description, err := upgrade.CheckMissingAgreements(desiredVersion.String(), clusterID, upgradePoliciesClient)
+	gates, description, err := upgrade.CheckMissingAgreements(ctx, desiredVersion.String(), clusterID, client)
 	if err != nil {
 		return fmt.Errorf("failed to check for missing upgrade agreements: %v", err)
 	}
@@ -1312,8 +1371,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 	for _, gate := range gates {
 		gateID := gate.ID()
 		tflog.Debug(ctx, "Acknowledging version gate", map[string]interface{}{"gateID": gateID})
-		gateAgreementsClient := clusterClient.GateAgreements()
-		err := upgrade.AckVersionGate(gateAgreementsClient, gateID)
+		err := client.AckVersionGate(ctx, clusterID, gateID)
 		if err != nil {
 			return fmt.Errorf("failed to acknowledge version gate '%s' for cluster '%s': %v",
 				gateID, clusterID, err)
@@ -1330,10 +1388,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 	if err != nil {
 		return fmt.Errorf("failed to create upgrade policy: %v", err)
 	}
-	_, err = clusterClient.UpgradePolicies().
-		Add().
-		Body(newPolicy).
-		SendContext(ctx)
+	_, err = client.Create(ctx, clusterID, newPolicy)
 	if err != nil {
 		return fmt.Errorf("failed to schedule upgrade: %v", err)
 	}
@@ -1371,12 +1426,14 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request resourc
 	resource := r.ClusterCollection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't delete cluster",
 			fmt.Sprintf(
 				"Can't delete cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1384,22 +1441,24 @@ func (r *ClusterRosaClassicResource) Delete(ctx context.Context, request resourc
 	if common.HasValue(state.DisableWaitingInDestroy) && state.DisableWaitingInDestroy.ValueBool() {
 		tflog.Info(ctx, "Waiting for destroy to be completed, is disabled")
 	} else {
-		timeout := rosa.MaxClusterWaitTimeoutInMinutes
-		if common.HasValue(state.DestroyTimeout) {
-			if state.DestroyTimeout.ValueInt64() <= 0 {
-				response.Diagnostics.AddWarning(rosa.NonPositiveTimeoutSummary, fmt.Sprintf(rosa.NonPositiveTimeoutFormat, state.ID.ValueString()))
-			} else {
-				timeout = state.DestroyTimeout.ValueInt64()
-			}
+		timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, state.Timeouts.Delete, state.DestroyTimeout,
+			time.Duration(rosa.MaxClusterWaitTimeoutInMinutes)*time.Minute, &response.Diagnostics)
+		if err != nil {
+			response.Diagnostics.AddWarning(rosa.NonPositiveTimeoutSummary, fmt.Sprintf(rosa.NonPositiveTimeoutFormat, state.ID.ValueString()))
+		}
+		if response.Diagnostics.HasError() {
+			return
 		}
 		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, ctx, timeout, resource)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't poll cluster state",
 				fmt.Sprintf(
 					"Can't poll state of cluster with identifier '%s': %v",
 					state.ID.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -1552,7 +1611,7 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 				state.Sts.OperatorRolePrefix = types.StringValue(operatorRolePrefix)
 			}
 		}
-		thumbprint, err := oidcconfigs.FetchThumbprint(stsState.OIDCEndpointURL())
+		thumbprint, err := common.FetchThumbprint(ctx, httpClient, stsState.OIDCEndpointURL())
 		if err != nil {
 			tflog.Error(ctx, fmt.Sprintf("cannot get thumbprint %v", err))
 			state.Sts.Thumbprint = types.StringValue("")
@@ -1708,12 +1767,15 @@ func populateRosaClassicClusterState(ctx context.Context, object *cmv1.Cluster,
 	return nil
 }
 
-func (r *ClusterRosaClassicResource) retryClusterNotFoundWithTimeout(attempts int, sleep time.Duration, ctx context.Context, timeout int64,
+func (r *ClusterRosaClassicResource) retryClusterNotFoundWithTimeout(attempts int, sleep time.Duration, ctx context.Context, timeout time.Duration,
 	resource *cmv1.ClusterClient) (bool, error) {
 	isNotFound, err := r.waitTillClusterIsNotFoundWithTimeout(ctx, timeout, resource)
 	if err != nil {
 		if attempts--; attempts > 0 {
-			time.Sleep(sleep)
+			// Stop at once when the user interrupts the apply, instead of waiting for the retry:
+			if sleepErr := common.Sleep(ctx, sleep); sleepErr != nil {
+				return isNotFound, err
+			}
 			return r.retryClusterNotFoundWithTimeout(attempts, 2*sleep, ctx, timeout, resource)
 		}
 		return isNotFound, err
@@ -1722,12 +1784,12 @@ func (r *ClusterRosaClassicResource) retryClusterNotFoundWithTimeout(attempts in
 	return isNotFound, nil
 }
 
-func (r *ClusterRosaClassicResource) waitTillClusterIsNotFoundWithTimeout(ctx context.Context, timeout int64,
+func (r *ClusterRosaClassicResource) waitTillClusterIsNotFoundWithTimeout(ctx context.Context, timeout time.Duration,
 	resource *cmv1.ClusterClient) (bool, error) {
-	timeoutInMinutes := time.Duration(timeout) * time.Minute
-	pollCtx, cancel := context.WithTimeout(ctx, timeoutInMinutes)
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	_, err := resource.Poll().
+		Header(common.CacheControlHeader, common.NoCache).
 		Interval(rosa.DefaultPollingIntervalInMinutes * time.Minute).
 		Status(http.StatusNotFound).
 		StartContext(pollCtx)
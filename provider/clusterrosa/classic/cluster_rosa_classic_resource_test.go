@@ -43,7 +43,7 @@ type MockHttpClient struct {
 	response *http.Response
 }
 
-func (c MockHttpClient) Get(url string) (resp *http.Response, err error) {
+func (c MockHttpClient) Get(ctx context.Context, url string) (resp *http.Response, err error) {
 	return c.response, nil
 }
 
@@ -140,23 +140,25 @@ func generateBasicRosaClassicClusterState() *ClusterRosaClassicState {
 		return nil
 	}
 	return &ClusterRosaClassicState{
-		Name:              types.StringValue(clusterName),
-		DomainPrefix:      types.StringValue(domainPrefix),
-		CloudRegion:       types.StringValue(regionId),
-		AWSAccountID:      types.StringValue(awsAccountID),
-		AvailabilityZones: azs,
-		Properties:        properties,
-		ChannelGroup:      types.StringValue("stable"),
-		Version:           types.StringValue("4.10"),
-		Proxy: &proxy.Proxy{
-			HttpProxy:  types.StringValue(httpProxy),
-			HttpsProxy: types.StringValue(httpsProxy),
+		clusterRosaClassicAttributes: clusterRosaClassicAttributes{
+			Name:              types.StringValue(clusterName),
+			DomainPrefix:      types.StringValue(domainPrefix),
+			CloudRegion:       types.StringValue(regionId),
+			AWSAccountID:      types.StringValue(awsAccountID),
+			AvailabilityZones: azs,
+			Properties:        properties,
+			ChannelGroup:      types.StringValue("stable"),
+			Version:           types.StringValue("4.10"),
+			Proxy: &proxy.Proxy{
+				HttpProxy:  types.StringValue(httpProxy),
+				HttpsProxy: types.StringValue(httpsProxy),
+			},
+			Sts:         &sts.ClassicSts{},
+			Replicas:    types.Int64Value(2),
+			MinReplicas: types.Int64Null(),
+			MaxReplicas: types.Int64Null(),
+			KMSKeyArn:   types.StringNull(),
 		},
-		Sts:         &sts.ClassicSts{},
-		Replicas:    types.Int64Value(2),
-		MinReplicas: types.Int64Null(),
-		MaxReplicas: types.Int64Null(),
-		KMSKeyArn:   types.StringNull(),
 	}
 }
 
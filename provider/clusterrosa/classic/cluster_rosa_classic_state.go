@@ -17,6 +17,7 @@ limitations under the License.
 package classic
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	rosaTypes "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
@@ -24,6 +25,13 @@ import (
 )
 
 type ClusterRosaClassicState struct {
+	clusterRosaClassicAttributes
+
+	TagsAll types.Map `tfsdk:"tags_all"`
+}
+
+// clusterRosaClassicAttributes are the attributes shared by the classic cluster resource and data source.
+type clusterRosaClassicAttributes struct {
 	APIURL                                    types.String                 `tfsdk:"api_url"`
 	AWSAccountID                              types.String                 `tfsdk:"aws_account_id"`
 	AWSSubnetIDs                              types.List                   `tfsdk:"aws_subnet_ids"`
@@ -80,4 +88,6 @@ type ClusterRosaClassicState struct {
 	DestroyTimeout                 types.Int64 `tfsdk:"destroy_timeout"`
 	WaitForCreateComplete          types.Bool  `tfsdk:"wait_for_create_complete"`
 	MaxClusterWaitTimeoutInMinutes types.Int64 `tfsdk:"max_cluster_wait_timeout_in_minutes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
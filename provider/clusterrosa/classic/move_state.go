@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package classic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cluster"
+	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
+	rosaTypes "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// legacyClusterTypeName is the type name of the deprecated generic cluster resource.
+const legacyClusterTypeName = "rhcs_cluster"
+
+var _ resource.ResourceWithMoveState = &ClusterRosaClassicResource{}
+
+// MoveState converts the state of the deprecated `rhcs_cluster` resource, so that a `moved` block
+// migrates the existing ROSA clusters to this resource without removing and importing them.
+func (r *ClusterRosaClassicResource) MoveState(ctx context.Context) []resource.StateMover {
+	response := &resource.SchemaResponse{}
+	cluster.New().Schema(ctx, resource.SchemaRequest{}, response)
+	return []resource.StateMover{
+		{
+			SourceSchema: &response.Schema,
+			StateMover:   moveLegacyClusterState,
+		},
+	}
+}
+
+func moveLegacyClusterState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	// Other movers may handle other sources, so this isn't an error:
+	if req.SourceTypeName != legacyClusterTypeName || !strings.HasSuffix(req.SourceProviderAddress, "/rhcs") {
+		return
+	}
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Can't move cluster state",
+			fmt.Sprintf("The state of the '%s' resource can't be decoded", legacyClusterTypeName),
+		)
+		return
+	}
+	source := &cluster.ClusterState{}
+	diags := req.SourceState.Get(ctx, source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !source.Product.IsNull() && !strings.EqualFold(source.Product.ValueString(), "rosa") {
+		resp.Diagnostics.AddError(
+			"Can't move cluster state",
+			fmt.Sprintf(
+				"Cluster '%s' has product '%s', only 'rosa' clusters can be moved to this resource",
+				source.ID.ValueString(), source.Product.ValueString(),
+			),
+		)
+		return
+	}
+	target, err := legacyClusterToState(source)
+	if err != nil {
+		common.AddError(
+			&resp.Diagnostics,
+			"Can't move cluster state",
+			fmt.Sprintf("Can't convert the state of cluster '%s': %v", source.ID.ValueString(), err),
+			err,
+		)
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Moved state of cluster '%s' from '%s'", source.ID.ValueString(),
+		legacyClusterTypeName))
+	diags = resp.TargetState.Set(ctx, target)
+	resp.Diagnostics.Append(diags...)
+}
+
+// legacyClusterToState maps the attributes of the deprecated cluster resource to this resource.
+// The AWS credentials, the cloud provider and the product don't exist in this resource, and the
+// attributes that don't exist in the deprecated resource are left null, to be populated by the
+// next refresh.
+func legacyClusterToState(source *cluster.ClusterState) (*ClusterRosaClassicState, error) {
+	version := types.StringNull()
+	if !source.Version.IsNull() && !source.Version.IsUnknown() {
+		version = types.StringValue(strings.TrimPrefix(source.Version.ValueString(), rosa.VersionPrefix))
+	}
+	properties := types.MapNull(types.StringType)
+	ocmProperties := types.MapNull(types.StringType)
+	if !source.Properties.IsNull() && !source.Properties.IsUnknown() {
+		userProperties := map[string]string{}
+		allProperties := map[string]string{}
+		for key, value := range source.Properties.Elements() {
+			text, ok := value.(types.String)
+			if !ok {
+				return nil, fmt.Errorf("property '%s' isn't a string", key)
+			}
+			allProperties[key] = text.ValueString()
+			if _, isDefault := rosa.OCMProperties[key]; !isDefault {
+				userProperties[key] = text.ValueString()
+			}
+		}
+		var err error
+		properties, err = common.ConvertStringMapToMapType(userProperties)
+		if err != nil {
+			return nil, err
+		}
+		ocmProperties, err = common.ConvertStringMapToMapType(allProperties)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ClusterRosaClassicState{
+		clusterRosaClassicAttributes: clusterRosaClassicAttributes{
+			ID:                    source.ID,
+			Name:                  source.Name,
+			DomainPrefix:          source.DomainPrefix,
+			APIURL:                source.APIURL,
+			ConsoleURL:            source.ConsoleURL,
+			State:                 source.State,
+			CloudRegion:           source.CloudRegion,
+			MultiAZ:               source.MultiAZ,
+			AvailabilityZones:     source.AvailabilityZones,
+			CCSEnabled:            source.CCSEnabled,
+			AWSAccountID:          source.AWSAccountID,
+			AWSPrivateLink:        source.AWSPrivateLink,
+			AWSSubnetIDs:          source.AWSSubnetIDs,
+			ComputeMachineType:    source.ComputeMachineType,
+			Replicas:              source.ComputeNodes,
+			MachineCIDR:           source.MachineCIDR,
+			ServiceCIDR:           source.ServiceCIDR,
+			PodCIDR:               source.PodCIDR,
+			HostPrefix:            source.HostPrefix,
+			Proxy:                 source.Proxy,
+			Properties:            properties,
+			OCMProperties:         ocmProperties,
+			Version:               version,
+			CurrentVersion:        version,
+			WaitForCreateComplete: source.Wait,
+
+			AWSAdditionalComputeSecurityGroupIds:      source.AWSAdditionalComputeSecurityGroupIds,
+			AWSAdditionalInfraSecurityGroupIds:        source.AWSAdditionalInfraSecurityGroupIds,
+			AWSAdditionalControlPlaneSecurityGroupIds: source.AWSAdditionalControlPlaneSecurityGroupIds,
+
+			DefaultMPLabels:  types.MapNull(types.StringType),
+			Tags:             types.MapNull(types.StringType),
+			AdminCredentials: rosaTypes.FlattenAdminCredentials("", ""),
+			Timeouts:         common.NullTimeouts(),
+		},
+		TagsAll: types.MapNull(types.StringType),
+	}, nil
+}
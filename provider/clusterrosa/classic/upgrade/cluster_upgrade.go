@@ -17,7 +17,6 @@ package upgrade
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,7 +25,6 @@ import (
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
-	"github.com/zgalor/weberr"
 )
 
 // ClusterUpgrade bundles the description of the upgrade with its current state
@@ -47,8 +45,8 @@ func (cu *ClusterUpgrade) NextRun() time.Time {
 	return cu.policy.NextRun()
 }
 
-func (cu *ClusterUpgrade) Delete(ctx context.Context, client *cmv1.ClustersClient) error {
-	_, err := client.Cluster(cu.policy.ClusterID()).UpgradePolicies().UpgradePolicy(cu.policy.ID()).Delete().SendContext(ctx)
+func (cu *ClusterUpgrade) Delete(ctx context.Context, client common.UpgradePoliciesClient) error {
+	err := client.Delete(ctx, cu.policy.ClusterID(), cu.policy.ID())
 	if err != nil {
 		return fmt.Errorf("failed to delete upgrade policy: %v", err)
 	}
@@ -57,25 +55,21 @@ func (cu *ClusterUpgrade) Delete(ctx context.Context, client *cmv1.ClustersClien
 
 // Get the available upgrade versions that are reachable from a given starting
 // version
-func GetAvailableUpgradeVersions(ctx context.Context, client *cmv1.VersionsClient, fromVersionId string) ([]*cmv1.Version, error) {
+func GetAvailableUpgradeVersions(ctx context.Context, client common.VersionsClient, fromVersionId string) ([]*cmv1.Version, error) {
 	// Retrieve info about the current version
-	resp, err := client.Version(fromVersionId).Get().SendContext(ctx)
+	version, err := client.Get(ctx, fromVersionId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get version information: %v", err)
 	}
-	version := resp.Body()
 
 	// Cycle through the available upgrades and find the ones that are ROSA enabled
 	availableUpgradeVersions := []*cmv1.Version{}
 	for _, v := range version.AvailableUpgrades() {
 		id := ocmUtils.CreateVersionId(v, version.ChannelGroup())
-		resp, err := client.Version(id).
-			Get().
-			Send()
+		availableVersion, err := client.Get(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get version information: %v", err)
 		}
-		availableVersion := resp.Body()
 		if availableVersion.ROSAEnabled() {
 			availableUpgradeVersions = append(availableUpgradeVersions, availableVersion)
 		}
@@ -85,27 +79,13 @@ func GetAvailableUpgradeVersions(ctx context.Context, client *cmv1.VersionsClien
 }
 
 // Get the list of upgrade policies associated with a cluster
-func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clusterId string) ([]ClusterUpgrade, error) {
+func GetScheduledUpgrades(ctx context.Context, client common.UpgradePoliciesClient, clusterId string) ([]ClusterUpgrade, error) {
 	upgrades := []ClusterUpgrade{}
 
 	// Get the upgrade policies for the cluster
-	upgradePolicies := []*cmv1.UpgradePolicy{}
-	upgradeClient := client.Cluster(clusterId).UpgradePolicies()
-	page := 1
-	size := 100
-	for {
-		resp, err := upgradeClient.List().
-			Page(page).
-			Size(size).
-			SendContext(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list upgrade policies: %v", err)
-		}
-		upgradePolicies = append(upgradePolicies, resp.Items().Slice()...)
-		if resp.Size() < size {
-			break
-		}
-		page++
+	upgradePolicies, err := client.List(ctx, clusterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upgrade policies: %v", err)
 	}
 
 	// For each upgrade policy, get its state
@@ -114,16 +94,13 @@ func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clus
 		if policy.UpgradeType() != cmv1.UpgradeTypeOSD {
 			continue
 		}
-		resp, err := upgradeClient.UpgradePolicy(policy.ID()).
-			State().
-			Get().
-			SendContext(ctx)
+		state, err := client.GetState(ctx, clusterId, policy.ID())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get upgrade policy state: %v", err)
 		}
 		upgrades = append(upgrades, ClusterUpgrade{
 			policy:      policy,
-			policyState: resp.Body(),
+			policyState: state,
 		})
 	}
 
@@ -133,7 +110,7 @@ func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clus
 // Check the provided list of upgrades, canceling pending upgrades that are not
 // for the correct version, and returning an error if there is already an
 // upgrade in progress that is not for the desired version
-func CheckAndCancelUpgrades(ctx context.Context, client *cmv1.ClustersClient, upgrades []ClusterUpgrade, desiredVersion *semver.Version) (bool, error) {
+func CheckAndCancelUpgrades(ctx context.Context, client common.UpgradePoliciesClient, upgrades []ClusterUpgrade, desiredVersion *semver.Version) (bool, error) {
 	correctUpgradePending := false
 	tenMinFromNow := time.Now().UTC().Add(10 * time.Minute)
 
@@ -163,26 +140,10 @@ func CheckAndCancelUpgrades(ctx context.Context, client *cmv1.ClustersClient, up
 	return correctUpgradePending, nil
 }
 
-func AckVersionGate(
-	gateAgreementsClient *cmv1.VersionGateAgreementsClient,
-	gateID string) error {
-	agreement, err := cmv1.NewVersionGateAgreement().
-		VersionGate(cmv1.NewVersionGate().ID(gateID)).
-		Build()
-	if err != nil {
-		return err
-	}
-	response, err := gateAgreementsClient.Add().Body(agreement).Send()
-	if err != nil {
-		return common.HandleErr(response.Error(), err)
-	}
-	return nil
-}
-
 // Construct a list of missing gate agreements for upgrade to a given cluster version
 // Returns: a list of all un-acked gate agreements, a string describing the ones that need user ack, and an error
-func CheckMissingAgreements(version string,
-	clusterKey string, upgradePoliciesClient *cmv1.UpgradePoliciesClient) ([]*cmv1.VersionGate, string, error) {
+func CheckMissingAgreements(ctx context.Context, version string,
+	clusterID string, client common.UpgradePoliciesClient) ([]*cmv1.VersionGate, string, error) {
 	upgradePolicyBuilder := cmv1.NewUpgradePolicy().
 		ScheduleType(cmv1.ScheduleTypeManual).
 		Version(version)
@@ -192,10 +153,10 @@ func CheckMissingAgreements(version string,
 	}
 
 	// check if the cluster upgrade requires gate agreements
-	gates, err := getMissingGateAgreements(upgradePolicy, upgradePoliciesClient)
+	gates, err := client.MissingGateAgreements(ctx, clusterID, upgradePolicy)
 	if err != nil {
 		return []*cmv1.VersionGate{}, "", fmt.Errorf("failed to check for missing gate agreements upgrade for "+
-			"cluster '%s': %v", clusterKey, err)
+			"cluster '%s': %v", clusterID, err)
 	}
 	str := "\nMissing required acknowledgements to schedule upgrade." +
 		"\nRead the below description and acknowledge to proceed with upgrade." +
@@ -214,31 +175,3 @@ func CheckMissingAgreements(version string,
 	}
 	return gates, str, nil
 }
-
-func getMissingGateAgreements(
-	upgradePolicy *cmv1.UpgradePolicy,
-	upgradePoliciesClient *cmv1.UpgradePoliciesClient) ([]*cmv1.VersionGate, error) {
-	response, err := upgradePoliciesClient.Add().Parameter("dryRun", true).Body(upgradePolicy).Send()
-	if err != nil && response.Error() != nil {
-		// parse gates list
-		errorDetails, ok := response.Error().GetDetails()
-		if !ok {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		data, err := json.Marshal(errorDetails)
-		if err != nil {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		gates, err := cmv1.UnmarshalVersionGateList(data)
-		if err != nil {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		// return original error if invaild version gate detected
-		if len(gates) > 0 && gates[0].ID() == "" {
-			errType := weberr.ErrorType(response.Error().Status())
-			return []*cmv1.VersionGate{}, errType.Set(weberr.Errorf(response.Error().Reason()))
-		}
-		return gates, nil
-	}
-	return []*cmv1.VersionGate{}, nil
-}
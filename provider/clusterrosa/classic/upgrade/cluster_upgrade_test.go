@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	semver "github.com/hashicorp/go-version"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+const clusterId = "myClusterId"
+
+func buildPolicy(id string, upgradeType cmv1.UpgradeType, version string, nextRun time.Time) *cmv1.UpgradePolicy {
+	policy, err := cmv1.NewUpgradePolicy().ID(id).ClusterID(clusterId).UpgradeType(upgradeType).
+		Version(version).NextRun(nextRun).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return policy
+}
+
+func buildState(value cmv1.UpgradePolicyStateValue) *cmv1.UpgradePolicyState {
+	state, err := cmv1.NewUpgradePolicyState().Value(value).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return state
+}
+
+var _ = Describe("Cluster upgrade", func() {
+	var ctx context.Context
+	var client *common.MockUpgradePoliciesClient
+	var desiredVersion *semver.Version
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		client = common.NewMockUpgradePoliciesClient(gomock.NewController(GinkgoT()))
+		desiredVersion = semver.Must(semver.NewVersion("4.14.1"))
+	})
+
+	Context("GetScheduledUpgrades", func() {
+		It("Returns only the OSD upgrades with their states", func() {
+			soon := time.Now().UTC().Add(time.Minute)
+			client.EXPECT().List(ctx, clusterId).Return([]*cmv1.UpgradePolicy{
+				buildPolicy("osd", cmv1.UpgradeTypeOSD, "4.14.1", soon),
+				buildPolicy("cve", cmv1.UpgradeType("CVE"), "4.14.1", soon),
+			}, nil)
+			client.EXPECT().GetState(ctx, clusterId, "osd").
+				Return(buildState(cmv1.UpgradePolicyStateValueScheduled), nil)
+
+			upgrades, err := GetScheduledUpgrades(ctx, client, clusterId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upgrades).To(HaveLen(1))
+			Expect(upgrades[0].Version()).To(Equal("4.14.1"))
+			Expect(upgrades[0].State()).To(Equal(cmv1.UpgradePolicyStateValueScheduled))
+		})
+		It("Fails when the policies can't be listed", func() {
+			client.EXPECT().List(ctx, clusterId).Return(nil, errors.New("boom"))
+
+			_, err := GetScheduledUpgrades(ctx, client, clusterId)
+			Expect(err).To(MatchError(ContainSubstring("failed to list upgrade policies: boom")))
+		})
+	})
+
+	Context("CheckAndCancelUpgrades", func() {
+		It("Keeps a pending upgrade to the desired version", func() {
+			upgrades := []ClusterUpgrade{{
+				policy:      buildPolicy("123", cmv1.UpgradeTypeOSD, "4.14.1", time.Now().UTC()),
+				policyState: buildState(cmv1.UpgradePolicyStateValuePending),
+			}}
+
+			pending, err := CheckAndCancelUpgrades(ctx, client, upgrades, desiredVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeTrue())
+		})
+		It("Cancels a scheduled upgrade to another version", func() {
+			upgrades := []ClusterUpgrade{{
+				policy:      buildPolicy("123", cmv1.UpgradeTypeOSD, "4.14.0", time.Now().UTC()),
+				policyState: buildState(cmv1.UpgradePolicyStateValueScheduled),
+			}}
+			client.EXPECT().Delete(ctx, clusterId, "123").Return(nil)
+
+			pending, err := CheckAndCancelUpgrades(ctx, client, upgrades, desiredVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeFalse())
+		})
+		It("Cancels a scheduled upgrade to the desired version that runs too late", func() {
+			upgrades := []ClusterUpgrade{{
+				policy:      buildPolicy("123", cmv1.UpgradeTypeOSD, "4.14.1", time.Now().UTC().Add(time.Hour)),
+				policyState: buildState(cmv1.UpgradePolicyStateValueScheduled),
+			}}
+			client.EXPECT().Delete(ctx, clusterId, "123").Return(nil)
+
+			pending, err := CheckAndCancelUpgrades(ctx, client, upgrades, desiredVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pending).To(BeFalse())
+		})
+		It("Fails when an upgrade to another version is in progress", func() {
+			upgrades := []ClusterUpgrade{{
+				policy:      buildPolicy("123", cmv1.UpgradeTypeOSD, "4.14.0", time.Now().UTC()),
+				policyState: buildState(cmv1.UpgradePolicyStateValueStarted),
+			}}
+
+			_, err := CheckAndCancelUpgrades(ctx, client, upgrades, desiredVersion)
+			Expect(err).To(MatchError("a cluster upgrade is already in progress"))
+		})
+	})
+
+	Context("CheckMissingAgreements", func() {
+		It("Describes only the gates that need the acknowledgement of the user", func() {
+			stsGate, err := cmv1.NewVersionGate().ID("sts").STSOnly(true).Description("STS gate").Build()
+			Expect(err).NotTo(HaveOccurred())
+			userGate, err := cmv1.NewVersionGate().ID("user").Description("User gate").
+				DocumentationURL("https://example.com").Build()
+			Expect(err).NotTo(HaveOccurred())
+			client.EXPECT().MissingGateAgreements(ctx, clusterId, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, policy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error) {
+					Expect(policy.Version()).To(Equal("4.14.1"))
+					return []*cmv1.VersionGate{stsGate, userGate}, nil
+				})
+
+			gates, description, err := CheckMissingAgreements(ctx, "4.14.1", clusterId, client)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gates).To(HaveLen(2))
+			Expect(description).To(ContainSubstring("1) User gate"))
+			Expect(description).To(ContainSubstring("URL:         https://example.com"))
+			Expect(description).NotTo(ContainSubstring("STS gate"))
+		})
+	})
+})
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/Masterminds/semver"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -36,9 +38,20 @@ const (
 )
 
 type BaseCluster struct {
-	ClusterCollection *cmv1.ClustersClient
-	VersionCollection *cmv1.VersionsClient
-	ClusterWait       common.ClusterWait
+	ClusterCollection                 *cmv1.ClustersClient
+	ClusterClient                     common.ClusterClient
+	VersionsClient                    common.VersionsClient
+	UpgradePoliciesClient             common.UpgradePoliciesClient
+	ControlPlaneUpgradePoliciesClient common.ControlPlaneUpgradePoliciesClient
+	ClusterWait                       common.ClusterWait
+	HttpClient                        common.HttpClient
+	DefaultTags                       map[string]string
+}
+
+// ModifyPlan calculates the effective tags of the cluster, merging the default tags of the
+// provider, so that they are visible in the plan.
+func (b *BaseCluster) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanTagsAll(ctx, req, resp, b.DefaultTags, path.Root("tags"), path.Root("tags_all"), "cluster")
 }
 
 // getAndValidateVersionInChannelGroup ensures that the cluster version is
@@ -89,8 +102,6 @@ func (b *BaseCluster) getVersionList(ctx context.Context,
 }
 
 func (b *BaseCluster) getVersions(ctx context.Context, topology ClusterTopology, channelGroup string) (versions []*cmv1.Version, err error) {
-	page := 1
-	size := 100
 	searchParams := []string{
 		"enabled = 'true'",
 		"rosa_enabled = 'true'",
@@ -100,23 +111,10 @@ func (b *BaseCluster) getVersions(ctx context.Context, topology ClusterTopology,
 		searchParams = append(searchParams, "hosted_control_plane_enabled = 'true'")
 	}
 	filter := strings.Join(searchParams, " AND ")
-	for {
-		var response *cmv1.VersionsListResponse
-		response, err = b.VersionCollection.List().
-			Search(filter).
-			Order("default desc, id desc").
-			Page(page).
-			Size(size).
-			Send()
-		if err != nil {
-			tflog.Debug(ctx, err.Error())
-			return nil, err
-		}
-		versions = append(versions, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+	versions, err = b.VersionsClient.List(ctx, filter)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		return nil, err
 	}
 
 	// Sort list in descending order
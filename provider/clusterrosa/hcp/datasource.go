@@ -26,7 +26,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
 	rosaTypes "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common/types"
@@ -42,6 +41,7 @@ type ClusterRosaHcpDatasource struct {
 	clusterCollection *cmv1.ClustersClient
 	versionCollection *cmv1.VersionsClient
 	clusterWait       common.ClusterWait
+	httpClient        common.HttpClient
 }
 
 var _ datasource.DataSource = &ClusterRosaHcpDatasource{}
@@ -108,6 +108,11 @@ func (r *ClusterRosaHcpDatasource) Schema(ctx context.Context, req datasource.Sc
 				Description: "Encrypt etcd data. Note that all AWS storage is already encrypted. " + common.ValueCannotBeChangedStringDescription,
 				Computed:    true,
 			},
+			"external_auth_providers_enabled": schema.BoolAttribute{
+				Description: "Indicates if the cluster uses external authentication providers instead of the " +
+					"built-in OAuth server. " + common.ValueCannotBeChangedStringDescription,
+				Computed: true,
+			},
 			"api_url": schema.StringAttribute{
 				Description: "URL of the API server.",
 				Computed:    true,
@@ -251,6 +256,7 @@ func (r *ClusterRosaHcpDatasource) Schema(ctx context.Context, req datasource.Sc
 				Description: deprecatedMessage,
 				Computed:    true,
 			},
+			"timeouts": common.TimeoutsDataSourceAttribute(ctx, deprecatedMessage),
 			"create_admin_user": schema.BoolAttribute{
 				Description: deprecatedMessage,
 				Computed:    true,
@@ -299,18 +305,21 @@ func (r *ClusterRosaHcpDatasource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
 	r.versionCollection = connection.ClustersMgmt().V1().Versions()
-	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.clusterWait = common.NewClusterWait(r.clusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.httpClient = providerData.HttpClient
 }
 
 func (r *ClusterRosaHcpDatasource) Read(ctx context.Context, request datasource.ReadRequest,
@@ -318,7 +327,7 @@ func (r *ClusterRosaHcpDatasource) Read(ctx context.Context, request datasource.
 	tflog.Debug(ctx, "begin Read()")
 	// Get the current state:
 	state := &ClusterRosaHcpState{}
-	diags := request.Config.Get(ctx, state)
+	diags := request.Config.Get(ctx, &state.clusterRosaHcpAttributes)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
@@ -334,25 +343,29 @@ func (r *ClusterRosaHcpDatasource) Read(ctx context.Context, request datasource.
 			response.State.RemoveResource(ctx)
 			return
 		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = populateRosaHcpClusterState(ctx, object, state, r.httpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -362,6 +375,7 @@ func (r *ClusterRosaHcpDatasource) Read(ctx context.Context, request datasource.
 	state.ChannelGroup = types.StringNull()
 	state.Version = types.StringNull()
 	state.DestroyTimeout = types.Int64Null()
+	state.Timeouts = common.NullTimeouts()
 	state.UpgradeAcksFor = types.StringNull()
 	state.WaitForCreateComplete = types.BoolNull()
 	state.WaitForStdComputeNodesComplete = types.BoolNull()
@@ -370,6 +384,6 @@ func (r *ClusterRosaHcpDatasource) Read(ctx context.Context, request datasource.
 	state.CreateAdminUser = types.BoolNull()
 	state.AdminCredentials = rosaTypes.AdminCredentialsNull()
 
-	diags = response.State.Set(ctx, state)
+	diags = response.State.Set(ctx, &state.clusterRosaHcpAttributes)
 	response.Diagnostics.Append(diags...)
 }
@@ -52,9 +52,7 @@ import (
 	"github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	ocmConsts "github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
-	"github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
 	commonutils "github.com/openshift-online/ocm-common/pkg/utils"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocm_errors "github.com/openshift-online/ocm-sdk-go/errors"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -81,6 +79,15 @@ type ClusterRosaHcpResource struct {
 
 var _ resource.ResourceWithConfigure = &ClusterRosaHcpResource{}
 var _ resource.ResourceWithImportState = &ClusterRosaHcpResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterRosaHcpResource{}
+
+// createErrorAttributes are the attributes that the errors returned by OCM when the cluster is
+// created can point to.
+var createErrorAttributes = []common.ErrorAttribute{
+	{Kind: common.SubnetsError, Path: path.Root("aws_subnet_ids")},
+	{Kind: common.VersionError, Path: path.Root("version")},
+	{Kind: common.RolesError, Path: path.Root("sts")},
+}
 
 func New() resource.Resource {
 	return &ClusterRosaHcpResource{}
@@ -93,6 +100,7 @@ func (r *ClusterRosaHcpResource) Metadata(ctx context.Context, req resource.Meta
 func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "OpenShift managed cluster using ROSA HCP.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier of the cluster.",
@@ -159,6 +167,11 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"tags_all": schema.MapAttribute{
+				Description: common.TagsAllDescription,
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"etcd_encryption": schema.BoolAttribute{
 				Description: "Encrypt etcd data. Note that all AWS storage is already encrypted. " + common.ValueCannotBeChangedStringDescription,
 				Optional:    true,
@@ -167,6 +180,16 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"external_auth_providers_enabled": schema.BoolAttribute{
+				Description: "Enable external authentication providers, which replace the built-in OAuth server " +
+					"of the cluster to authenticate the users of the API. The providers are managed with the " +
+					"'rhcs_external_auth_provider' resource. " + common.ValueCannotBeChangedStringDescription,
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"api_url": schema.StringAttribute{
 				Description: "URL of the API server.",
 				Computed:    true,
@@ -310,8 +333,9 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Optional:    true,
 			},
 			"destroy_timeout": schema.Int64Attribute{
-				Description: "This value sets the maximum duration in minutes to allow for destroying resources. Default value is 60 minutes.",
-				Optional:    true,
+				Description:        "This value sets the maximum duration in minutes to allow for destroying resources. Default value is 60 minutes.",
+				Optional:           true,
+				DeprecationMessage: fmt.Sprintf(common.TimeoutsDeprecationFormat, "delete"),
 			},
 			"state": schema.StringAttribute{
 				Description: "State of the cluster.",
@@ -324,7 +348,7 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Optional: true,
 			},
 			"wait_for_create_complete": schema.BoolAttribute{
-				Description: "Wait until the cluster is either in a ready state or in an error state. The waiter has a timeout of 45 minutes, with the default value set to false",
+				Description: "Wait until the cluster is either in a ready state or in an error state. The waiter has the `create` timeout of the `timeouts` block, 45 minutes by default, with the default value set to false",
 				Optional:    true,
 			},
 			"wait_for_std_compute_nodes_complete": schema.BoolAttribute{
@@ -332,8 +356,9 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Optional:    true,
 			},
 			"max_hcp_cluster_wait_timeout_in_minutes": schema.Int64Attribute{
-				Description: "This value sets the maximum duration in minutes to wait for a HCP cluster to be in a ready state.",
-				Optional:    true,
+				Description:        "This value sets the maximum duration in minutes to wait for a HCP cluster to be in a ready state.",
+				Optional:           true,
+				DeprecationMessage: fmt.Sprintf(common.TimeoutsDeprecationFormat, "create"),
 			},
 			"max_machinepool_wait_timeout_in_minutes": schema.Int64Attribute{
 				Description: "This value sets the maximum duration in minutes to wait for machine pools to be in a ready state.",
@@ -413,6 +438,9 @@ func (r *ClusterRosaHcpResource) Schema(ctx context.Context, req resource.Schema
 				Optional:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -422,18 +450,24 @@ func (r *ClusterRosaHcpResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connection, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.ClusterCollection = connection.ClustersMgmt().V1().Clusters()
-	r.VersionCollection = connection.ClustersMgmt().V1().Versions()
-	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, connection)
+	r.ClusterClient = common.NewClusterClient(r.ClusterCollection)
+	r.VersionsClient = common.NewVersionsClient(connection.ClustersMgmt().V1().Versions())
+	r.ControlPlaneUpgradePoliciesClient = common.NewControlPlaneUpgradePoliciesClient(r.ClusterCollection)
+	r.ClusterWait = common.NewClusterWait(r.ClusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.HttpClient = providerData.HttpClient
+	r.DefaultTags = providerData.DefaultTags
 }
 
 const (
@@ -520,6 +554,11 @@ func createHcpClusterObject(ctx context.Context,
 		builder.EtcdEncryption(state.EtcdEncryption.ValueBool())
 	}
 
+	if common.HasValue(state.ExternalAuthProvidersEnabled) {
+		builder.ExternalAuthConfig(cmv1.NewExternalAuthConfig().
+			Enabled(state.ExternalAuthProvidersEnabled.ValueBool()))
+	}
+
 	if common.HasValue(state.ExternalID) {
 		builder.ExternalID(state.ExternalID.ValueString())
 	}
@@ -568,7 +607,8 @@ func createHcpClusterObject(ctx context.Context,
 			state.Sts.OperatorRolePrefix.ValueString(), common.OptionalString(state.Sts.OIDCConfigID))
 	}
 
-	awsTags, err := common.OptionalMap(ctx, state.Tags)
+	// The plan contains the tags merged with the default tags of the provider:
+	awsTags, err := common.OptionalMap(ctx, state.TagsAll)
 	if err != nil {
 		return nil, err
 	}
@@ -773,68 +813,81 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 	}
 	_, err := r.GetAndValidateVersionInChannelGroup(ctx, rosaTypes.Hcp, channelGroup, desiredVersion)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	object, err := createHcpClusterObject(ctx, state, diags)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't build cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	add, err := r.ClusterCollection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			summary,
 			fmt.Sprintf(
 				"Can't create cluster with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
+			createErrorAttributes...,
 		)
 		return
 	}
 	object = add.Body()
 
 	// Save initial state:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = populateRosaHcpClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
 
 	if shouldWaitCreationComplete {
 		tflog.Info(ctx, "Waiting for cluster to get ready")
-		timeOut := common.OptionalInt64(state.MaxHCPClusterWaitTimeoutInMinutes)
-		timeOut, err = common.ValidateTimeout(timeOut, rosa.MaxHCPClusterWaitTimeoutInMinutes)
+		timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, state.Timeouts.Create, state.MaxHCPClusterWaitTimeoutInMinutes,
+			time.Duration(rosa.MaxHCPClusterWaitTimeoutInMinutes)*time.Minute, &response.Diagnostics)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Waiting for cluster creation finished with error",
 				fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+				err,
 			)
 		}
-		object, err = r.ClusterWait.WaitForClusterToBeReady(ctx, object.ID(), *timeOut)
+		object, err = r.ClusterWait.WaitForClusterToBeReady(ctx, object.ID(), timeout)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Waiting for cluster creation finished with error",
 				fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+				err,
 			)
 			if object == nil {
 				diags = response.State.Set(ctx, state)
@@ -844,19 +897,23 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 		}
 		if shouldWaitComputeNodesComplete {
 			tflog.Info(ctx, "Waiting for standard compute nodes to get ready")
-			timeOut := common.OptionalInt64(state.MaxMachinePoolWaitTimeoutInMinutes)
-			timeOut, err = common.ValidateTimeout(timeOut, rosa.MaxMachinePoolWaitTimeoutInMinutes)
+			timeout, err := common.TimeoutFromMinutes(state.MaxMachinePoolWaitTimeoutInMinutes,
+				time.Duration(rosa.MaxMachinePoolWaitTimeoutInMinutes)*time.Minute)
 			if err != nil {
-				response.Diagnostics.AddError(
+				common.AddError(
+					&response.Diagnostics,
 					"Waiting for cluster creation finished with error",
 					fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+					err,
 				)
 			}
-			object, err = r.ClusterWait.WaitForStdComputeNodesToBeReady(ctx, object.ID(), *timeOut)
+			object, err = r.ClusterWait.WaitForStdComputeNodesToBeReady(ctx, object.ID(), timeout)
 			if err != nil {
-				response.Diagnostics.AddError(
+				common.AddError(
+					&response.Diagnostics,
 					"Waiting for std compute nodes completion finished with error",
 					fmt.Sprintf("Waiting for std compute nodes completion finished with the error %v", err),
+					err,
 				)
 				if object == nil {
 					diags = response.State.Set(ctx, state)
@@ -868,13 +925,15 @@ func (r *ClusterRosaHcpResource) Create(ctx context.Context, request resource.Cr
 	}
 
 	// Save the state post wait completion:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = populateRosaHcpClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -904,12 +963,14 @@ func (r *ClusterRosaHcpResource) Read(ctx context.Context, request resource.Read
 			response.State.RemoveResource(ctx)
 			return
 		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -917,13 +978,15 @@ func (r *ClusterRosaHcpResource) Read(ctx context.Context, request resource.Read
 	object := get.Body()
 
 	// Save the state:
-	err = populateRosaHcpClusterState(ctx, object, state)
+	err = populateRosaHcpClusterState(ctx, object, state, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -942,6 +1005,8 @@ func validateNoImmutableAttChange(state, plan *ClusterRosaHcpState) diag.Diagnos
 	common.ValidateStateAndPlanEquals(state.AWSAccountID, plan.AWSAccountID, "aws_account_id", &diags)
 	common.ValidateStateAndPlanEquals(state.AWSSubnetIDs, plan.AWSSubnetIDs, "aws_subnet_ids", &diags)
 	common.ValidateStateAndPlanEquals(state.EtcdEncryption, plan.EtcdEncryption, "etcd_encryption", &diags)
+	common.ValidateStateAndPlanEquals(state.ExternalAuthProvidersEnabled, plan.ExternalAuthProvidersEnabled,
+		"external_auth_providers_enabled", &diags)
 	common.ValidateStateAndPlanEquals(state.KMSKeyArn, plan.KMSKeyArn, "kms_key_arn", &diags)
 	common.ValidateStateAndPlanEquals(state.EtcdKmsKeyArn, plan.EtcdKmsKeyArn, "etcd_kms_key_arn", &diags)
 	common.ValidateStateAndPlanEquals(state.Private, plan.Private, "private", &diags)
@@ -1005,6 +1070,12 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	//assert no changes on specific attributes
 	diags = validateNoImmutableAttChange(state, plan)
 	if diags.HasError() {
@@ -1028,9 +1099,11 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 
 	// Schedule a cluster upgrade if a newer version is requested
 	if err := r.upgradeClusterIfNeeded(ctx, state, plan); err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't upgrade cluster",
 			fmt.Sprintf("Can't upgrade cluster version with identifier: `%s`, %v", state.ID.ValueString(), err),
+			err,
 		)
 		return
 	}
@@ -1039,12 +1112,14 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 
 	clusterBuilder, err := updateProxy(state, plan, clusterBuilder)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update proxy's configuration for cluster with identifier: `%s`, %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1069,9 +1144,11 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 	registryConfigBuilder, err := registry_config.UpdateRegistryConfigBuilder(ctx,
 		state.RegistryConfig, plan.RegistryConfig)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't patch cluster",
 			fmt.Sprintf("Can't patch registry config for cluster with identifier: '%s', %v", state.ID.ValueString(), err),
+			err,
 		)
 		return
 	}
@@ -1085,9 +1162,11 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 	if toPatch, shouldPatch := common.ShouldPatchList(state.AWSAdditionalAllowedPrincipals, plan.AWSAdditionalAllowedPrincipals); shouldPatch {
 		additionalAllowedPrincipalsPatch, err := common.StringListToArray(ctx, toPatch)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't patch cluster",
 				fmt.Sprintf("Can't patch additional allowed principals for cluster with identifier: '%s', %v", state.ID.ValueString(), err),
+				err,
 			)
 			return
 		}
@@ -1106,12 +1185,14 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 
 	clusterSpec, err := clusterBuilder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't build cluster patch",
 			fmt.Sprintf(
 				"Can't build patch for cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1120,12 +1201,14 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 		Body(clusterSpec).
 		SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't update cluster",
 			fmt.Sprintf(
 				"Can't update cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1133,13 +1216,15 @@ func (r *ClusterRosaHcpResource) Update(ctx context.Context, request resource.Up
 	object := update.Body()
 
 	// Update the state:
-	err = populateRosaHcpClusterState(ctx, object, plan)
+	err = populateRosaHcpClusterState(ctx, object, plan, r.HttpClient)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't populate cluster state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -1199,14 +1284,14 @@ func (r *ClusterRosaHcpResource) upgradeClusterIfNeeded(ctx context.Context, sta
 	}
 
 	// Fetch existing upgrade policies
-	upgrades, err := upgrade.GetScheduledUpgrades(ctx, r.ClusterCollection, state.ID.ValueString())
+	upgrades, err := upgrade.GetScheduledUpgrades(ctx, r.ControlPlaneUpgradePoliciesClient, state.ID.ValueString())
 	if err != nil {
 		return fmt.Errorf("failed to get upgrade policies: %v", err)
 	}
 
 	// Stop if an upgrade is already in progress
 	correctUpgradePending, err := upgrade.CheckAndCancelUpgrades(
-		ctx, r.ClusterCollection, upgrades, desiredVersion)
+		ctx, r.ControlPlaneUpgradePoliciesClient, upgrades, desiredVersion)
 	if err != nil {
 		return err
 	}
@@ -1214,7 +1299,7 @@ func (r *ClusterRosaHcpResource) upgradeClusterIfNeeded(ctx context.Context, sta
 	// Schedule a new upgrade
 	if !correctUpgradePending && !cancelingUpgradeOnly {
 		ackString := plan.UpgradeAcksFor.ValueString()
-		if err = scheduleUpgrade(ctx, r.ClusterCollection, state.ID.ValueString(), desiredVersion, ackString); err != nil {
+		if err = scheduleUpgrade(ctx, r.ControlPlaneUpgradePoliciesClient, state.ID.ValueString(), desiredVersion, ackString); err != nil {
 			return err
 		}
 	}
@@ -1226,7 +1311,7 @@ func (r *ClusterRosaHcpResource) upgradeClusterIfNeeded(ctx context.Context, sta
 
 func (r *ClusterRosaHcpResource) validateUpgrade(ctx context.Context, state, plan *ClusterRosaHcpState) error {
 	availableVersions, err := upgrade.GetAvailableUpgradeVersions(
-		ctx, r.ClusterCollection, r.VersionCollection, state.ID.ValueString())
+		ctx, r.ClusterClient, r.VersionsClient, state.ID.ValueString())
 	if err != nil {
 		return fmt.Errorf("failed to get available upgrades: %v", err)
 	}
@@ -1258,13 +1343,11 @@ func (r *ClusterRosaHcpResource) validateUpgrade(ctx context.Context, state, pla
 }
 
 // Ensure user has acked upgrade gates and schedule the upgrade
-func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID string, desiredVersion *semver.Version, userAckString string) error {
+func scheduleUpgrade(ctx context.Context, client common.ControlPlaneUpgradePoliciesClient, clusterID string, desiredVersion *semver.Version, userAckString string) error {
 	// Gate agreements are checked when the upgrade is scheduled, resulting
 	// in an error return. ROSA cli does this by scheduling once w/ dryRun
 	// to look for un-acked agreements.
-	clusterClient := client.Cluster(clusterID)
-	upgradePoliciesClient := clusterClient.ControlPlane().UpgradePolicies()
-	gates, description, err := upgrade.CheckMissingAgreements(desiredVersion.String(), clusterID, upgradePoliciesClient)
+	gates, description, err := upgrade.CheckMissingAgreements(ctx, desiredVersion.String(), clusterID, client)
 	if err != nil {
 		return fmt.Errorf("failed to check for missing upgrade agreements: %v", err)
 	}
@@ -1285,8 +1368,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 	for _, gate := range gates {
 		gateID := gate.ID()
 		tflog.Debug(ctx, "Acknowledging version gate", map[string]interface{}{"gateID": gateID})
-		gateAgreementsClient := clusterClient.GateAgreements()
-		err := upgrade.AckVersionGate(gateAgreementsClient, gateID)
+		err := client.AckVersionGate(ctx, clusterID, gateID)
 		if err != nil {
 			return fmt.Errorf("failed to acknowledge version gate '%s' for cluster '%s': %v",
 				gateID, clusterID, err)
@@ -1303,10 +1385,7 @@ func scheduleUpgrade(ctx context.Context, client *cmv1.ClustersClient, clusterID
 	if err != nil {
 		return fmt.Errorf("failed to create upgrade policy: %v", err)
 	}
-	_, err = clusterClient.ControlPlane().UpgradePolicies().
-		Add().
-		Body(newPolicy).
-		SendContext(ctx)
+	_, err = client.Create(ctx, clusterID, newPolicy)
 	if err != nil {
 		return fmt.Errorf("failed to schedule upgrade: %v", err)
 	}
@@ -1344,12 +1423,14 @@ func (r *ClusterRosaHcpResource) Delete(ctx context.Context, request resource.De
 	resource := r.ClusterCollection.Cluster(state.ID.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't delete cluster",
 			fmt.Sprintf(
 				"Can't delete cluster with identifier '%s': %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -1357,22 +1438,24 @@ func (r *ClusterRosaHcpResource) Delete(ctx context.Context, request resource.De
 	if common.HasValue(state.DisableWaitingInDestroy) && state.DisableWaitingInDestroy.ValueBool() {
 		tflog.Info(ctx, "Waiting for destroy to be completed, is disabled")
 	} else {
-		timeout := rosa.MaxMachinePoolWaitTimeoutInMinutes
-		if common.HasValue(state.DestroyTimeout) {
-			if state.DestroyTimeout.ValueInt64() <= 0 {
-				response.Diagnostics.AddWarning(rosa.NonPositiveTimeoutSummary, fmt.Sprintf(rosa.NonPositiveTimeoutFormat, state.ID.ValueString()))
-			} else {
-				timeout = state.DestroyTimeout.ValueInt64()
-			}
+		timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, state.Timeouts.Delete, state.DestroyTimeout,
+			time.Duration(rosa.MaxMachinePoolWaitTimeoutInMinutes)*time.Minute, &response.Diagnostics)
+		if err != nil {
+			response.Diagnostics.AddWarning(rosa.NonPositiveTimeoutSummary, fmt.Sprintf(rosa.NonPositiveTimeoutFormat, state.ID.ValueString()))
+		}
+		if response.Diagnostics.HasError() {
+			return
 		}
 		isNotFound, err := r.retryClusterNotFoundWithTimeout(3, 1*time.Minute, ctx, timeout, resource)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't poll cluster state",
 				fmt.Sprintf(
 					"Can't poll state of cluster with identifier '%s': %v",
 					state.ID.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -1397,7 +1480,7 @@ func (r *ClusterRosaHcpResource) ImportState(ctx context.Context, request resour
 }
 
 // populateRosaHcpClusterState copies the data from the API object to the Terraform state.
-func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, state *ClusterRosaHcpState) error {
+func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, state *ClusterRosaHcpState, httpClient common.HttpClient) error {
 	state.ID = types.StringValue(object.ID())
 	state.ExternalID = types.StringValue(object.ExternalID())
 	object.API()
@@ -1443,6 +1526,7 @@ func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, stat
 	}
 
 	state.EtcdEncryption = types.BoolValue(object.EtcdEncryption())
+	state.ExternalAuthProvidersEnabled = types.BoolValue(object.ExternalAuthConfig().Enabled())
 
 	// Note: The API does not currently return account id, but we try to get it
 	// anyway. Failing that, we fetch the creator ARN from the properties like
@@ -1504,7 +1588,7 @@ func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, stat
 				state.Sts.OperatorRolePrefix = types.StringValue(operatorRolePrefix)
 			}
 		}
-		thumbprint, err := oidcconfigs.FetchThumbprint(stsState.OIDCEndpointURL())
+		thumbprint, err := common.FetchThumbprint(ctx, httpClient, stsState.OIDCEndpointURL())
 		if err != nil {
 			tflog.Error(ctx, fmt.Sprintf("cannot get thumbprint %v", err))
 			state.Sts.Thumbprint = types.StringValue("")
@@ -1659,12 +1743,15 @@ func populateRosaHcpClusterState(ctx context.Context, object *cmv1.Cluster, stat
 	return nil
 }
 
-func (r *ClusterRosaHcpResource) retryClusterNotFoundWithTimeout(attempts int, sleep time.Duration, ctx context.Context, timeout int64,
+func (r *ClusterRosaHcpResource) retryClusterNotFoundWithTimeout(attempts int, sleep time.Duration, ctx context.Context, timeout time.Duration,
 	resource *cmv1.ClusterClient) (bool, error) {
 	isNotFound, err := r.waitTillClusterIsNotFoundWithTimeout(ctx, timeout, resource)
 	if err != nil {
 		if attempts--; attempts > 0 {
-			time.Sleep(sleep)
+			// Stop at once when the user interrupts the apply, instead of waiting for the retry:
+			if sleepErr := common.Sleep(ctx, sleep); sleepErr != nil {
+				return isNotFound, err
+			}
 			return r.retryClusterNotFoundWithTimeout(attempts, 2*sleep, ctx, timeout, resource)
 		}
 		return isNotFound, err
@@ -1673,12 +1760,12 @@ func (r *ClusterRosaHcpResource) retryClusterNotFoundWithTimeout(attempts int, s
 	return isNotFound, nil
 }
 
-func (r *ClusterRosaHcpResource) waitTillClusterIsNotFoundWithTimeout(ctx context.Context, timeout int64,
+func (r *ClusterRosaHcpResource) waitTillClusterIsNotFoundWithTimeout(ctx context.Context, timeout time.Duration,
 	resource *cmv1.ClusterClient) (bool, error) {
-	timeoutInMinutes := time.Duration(timeout) * time.Minute
-	pollCtx, cancel := context.WithTimeout(ctx, timeoutInMinutes)
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	_, err := resource.Poll().
+		Header(common.CacheControlHeader, common.NoCache).
 		Interval(rosa.DefaultPollingIntervalInMinutes * time.Minute).
 		Status(http.StatusNotFound).
 		StartContext(pollCtx)
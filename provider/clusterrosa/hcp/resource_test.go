@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -37,13 +38,14 @@ import (
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/proxy"
+	"go.uber.org/mock/gomock"
 )
 
 type MockHttpClient struct {
 	response *http.Response
 }
 
-func (c MockHttpClient) Get(url string) (resp *http.Response, err error) {
+func (c MockHttpClient) Get(ctx context.Context, url string) (resp *http.Response, err error) {
 	return c.response, nil
 }
 
@@ -151,23 +153,25 @@ func generateBasicRosaHcpClusterState() *ClusterRosaHcpState {
 	}
 	subnetIdsList, _ := types.ListValueFrom(context.TODO(), types.StringType, subnetIds)
 	return &ClusterRosaHcpState{
-		Name:                types.StringValue(clusterName),
-		DomainPrefix:        types.StringValue(domainPrefix),
-		CloudRegion:         types.StringValue(regionId),
-		AWSAccountID:        types.StringValue(awsAccountID),
-		AWSBillingAccountID: types.StringValue(awsBillingAccountId),
-		AvailabilityZones:   azs,
-		Properties:          properties,
-		ChannelGroup:        types.StringValue("stable"),
-		Version:             types.StringValue("4.12"),
-		Proxy: &proxy.Proxy{
-			HttpProxy:  types.StringValue(httpProxy),
-			HttpsProxy: types.StringValue(httpsProxy),
+		clusterRosaHcpAttributes: clusterRosaHcpAttributes{
+			Name:                types.StringValue(clusterName),
+			DomainPrefix:        types.StringValue(domainPrefix),
+			CloudRegion:         types.StringValue(regionId),
+			AWSAccountID:        types.StringValue(awsAccountID),
+			AWSBillingAccountID: types.StringValue(awsBillingAccountId),
+			AvailabilityZones:   azs,
+			Properties:          properties,
+			ChannelGroup:        types.StringValue("stable"),
+			Version:             types.StringValue("4.12"),
+			Proxy: &proxy.Proxy{
+				HttpProxy:  types.StringValue(httpProxy),
+				HttpsProxy: types.StringValue(httpsProxy),
+			},
+			Sts:          &sts.HcpSts{},
+			Replicas:     types.Int64Value(2),
+			KMSKeyArn:    types.StringNull(),
+			AWSSubnetIDs: subnetIdsList,
 		},
-		Sts:          &sts.HcpSts{},
-		Replicas:     types.Int64Value(2),
-		KMSKeyArn:    types.StringNull(),
-		AWSSubnetIDs: subnetIdsList,
 	}
 }
 
@@ -211,6 +215,14 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 			Expect(channel).To(Equal("stable"))
 		})
 	})
+	It("Enables the external authentication providers", func() {
+		clusterState := generateBasicRosaHcpClusterState()
+		clusterState.ExternalAuthProvidersEnabled = types.BoolValue(true)
+		rosaClusterObject, err := createHcpClusterObject(context.Background(), clusterState, diag.Diagnostics{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rosaClusterObject.ExternalAuthConfig().Enabled()).To(BeTrue())
+	})
+
 	It("Throws an error when version format is invalid", func() {
 		clusterState := generateBasicRosaHcpClusterState()
 		clusterState.Version = types.StringValue("a.4.1")
@@ -248,7 +260,7 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 
 			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
 			Expect(err).ToNot(HaveOccurred())
-			Expect(populateRosaHcpClusterState(context.Background(), clusterObject, clusterState)).To(Succeed())
+			Expect(populateRosaHcpClusterState(context.Background(), clusterObject, clusterState, mockHttpClient)).To(Succeed())
 
 			Expect(clusterState.ID.ValueString()).To(Equal(clusterId))
 			Expect(clusterState.CloudRegion.ValueString()).To(Equal(regionId))
@@ -279,6 +291,21 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 			Expect(clusterState.Sts.OIDCEndpointURL.ValueString()).To(Equal(oidcEndpointUrl))
 			Expect(clusterState.Sts.RoleARN.ValueString()).To(Equal(roleArn))
 			Expect(clusterState.Ec2MetadataHttpTokens.ValueString()).To(Equal(httpTokens))
+			Expect(clusterState.ExternalAuthProvidersEnabled.ValueBool()).To(BeFalse())
+		})
+		It("Reads whether the external authentication providers are enabled", func() {
+			clusterState := &ClusterRosaHcpState{}
+			clusterJson := generateBasicRosaHcpClusterJson()
+			clusterJson["external_auth_config"] = map[string]interface{}{
+				"enabled": true,
+			}
+			clusterJsonString, err := json.Marshal(clusterJson)
+			Expect(err).ToNot(HaveOccurred())
+
+			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(populateRosaHcpClusterState(context.Background(), clusterObject, clusterState, mockHttpClient)).To(Succeed())
+			Expect(clusterState.ExternalAuthProvidersEnabled.ValueBool()).To(BeTrue())
 		})
 		It("Check trimming of oidc url with https perfix", func() {
 			clusterState := &ClusterRosaHcpState{}
@@ -292,7 +319,7 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
 			Expect(err).ToNot(HaveOccurred())
 
-			err = populateRosaHcpClusterState(context.Background(), clusterObject, clusterState)
+			err = populateRosaHcpClusterState(context.Background(), clusterObject, clusterState, mockHttpClient)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(clusterState.Sts.OIDCEndpointURL.ValueString()).To(Equal("nonce.com"))
 		})
@@ -307,7 +334,7 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 			clusterObject, err := cmv1.UnmarshalCluster(clusterJsonString)
 			Expect(err).ToNot(HaveOccurred())
 
-			err = populateRosaHcpClusterState(context.Background(), clusterObject, clusterState)
+			err = populateRosaHcpClusterState(context.Background(), clusterObject, clusterState, mockHttpClient)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(clusterState.Sts.Thumbprint.ValueString()).To(Equal(""))
 		})
@@ -351,4 +378,123 @@ var _ = Describe("Rosa HCP Sts cluster", func() {
 			Expect(user.HashedPassword()).NotTo(Equal(password))
 		})
 	})
+
+	Context("upgradeClusterIfNeeded", func() {
+		var (
+			ctx           context.Context
+			clusterClient *common.MockClusterClient
+			versions      *common.MockVersionsClient
+			policies      *common.MockControlPlaneUpgradePoliciesClient
+			r             *ClusterRosaHcpResource
+			state         *ClusterRosaHcpState
+			plan          *ClusterRosaHcpState
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			ctrl := gomock.NewController(GinkgoT())
+			clusterClient = common.NewMockClusterClient(ctrl)
+			versions = common.NewMockVersionsClient(ctrl)
+			policies = common.NewMockControlPlaneUpgradePoliciesClient(ctrl)
+			r = &ClusterRosaHcpResource{
+				BaseCluster: rosaTypes.BaseCluster{
+					ClusterClient:                     clusterClient,
+					VersionsClient:                    versions,
+					ControlPlaneUpgradePoliciesClient: policies,
+				},
+			}
+			state = &ClusterRosaHcpState{}
+			state.ID = types.StringValue(clusterId)
+			state.Version = types.StringValue("4.14.1")
+			state.CurrentVersion = types.StringValue("4.14.1")
+			plan = &ClusterRosaHcpState{}
+			plan.Version = types.StringValue("4.14.2")
+			plan.UpgradeAcksFor = types.StringNull()
+		})
+
+		// expectAvailableUpgrade makes the cluster offer the upgrade to 4.14.2.
+		expectAvailableUpgrade := func() {
+			cluster, err := cmv1.NewCluster().ID(clusterId).
+				Version(cmv1.NewVersion().ID("openshift-v4.14.1").ChannelGroup("stable").
+					AvailableUpgrades("4.14.2")).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			clusterClient.EXPECT().FetchCluster(gomock.Any(), clusterId).Return(cluster, nil)
+			version, err := cmv1.NewVersion().ID("openshift-v4.14.2").RawID("4.14.2").
+				HostedControlPlaneEnabled(true).Build()
+			Expect(err).ToNot(HaveOccurred())
+			versions.EXPECT().Get(gomock.Any(), "openshift-v4.14.2").Return(version, nil)
+		}
+
+		// buildUpgrade returns a control plane upgrade policy of the cluster and the same policy
+		// with the given state, as returned when it is read one by one.
+		buildUpgrade := func(id string, version string,
+			value cmv1.UpgradePolicyStateValue) (*cmv1.ControlPlaneUpgradePolicy, *cmv1.ControlPlaneUpgradePolicy) {
+			builder := cmv1.NewControlPlaneUpgradePolicy().ID(id).ClusterID(clusterId).
+				UpgradeType(cmv1.UpgradeTypeControlPlane).Version(version).
+				NextRun(time.Now().UTC().Add(time.Hour))
+			policy, err := builder.Build()
+			Expect(err).ToNot(HaveOccurred())
+			withState, err := builder.State(cmv1.NewUpgradePolicyState().Value(value)).Build()
+			Expect(err).ToNot(HaveOccurred())
+			return policy, withState
+		}
+
+		It("Schedules an upgrade to an available version", func() {
+			expectAvailableUpgrade()
+			policies.EXPECT().List(gomock.Any(), clusterId).Return(nil, nil)
+			policies.EXPECT().MissingGateAgreements(gomock.Any(), clusterId, gomock.Any()).
+				Return([]*cmv1.VersionGate{}, nil)
+			policies.EXPECT().Create(gomock.Any(), clusterId, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, clusterId string,
+					policy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error) {
+					Expect(policy.Version()).To(Equal("4.14.2"))
+					Expect(policy.ScheduleType()).To(Equal(cmv1.ScheduleTypeManual))
+					return policy, nil
+				})
+
+			Expect(r.upgradeClusterIfNeeded(ctx, state, plan)).To(Succeed())
+			Expect(state.Version.ValueString()).To(Equal("4.14.2"))
+		})
+
+		It("Rejects a version that isn't an available upgrade", func() {
+			expectAvailableUpgrade()
+			plan.Version = types.StringValue("4.14.3")
+
+			err := r.upgradeClusterIfNeeded(ctx, state, plan)
+			Expect(err).To(MatchError(ContainSubstring("is not in the list of available upgrades")))
+		})
+
+		It("Requires the acknowledgement of the version gates", func() {
+			expectAvailableUpgrade()
+			policies.EXPECT().List(gomock.Any(), clusterId).Return(nil, nil)
+			gate, err := cmv1.NewVersionGate().ID("gate").Description("API removals").Build()
+			Expect(err).ToNot(HaveOccurred())
+			policies.EXPECT().MissingGateAgreements(gomock.Any(), clusterId, gomock.Any()).
+				Return([]*cmv1.VersionGate{gate}, nil)
+
+			err = r.upgradeClusterIfNeeded(ctx, state, plan)
+			Expect(err).To(MatchError(ContainSubstring(`upgrade_acknowledgements_for = 4.14`)))
+		})
+
+		It("Cancels the pending upgrades to other versions", func() {
+			plan.Version = types.StringValue("4.14.1")
+			policy, withState := buildUpgrade("other", "4.14.3", cmv1.UpgradePolicyStateValueScheduled)
+			policies.EXPECT().List(gomock.Any(), clusterId).Return([]*cmv1.ControlPlaneUpgradePolicy{policy}, nil)
+			policies.EXPECT().Get(gomock.Any(), clusterId, "other").Return(withState, nil)
+			policies.EXPECT().Delete(gomock.Any(), clusterId, "other").Return(nil)
+
+			Expect(r.upgradeClusterIfNeeded(ctx, state, plan)).To(Succeed())
+		})
+
+		It("Fails when an upgrade to another version has started", func() {
+			expectAvailableUpgrade()
+			policy, withState := buildUpgrade("other", "4.14.3", cmv1.UpgradePolicyStateValueStarted)
+			policies.EXPECT().List(gomock.Any(), clusterId).Return([]*cmv1.ControlPlaneUpgradePolicy{policy}, nil)
+			policies.EXPECT().Get(gomock.Any(), clusterId, "other").Return(withState, nil)
+
+			err := r.upgradeClusterIfNeeded(ctx, state, plan)
+			Expect(err).To(MatchError(ContainSubstring("a cluster upgrade is already in progress")))
+		})
+	})
 })
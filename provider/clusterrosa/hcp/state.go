@@ -1,6 +1,7 @@
 package hcp
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	sharedvpc "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp/shared_vpc"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/sts"
@@ -9,6 +10,13 @@ import (
 )
 
 type ClusterRosaHcpState struct {
+	clusterRosaHcpAttributes
+
+	TagsAll types.Map `tfsdk:"tags_all"`
+}
+
+// clusterRosaHcpAttributes are the attributes shared by the hosted control plane cluster resource and data source.
+type clusterRosaHcpAttributes struct {
 	ID             types.String `tfsdk:"id"`
 	Name           types.String `tfsdk:"name"`
 	DomainPrefix   types.String `tfsdk:"domain_prefix"`
@@ -34,6 +42,9 @@ type ClusterRosaHcpState struct {
 	AWSAdditionalComputeSecurityGroupIds types.List   `tfsdk:"aws_additional_compute_security_group_ids"`
 	AWSAdditionalAllowedPrincipals       types.List   `tfsdk:"aws_additional_allowed_principals"`
 
+	// Authentication fields
+	ExternalAuthProvidersEnabled types.Bool `tfsdk:"external_auth_providers_enabled"`
+
 	// Network fields
 	Domain      types.String `tfsdk:"domain"`
 	PodCIDR     types.String `tfsdk:"pod_cidr"`
@@ -62,6 +73,8 @@ type ClusterRosaHcpState struct {
 	MaxHCPClusterWaitTimeoutInMinutes  types.Int64 `tfsdk:"max_hcp_cluster_wait_timeout_in_minutes"`
 	MaxMachinePoolWaitTimeoutInMinutes types.Int64 `tfsdk:"max_machinepool_wait_timeout_in_minutes"`
 
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
 	// Admin user fields
 	CreateAdminUser  types.Bool   `tfsdk:"create_admin_user"`
 	AdminCredentials types.Object `tfsdk:"admin_credentials"`
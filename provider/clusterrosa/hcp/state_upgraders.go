@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hcp
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// stateUpgraders is the chain of upgraders of the state, see common.StateUpgraders.
+var stateUpgraders = []common.StateUpgrader{
+	upgradeStateV0,
+}
+
+var _ resource.ResourceWithUpgradeState = &ClusterRosaHcpResource{}
+
+func (r *ClusterRosaHcpResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return common.StateUpgraders(stateUpgraders...)
+}
+
+// upgradeStateV0 copies the tags to the merged tags, because the clusters of the legacy states
+// were created with exactly the tags of the resource.
+func upgradeStateV0(ctx context.Context, state map[string]interface{}) error {
+	common.CopyStateAttributeIfNull(state, "tags", "tags_all")
+	return nil
+}
@@ -17,7 +17,6 @@ package upgrade
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,7 +25,6 @@ import (
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
-	"github.com/zgalor/weberr"
 )
 
 type ControlPlaneUpgrade struct {
@@ -36,26 +34,22 @@ type ControlPlaneUpgrade struct {
 
 // Get the available upgrade versions that are reachable from a given starting
 // version
-func GetAvailableUpgradeVersions(ctx context.Context, clustersClient *cmv1.ClustersClient, versionClient *cmv1.VersionsClient, clusterId string) ([]*cmv1.Version, error) {
+func GetAvailableUpgradeVersions(ctx context.Context, clusterClient common.ClusterClient, versionClient common.VersionsClient, clusterId string) ([]*cmv1.Version, error) {
 	// Retrieve info about the current version
-	resp, err := clustersClient.Cluster(clusterId).Get().SendContext(ctx)
+	cluster, err := clusterClient.FetchCluster(ctx, clusterId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get version information: %v", err)
 	}
-	cluster := resp.Body()
 	version := cluster.Version()
 
 	// Cycle through the available upgrades and find the ones that are HCP enabled
 	availableUpgradeVersions := []*cmv1.Version{}
 	for _, v := range version.AvailableUpgrades() {
 		id := ocmUtils.CreateVersionId(v, version.ChannelGroup())
-		resp, err := versionClient.Version(id).
-			Get().
-			Send()
+		availableVersion, err := versionClient.Get(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get version information: %v", err)
 		}
-		availableVersion := resp.Body()
 		if availableVersion.HostedControlPlaneEnabled() {
 			availableUpgradeVersions = append(availableUpgradeVersions, availableVersion)
 		}
@@ -65,27 +59,13 @@ func GetAvailableUpgradeVersions(ctx context.Context, clustersClient *cmv1.Clust
 }
 
 // Get the list of upgrade policies associated with a cluster
-func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clusterId string) ([]ControlPlaneUpgrade, error) {
+func GetScheduledUpgrades(ctx context.Context, client common.ControlPlaneUpgradePoliciesClient, clusterId string) ([]ControlPlaneUpgrade, error) {
 	upgrades := []ControlPlaneUpgrade{}
 
 	// Get the upgrade policies for the cluster
-	upgradePolicies := []*cmv1.ControlPlaneUpgradePolicy{}
-	upgradeClient := client.Cluster(clusterId).ControlPlane().UpgradePolicies()
-	page := 1
-	size := 100
-	for {
-		resp, err := upgradeClient.List().
-			Page(page).
-			Size(size).
-			SendContext(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list control plane upgrade policies: %v", err)
-		}
-		upgradePolicies = append(upgradePolicies, resp.Items().Slice()...)
-		if resp.Size() < size {
-			break
-		}
-		page++
+	upgradePolicies, err := client.List(ctx, clusterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list control plane upgrade policies: %v", err)
 	}
 
 	// For each upgrade policy, get its state
@@ -93,16 +73,13 @@ func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clus
 		if policy.UpgradeType() != cmv1.UpgradeTypeControlPlane {
 			continue
 		}
-		resp, err := upgradeClient.ControlPlaneUpgradePolicy(policy.ID()).
-			Get().
-			SendContext(ctx)
+		current, err := client.Get(ctx, clusterId, policy.ID())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get upgrade policy state: %v", err)
 		}
-		state := resp.Body().State()
 		upgrades = append(upgrades, ControlPlaneUpgrade{
 			Policy:      policy,
-			PolicyState: state,
+			PolicyState: current.State(),
 		})
 	}
 
@@ -113,7 +90,7 @@ func GetScheduledUpgrades(ctx context.Context, client *cmv1.ClustersClient, clus
 // for the correct version, and returning an error if there is already an
 // upgrade in progress that is not for the desired version
 func CheckAndCancelUpgrades(
-	ctx context.Context, client *cmv1.ClustersClient, upgrades []ControlPlaneUpgrade, desiredVersion *semver.Version) (bool, error) {
+	ctx context.Context, client common.ControlPlaneUpgradePoliciesClient, upgrades []ControlPlaneUpgrade, desiredVersion *semver.Version) (bool, error) {
 	correctUpgradePending := false
 	tenMinFromNow := time.Now().UTC().Add(10 * time.Minute)
 
@@ -134,10 +111,7 @@ func CheckAndCancelUpgrades(
 				correctUpgradePending = true
 			} else {
 				// The upgrade is not one we want, so cancel it
-				_, err := client.Cluster(upgrade.Policy.ClusterID()).
-					ControlPlane().UpgradePolicies().
-					ControlPlaneUpgradePolicy(upgrade.Policy.ID()).
-					Delete().SendContext(ctx)
+				err := client.Delete(ctx, upgrade.Policy.ClusterID(), upgrade.Policy.ID())
 				if err != nil {
 					return false, fmt.Errorf("failed to delete upgrade policy: %v", err)
 				}
@@ -147,26 +121,10 @@ func CheckAndCancelUpgrades(
 	return correctUpgradePending, nil
 }
 
-func AckVersionGate(
-	gateAgreementsClient *cmv1.VersionGateAgreementsClient,
-	gateID string) error {
-	agreement, err := cmv1.NewVersionGateAgreement().
-		VersionGate(cmv1.NewVersionGate().ID(gateID)).
-		Build()
-	if err != nil {
-		return err
-	}
-	response, err := gateAgreementsClient.Add().Body(agreement).Send()
-	if err != nil {
-		return common.HandleErr(response.Error(), err)
-	}
-	return nil
-}
-
 // Construct a list of missing gate agreements for upgrade to a given cluster version
 // Returns: a list of all un-acked gate agreements, a string describing the ones that need user ack, and an error
-func CheckMissingAgreements(version string,
-	clusterKey string, upgradePoliciesClient *cmv1.ControlPlaneUpgradePoliciesClient) ([]*cmv1.VersionGate, string, error) {
+func CheckMissingAgreements(ctx context.Context, version string,
+	clusterKey string, client common.ControlPlaneUpgradePoliciesClient) ([]*cmv1.VersionGate, string, error) {
 	// Schedule an upgrade
 	tenMinFromNow := time.Now().UTC().Add(10 * time.Minute)
 	upgradePolicyBuilder := cmv1.NewControlPlaneUpgradePolicy().
@@ -179,7 +137,7 @@ func CheckMissingAgreements(version string,
 	}
 
 	// check if the cluster upgrade requires gate agreements
-	gates, err := getMissingGateAgreements(upgradePolicy, upgradePoliciesClient)
+	gates, err := client.MissingGateAgreements(ctx, clusterKey, upgradePolicy)
 	if err != nil {
 		return []*cmv1.VersionGate{}, "", fmt.Errorf("failed to check for missing gate agreements upgrade for "+
 			"cluster '%s': %v", clusterKey, err)
@@ -201,31 +159,3 @@ func CheckMissingAgreements(version string,
 	}
 	return gates, str, nil
 }
-
-func getMissingGateAgreements(
-	upgradePolicy *cmv1.ControlPlaneUpgradePolicy,
-	upgradePoliciesClient *cmv1.ControlPlaneUpgradePoliciesClient) ([]*cmv1.VersionGate, error) {
-	response, err := upgradePoliciesClient.Add().Parameter("dryRun", true).Body(upgradePolicy).Send()
-	if err != nil && response.Error() != nil {
-		// parse gates list
-		errorDetails, ok := response.Error().GetDetails()
-		if !ok {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		data, err := json.Marshal(errorDetails)
-		if err != nil {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		gates, err := cmv1.UnmarshalVersionGateList(data)
-		if err != nil {
-			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
-		}
-		// return original error if invaild version gate detected
-		if len(gates) > 0 && gates[0].ID() == "" {
-			errType := weberr.ErrorType(response.Error().Status())
-			return []*cmv1.VersionGate{}, errType.Set(weberr.Errorf(response.Error().Reason()))
-		}
-		return gates, nil
-	}
-	return []*cmv1.VersionGate{}, nil
-}
@@ -4,31 +4,38 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type ClusterWaiterResource struct {
-	collection  *cmv1.ClustersClient
-	clusterWait common.ClusterWait
+	collection                  *cmv1.ClustersClient
+	clusterWait                 common.ClusterWait
+	machinePools                common.MachinePoolsClient
+	nodePools                   common.NodePoolsClient
+	upgradePolicies             common.UpgradePoliciesClient
+	controlPlaneUpgradePolicies common.ControlPlaneUpgradePoliciesClient
+	httpClient                  common.HttpClient
+	urlTimeout                  time.Duration
 }
 
 var _ resource.ResourceWithConfigure = &ClusterWaiterResource{}
-
-const (
-	defaultTimeoutInMinutes = int64(60)
-)
+var _ resource.ResourceWithValidateConfig = &ClusterWaiterResource{}
 
 func New() resource.Resource {
-	return &ClusterWaiterResource{}
+	return &ClusterWaiterResource{
+		urlTimeout: urlCheckTimeout,
+	}
 }
 
 func (r *ClusterWaiterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -37,7 +44,7 @@ func (r *ClusterWaiterResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *ClusterWaiterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Wait Cluster Resource To be Ready",
+		Description: "Wait Cluster Resource To be Ready, or to meet other conditions",
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
 				Description: "Identifier of the cluster.",
@@ -46,20 +53,46 @@ func (r *ClusterWaiterResource) Schema(ctx context.Context, req resource.SchemaR
 					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "cluster ID may not be empty/blank string"),
 				},
 			},
+			"conditions": schema.SetAttribute{
+				Description: "Conditions that the cluster has to meet, checked one after the other. " +
+					"Options are `ready`, the state of the cluster is ready, `compute_nodes_ready`, " +
+					"all the compute nodes of the cluster are running, `machine_pool_ready`, the " +
+					"machine or node pool given in `machine_pool` reached its desired replicas, " +
+					"`urls_reachable`, the API and console URLs answer, `no_upgrade_in_progress`, no " +
+					"upgrade of the cluster has started, and `deleted`, the cluster doesn't exist " +
+					"anymore, which can't be combined with the other conditions. The default is `ready`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(conditionNames...)),
+				},
+			},
+			"machine_pool": schema.StringAttribute{
+				Description: "Identifier of the machine pool, or of the node pool for hosted control " +
+					"plane clusters, checked by the `machine_pool_ready` condition. For classic " +
+					"clusters OCM doesn't report the running replicas of each pool, so the condition " +
+					"compares the running compute nodes with the desired replicas of all the pools.",
+				Optional: true,
+			},
 			"timeout": schema.Int64Attribute{
 				Description: "An optional timeout until the cluster is ready. The timeout value is set in minutes." +
 					" The default value is 60 minutes.",
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use the `create` and `update` timeouts of the `timeouts` block instead.",
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1), // Timeout must be positive
 				},
 			},
 			"ready": schema.BoolAttribute{
-				Description: "Whether the cluster is ready." +
+				Description: "Whether the cluster meets all the conditions." +
 					"Note: this does not account for cluster operators still progressing to completion.",
 				Computed: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.CreateUpdateTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -69,17 +102,55 @@ func (r *ClusterWaiterResource) Configure(ctx context.Context, req resource.Conf
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
-	r.clusterWait = common.NewClusterWait(r.collection, connection)
+	r.clusterWait = common.NewClusterWait(r.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.machinePools = common.NewMachinePoolsClient(r.collection)
+	r.nodePools = common.NewNodePoolsClient(r.collection)
+	r.upgradePolicies = common.NewUpgradePoliciesClient(r.collection)
+	r.controlPlaneUpgradePolicies = common.NewControlPlaneUpgradePoliciesClient(r.collection)
+	r.httpClient = providerData.HttpClient
+}
+
+func (r *ClusterWaiterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	config := &ClusterWaiterState{}
+	diags := req.Config.Get(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Conditions.IsUnknown() {
+		return
+	}
+	for _, element := range config.Conditions.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+	conditions, err := common.StringSetToArray(ctx, config.Conditions)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("conditions"), "Invalid conditions", err.Error())
+		return
+	}
+	selected := map[string]bool{}
+	for _, condition := range conditions {
+		selected[condition] = true
+	}
+	if selected[ConditionDeleted] && len(selected) > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("conditions"), "Invalid conditions",
+			fmt.Sprintf("The '%s' condition can't be combined with other conditions", ConditionDeleted))
+	}
+	if selected[ConditionMachinePoolReady] && config.MachinePool.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("machine_pool"), "Missing machine pool",
+			fmt.Sprintf("The '%s' condition requires the 'machine_pool' attribute", ConditionMachinePoolReady))
+	}
 }
 
 func (r *ClusterWaiterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -91,12 +162,23 @@ func (r *ClusterWaiterResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	state, err := r.startPolling(ctx, state)
+	timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, state.Timeouts.Create, state.Timeout,
+		common.DefaultTimeout, &resp.Diagnostics)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, "Invalid timeout", err.Error(), err)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, err = r.startPolling(ctx, state, timeout)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Waiting for cluster creation finished with error",
 			fmt.Sprintf("Waiting for cluster creation finished with the error %v", err),
+			err,
 		)
 		if state == nil {
 			return
@@ -120,10 +202,19 @@ func (r *ClusterWaiterResource) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state, err := r.startPolling(ctx, plan)
+	timeout, err := common.TimeoutWithDeprecatedMinutes(ctx, plan.Timeouts.Update, plan.Timeout,
+		common.DefaultTimeout, &resp.Diagnostics)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, "Invalid timeout", err.Error(), err)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, err := r.startPolling(ctx, plan, timeout)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Can't poll cluster state (update resource)", err.Error())
+		common.AddError(&resp.Diagnostics, "Can't poll cluster state (update resource)", err.Error(), err)
 		return
 	}
 
@@ -136,23 +227,36 @@ func (r *ClusterWaiterResource) Delete(ctx context.Context, req resource.DeleteR
 	resp.State.RemoveResource(ctx)
 }
 
-func (r *ClusterWaiterResource) startPolling(ctx context.Context, state *ClusterWaiterState) (*ClusterWaiterState, error) {
+func (r *ClusterWaiterResource) startPolling(ctx context.Context, state *ClusterWaiterState,
+	timeout time.Duration) (*ClusterWaiterState, error) {
 	state.Ready = types.BoolValue(false)
-
-	timeout := defaultTimeoutInMinutes
-	if !state.Timeout.IsUnknown() && !state.Timeout.IsNull() {
-		timeout = state.Timeout.ValueInt64()
+	conditions, err := common.StringSetToArray(ctx, state.Conditions)
+	if err != nil {
+		return state, err
+	}
+	selected := map[string]bool{}
+	for _, condition := range conditions {
+		selected[condition] = true
+	}
+	if len(selected) == 0 {
+		selected[ConditionReady] = true
 	}
 
-	// Wait till the cluster is ready:
-	object, err := r.clusterWait.WaitForClusterToBeReady(ctx, state.Cluster.ValueString(), timeout)
-	if err != nil {
-		return state, fmt.Errorf(
-			"Can't poll state of cluster with identifier '%s': %v",
-			state.Cluster.ValueString(), err,
-		)
+	// Wait till the cluster meets the conditions, which share the timeout:
+	deadline := time.Now().Add(timeout)
+	for _, condition := range conditionNames {
+		if !selected[condition] {
+			continue
+		}
+		err = r.waitForCondition(ctx, state, condition, time.Until(deadline))
+		if err != nil {
+			return state, fmt.Errorf(
+				"Can't poll state of cluster with identifier '%s': %w",
+				state.Cluster.ValueString(), err,
+			)
+		}
 	}
 
-	state.Ready = types.BoolValue(object.State() == cmv1.ClusterStateReady)
+	state.Ready = types.BoolValue(true)
 	return state, nil
 }
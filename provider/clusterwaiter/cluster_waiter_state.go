@@ -1,11 +1,15 @@
 package clusterwaiter
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type ClusterWaiterState struct {
-	Cluster types.String `tfsdk:"cluster"`
-	Ready   types.Bool   `tfsdk:"ready"`
-	Timeout types.Int64  `tfsdk:"timeout"`
+	Cluster     types.String   `tfsdk:"cluster"`
+	Conditions  types.Set      `tfsdk:"conditions"`
+	MachinePool types.String   `tfsdk:"machine_pool"`
+	Ready       types.Bool     `tfsdk:"ready"`
+	Timeout     types.Int64    `tfsdk:"timeout"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
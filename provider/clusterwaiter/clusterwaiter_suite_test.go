@@ -0,0 +1,13 @@
+package clusterwaiter
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterWaiter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster Waiter Suite")
+}
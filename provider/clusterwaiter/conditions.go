@@ -0,0 +1,205 @@
+package clusterwaiter
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	classicUpgrade "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/classic/upgrade"
+	hcpUpgrade "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp/upgrade"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// Names of the conditions that the waiter can wait for.
+const (
+	ConditionReady               = "ready"
+	ConditionComputeNodesReady   = "compute_nodes_ready"
+	ConditionMachinePoolReady    = "machine_pool_ready"
+	ConditionURLsReachable       = "urls_reachable"
+	ConditionNoUpgradeInProgress = "no_upgrade_in_progress"
+	ConditionDeleted             = "deleted"
+)
+
+// conditionNames are the names of the conditions, in the order that they are checked.
+var conditionNames = []string{
+	ConditionReady,
+	ConditionComputeNodesReady,
+	ConditionMachinePoolReady,
+	ConditionURLsReachable,
+	ConditionNoUpgradeInProgress,
+	ConditionDeleted,
+}
+
+// waitForCondition waits until the cluster meets the condition with the given name.
+func (r *ClusterWaiterResource) waitForCondition(ctx context.Context, state *ClusterWaiterState,
+	name string, timeout time.Duration) error {
+	clusterId := state.Cluster.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Waiting for condition '%s' of cluster '%s'", name, clusterId))
+	var err error
+	switch name {
+	case ConditionReady:
+		_, err = r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, timeout)
+	case ConditionComputeNodesReady:
+		_, err = r.clusterWait.WaitForStdComputeNodesToBeReady(ctx, clusterId, timeout)
+	case ConditionMachinePoolReady:
+		_, err = r.clusterWait.WaitForCondition(ctx, clusterId, timeout,
+			r.machinePoolReady(state.MachinePool.ValueString()))
+	case ConditionURLsReachable:
+		_, err = r.clusterWait.WaitForCondition(ctx, clusterId, timeout, r.urlsReachable)
+	case ConditionNoUpgradeInProgress:
+		_, err = r.clusterWait.WaitForCondition(ctx, clusterId, timeout, r.noUpgradeInProgress)
+	case ConditionDeleted:
+		_, err = r.clusterWait.WaitForCondition(ctx, clusterId, timeout, clusterDeleted)
+	default:
+		err = fmt.Errorf("unknown condition '%s'", name)
+	}
+	if err != nil {
+		return fmt.Errorf("condition '%s' isn't met: %w", name, err)
+	}
+	return nil
+}
+
+// machinePoolReady checks that a pool reached its desired replicas, or the minimum replicas when
+// it is autoscaled. OCM only reports the current replicas of each pool for hosted control plane
+// clusters, so for classic clusters the current compute nodes of the cluster are compared to the
+// desired replicas of all its machine pools.
+func (r *ClusterWaiterResource) machinePoolReady(poolId string) common.ClusterCondition {
+	return func(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+		if cluster == nil {
+			return false, nil
+		}
+		if cluster.Hypershift().Enabled() {
+			exists, pool, err := r.nodePools.Exists(ctx, cluster.ID(), poolId)
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Can't get node pool '%s' of cluster '%s': %v", poolId,
+					cluster.ID(), err))
+				return false, nil
+			}
+			if !exists {
+				return false, fmt.Errorf("node pool '%s' doesn't exist in cluster '%s'", poolId, cluster.ID())
+			}
+			desired := pool.Replicas()
+			if autoscaling, ok := pool.GetAutoscaling(); ok {
+				desired = autoscaling.MinReplica()
+			}
+			current := pool.Status().CurrentReplicas()
+			tflog.Info(ctx, fmt.Sprintf("Node pool '%s' of cluster '%s' has %d/%d replicas", poolId,
+				cluster.ID(), current, desired))
+			return current >= desired, nil
+		}
+		pools, err := r.machinePools.List(ctx, cluster.ID())
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Can't list the machine pools of cluster '%s': %v", cluster.ID(), err))
+			return false, nil
+		}
+		found := false
+		desired := 0
+		for _, pool := range pools {
+			found = found || pool.ID() == poolId
+			if autoscaling, ok := pool.GetAutoscaling(); ok {
+				desired += autoscaling.MinReplicas()
+			} else {
+				desired += pool.Replicas()
+			}
+		}
+		if !found {
+			return false, fmt.Errorf("machine pool '%s' doesn't exist in cluster '%s'", poolId, cluster.ID())
+		}
+		current := cluster.Status().CurrentCompute()
+		tflog.Info(ctx, fmt.Sprintf("Cluster '%s' has %d/%d compute nodes for its machine pools",
+			cluster.ID(), current, desired))
+		return current >= desired, nil
+	}
+}
+
+// urlsReachable checks that the API and the console of the cluster answer.
+func (r *ClusterWaiterResource) urlsReachable(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+	if cluster == nil {
+		return false, nil
+	}
+	for _, url := range []string{cluster.API().URL(), cluster.Console().URL()} {
+		if url == "" {
+			tflog.Info(ctx, fmt.Sprintf("The URLs of cluster '%s' aren't assigned yet", cluster.ID()))
+			return false, nil
+		}
+		err := r.checkURL(ctx, url)
+		if err != nil {
+			tflog.Info(ctx, fmt.Sprintf("URL '%s' of cluster '%s' doesn't answer yet: %v", url, cluster.ID(), err))
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// urlCheckTimeout is the time that the check of an URL waits for its response, so that an URL that
+// accepts the connection but doesn't answer doesn't block the wait.
+const urlCheckTimeout = 10 * time.Second
+
+// checkURL sends a request to the URL. It answers if it returns any response that isn't a server
+// error. The API server of some clusters presents a certificate signed by the CA of the cluster,
+// which the provider doesn't trust, but that still means that it answers.
+func (r *ClusterWaiterResource) checkURL(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.urlTimeout)
+	defer cancel()
+	response, err := r.httpClient.Get(ctx, url)
+	if err != nil {
+		var unknownAuthority x509.UnknownAuthorityError
+		if errors.As(err, &unknownAuthority) {
+			return nil
+		}
+		return err
+	}
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+// noUpgradeInProgress checks that none of the upgrades of the cluster, or of its control plane
+// for hosted control plane clusters, has started.
+func (r *ClusterWaiterResource) noUpgradeInProgress(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+	if cluster == nil {
+		return false, nil
+	}
+	states := []cmv1.UpgradePolicyStateValue{}
+	if cluster.Hypershift().Enabled() {
+		upgrades, err := hcpUpgrade.GetScheduledUpgrades(ctx, r.controlPlaneUpgradePolicies, cluster.ID())
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Can't get the upgrades of cluster '%s': %v", cluster.ID(), err))
+			return false, nil
+		}
+		for _, upgrade := range upgrades {
+			states = append(states, upgrade.PolicyState.Value())
+		}
+	} else {
+		upgrades, err := classicUpgrade.GetScheduledUpgrades(ctx, r.upgradePolicies, cluster.ID())
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Can't get the upgrades of cluster '%s': %v", cluster.ID(), err))
+			return false, nil
+		}
+		for _, upgrade := range upgrades {
+			states = append(states, upgrade.State())
+		}
+	}
+	for _, state := range states {
+		switch state {
+		case cmv1.UpgradePolicyStateValueStarted, cmv1.UpgradePolicyStateValueDelayed:
+			tflog.Info(ctx, fmt.Sprintf("An upgrade of cluster '%s' is in state '%s'", cluster.ID(), state))
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// clusterDeleted checks that the cluster doesn't exist anymore.
+func clusterDeleted(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+	return cluster == nil, nil
+}
@@ -0,0 +1,244 @@
+package clusterwaiter
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/fake"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// urlAnswers is an HTTP client that returns for each URL the configured status or error, and
+// fails for the rest. The URLs whose answer is hangs don't answer until the request is cancelled.
+type urlAnswers map[string]interface{}
+
+type hangs struct{}
+
+func (a urlAnswers) Get(ctx context.Context, address string) (*http.Response, error) {
+	switch answer := a[address].(type) {
+	case hangs:
+		<-ctx.Done()
+		return nil, &url.Error{Op: "Get", URL: address, Err: ctx.Err()}
+	case int:
+		return &http.Response{StatusCode: answer, Body: io.NopCloser(strings.NewReader(""))}, nil
+	case error:
+		return nil, &url.Error{Op: "Get", URL: address, Err: answer}
+	default:
+		return nil, fmt.Errorf("no such host")
+	}
+}
+
+var _ = Describe("Conditions", func() {
+	const (
+		clusterPath = "/api/clusters_mgmt/v1/clusters/123"
+		apiURL      = "https://api.mycluster.example.com:6443"
+		consoleURL  = "https://console.mycluster.example.com"
+	)
+
+	var (
+		ctx        context.Context
+		server     *fake.Server
+		listener   *httptest.Server
+		connection *sdk.Connection
+		answers    urlAnswers
+		waiter     *ClusterWaiterResource
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = fake.NewServer().Build()
+		listener = httptest.NewServer(server)
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(listener.URL).
+			Tokens(fake.Token()).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		collection := connection.ClustersMgmt().V1().Clusters()
+		answers = urlAnswers{}
+		waiter = &ClusterWaiterResource{
+			collection:                  collection,
+			clusterWait:                 common.NewClusterWait(collection, time.Millisecond, nil),
+			machinePools:                common.NewMachinePoolsClient(collection),
+			nodePools:                   common.NewNodePoolsClient(collection),
+			upgradePolicies:             common.NewUpgradePoliciesClient(collection),
+			controlPlaneUpgradePolicies: common.NewControlPlaneUpgradePoliciesClient(collection),
+			httpClient:                  answers,
+			urlTimeout:                  10 * time.Millisecond,
+		}
+	})
+
+	AfterEach(func() {
+		connection.Close()
+		listener.Close()
+	})
+
+	newState := func(conditions ...string) *ClusterWaiterState {
+		values := []attr.Value{}
+		for _, condition := range conditions {
+			values = append(values, types.StringValue(condition))
+		}
+		return &ClusterWaiterState{
+			Cluster:     types.StringValue("123"),
+			Conditions:  types.SetValueMust(types.StringType, values),
+			MachinePool: types.StringValue("workers"),
+		}
+	}
+
+	putCluster := func(hosted bool) {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "ready",
+			"hypershift": map[string]interface{}{
+				"enabled": hosted,
+			},
+			"nodes": map[string]interface{}{
+				"compute": 2,
+			},
+			"status": map[string]interface{}{
+				"state":           "ready",
+				"current_compute": 2,
+			},
+			"api": map[string]interface{}{
+				"url": apiURL,
+			},
+			"console": map[string]interface{}{
+				"url": consoleURL,
+			},
+		})
+	}
+
+	It("Waits for the cluster to be ready by default", func() {
+		putCluster(false)
+		state := newState()
+		state.Conditions = types.SetNull(types.StringType)
+		state, err := waiter.startPolling(ctx, state, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Ready.ValueBool()).To(BeTrue())
+	})
+
+	It("Waits for the node pool to reach its replicas", func() {
+		putCluster(true)
+		server.Put(clusterPath+"/node_pools/workers", map[string]interface{}{
+			"replicas": 3,
+			"status": map[string]interface{}{
+				"current_replicas": 3,
+			},
+		})
+		state, err := waiter.startPolling(ctx, newState(ConditionReady, ConditionMachinePoolReady), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Ready.ValueBool()).To(BeTrue())
+	})
+
+	It("Times out while the node pool doesn't reach its replicas", func() {
+		putCluster(true)
+		server.Put(clusterPath+"/node_pools/workers", map[string]interface{}{
+			"autoscaling": map[string]interface{}{
+				"min_replica": 2,
+				"max_replica": 4,
+			},
+			"status": map[string]interface{}{
+				"current_replicas": 1,
+			},
+		})
+		state, err := waiter.startPolling(ctx, newState(ConditionMachinePoolReady), 50*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("condition 'machine_pool_ready' isn't met")))
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+		Expect(state.Ready.ValueBool()).To(BeFalse())
+	})
+
+	It("Compares the compute nodes with the replicas of all the pools of classic clusters", func() {
+		putCluster(false)
+		server.Put(clusterPath+"/machine_pools/worker", map[string]interface{}{
+			"replicas": 2,
+		})
+		server.Put(clusterPath+"/machine_pools/workers", map[string]interface{}{
+			"autoscaling": map[string]interface{}{
+				"min_replicas": 1,
+				"max_replicas": 3,
+			},
+		})
+		_, err := waiter.startPolling(ctx, newState(ConditionMachinePoolReady), 50*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+
+		server.Put(clusterPath+"/machine_pools/workers", map[string]interface{}{
+			"replicas": 0,
+		})
+		_, err = waiter.startPolling(ctx, newState(ConditionMachinePoolReady), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Fails when the pool doesn't exist", func() {
+		putCluster(false)
+		_, err := waiter.startPolling(ctx, newState(ConditionMachinePoolReady), time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("machine pool 'workers' doesn't exist")))
+	})
+
+	It("Waits for the URLs to answer", func() {
+		putCluster(false)
+		answers[apiURL] = x509.UnknownAuthorityError{}
+		answers[consoleURL] = http.StatusServiceUnavailable
+		_, err := waiter.startPolling(ctx, newState(ConditionURLsReachable), 50*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+
+		answers[consoleURL] = http.StatusOK
+		_, err = waiter.startPolling(ctx, newState(ConditionURLsReachable), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Doesn't block on URLs that don't answer", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("123").
+			API(cmv1.NewClusterAPI().URL(apiURL)).
+			Console(cmv1.NewClusterConsole().URL(consoleURL)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		answers[apiURL] = hangs{}
+		answers[consoleURL] = http.StatusOK
+		reachable, err := waiter.urlsReachable(ctx, cluster)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reachable).To(BeFalse())
+	})
+
+	It("Waits while an upgrade is in progress", func() {
+		putCluster(false)
+		server.Put(clusterPath+"/upgrade_policies/1", map[string]interface{}{
+			"upgrade_type": "OSD",
+			"version":      "4.15.3",
+		})
+		server.Put(clusterPath+"/upgrade_policies/1/state", map[string]interface{}{
+			"value": "started",
+		})
+		_, err := waiter.startPolling(ctx, newState(ConditionNoUpgradeInProgress), 50*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+
+		server.Put(clusterPath+"/upgrade_policies/1/state", map[string]interface{}{
+			"value": "scheduled",
+		})
+		_, err = waiter.startPolling(ctx, newState(ConditionNoUpgradeInProgress), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Waits for the cluster to be deleted", func() {
+		state, err := waiter.startPolling(ctx, newState(ConditionDeleted), time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(state.Ready.ValueBool()).To(BeTrue())
+	})
+
+	It("Fails when the cluster doesn't exist for the other conditions", func() {
+		_, err := waiter.startPolling(ctx, newState(ConditionReady), time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("Failed to get Cluster '123'")))
+	})
+})
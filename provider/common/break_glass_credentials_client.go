@@ -0,0 +1,86 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// BreakGlassCredentialsClient manages the break glass credentials of the hosted control plane
+// clusters that use external authentication providers. OCM doesn't revoke the credentials one by
+// one, all the credentials of a cluster are revoked at once.
+//
+//go:generate mockgen -source=break_glass_credentials_client.go -package=common -destination=mock_breakglasscredentialsclient.go
+type BreakGlassCredentialsClient interface {
+	Get(ctx context.Context, clusterId string, credentialId string) (*cmv1.BreakGlassCredential, error)
+	Exists(ctx context.Context, clusterId string, credentialId string) (bool, *cmv1.BreakGlassCredential, error)
+	Create(ctx context.Context, clusterId string,
+		credential *cmv1.BreakGlassCredential) (*cmv1.BreakGlassCredential, error)
+	List(ctx context.Context, clusterId string) ([]*cmv1.BreakGlassCredential, error)
+	RevokeAll(ctx context.Context, clusterId string) error
+}
+
+type DefaultBreakGlassCredentialsClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewBreakGlassCredentialsClient(client *cmv1.ClustersClient) BreakGlassCredentialsClient {
+	return &DefaultBreakGlassCredentialsClient{client: client}
+}
+
+func (c *DefaultBreakGlassCredentialsClient) collection(clusterId string) *cmv1.BreakGlassCredentialsClient {
+	return c.client.Cluster(clusterId).BreakGlassCredentials()
+}
+
+func (c *DefaultBreakGlassCredentialsClient) Get(ctx context.Context, clusterId string,
+	credentialId string) (*cmv1.BreakGlassCredential, error) {
+	resp, err := c.collection(clusterId).BreakGlassCredential(credentialId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultBreakGlassCredentialsClient) Exists(ctx context.Context, clusterId string,
+	credentialId string) (bool, *cmv1.BreakGlassCredential, error) {
+	resp, err := c.collection(clusterId).BreakGlassCredential(credentialId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultBreakGlassCredentialsClient) Create(ctx context.Context, clusterId string,
+	credential *cmv1.BreakGlassCredential) (*cmv1.BreakGlassCredential, error) {
+	resp, err := c.collection(clusterId).Add().Body(credential).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultBreakGlassCredentialsClient) List(ctx context.Context,
+	clusterId string) ([]*cmv1.BreakGlassCredential, error) {
+	result := []*cmv1.BreakGlassCredential{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultBreakGlassCredentialsClient) RevokeAll(ctx context.Context, clusterId string) error {
+	_, err := c.collection(clusterId).Delete().SendContext(ctx)
+	return err
+}
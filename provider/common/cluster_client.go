@@ -3,13 +3,19 @@ package common
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+// ListPageSize is the number of items requested in each page by the clients that list all the
+// items of a collection.
+const ListPageSize = 100
+
 //go:generate mockgen -source=cluster_client.go -package=common -destination=mock_clusterclient.go
 type ClusterClient interface {
 	FetchCluster(ctx context.Context, clusterId string) (*cmv1.Cluster, error)
+	Exists(ctx context.Context, clusterId string) (bool, *cmv1.Cluster, error)
 }
 
 type DefaultClusterClient struct {
@@ -29,3 +35,14 @@ func (c *DefaultClusterClient) FetchCluster(ctx context.Context, clusterId strin
 		return clusterResp.Body(), nil
 	}
 }
+
+func (c *DefaultClusterClient) Exists(ctx context.Context, clusterId string) (bool, *cmv1.Cluster, error) {
+	resp, err := c.client.Cluster(clusterId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
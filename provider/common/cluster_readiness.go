@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ClusterReadiness tracks the clusters that are known to be ready, and the waits in progress for
+// the others, so that the resources of the same cluster don't poll it each on their own. It is
+// shared by all the resources of the provider, and lives as long as the provider, which is one
+// Terraform command. Only the fact that a cluster is ready is remembered, the cluster itself is
+// fetched again for each caller, as it may have changed since.
+type ClusterReadiness struct {
+	lock  sync.Mutex
+	ready map[string]bool
+	waits map[string]*readinessWait
+}
+
+// readinessWait is a wait for a cluster to be ready. The done channel is closed when it finishes,
+// and then the cluster and the error contain its result. It is interrupted when it stopped because
+// of the timeout or the cancellation of the caller that polled, rather than because of the cluster.
+type readinessWait struct {
+	done        chan struct{}
+	cluster     *cmv1.Cluster
+	err         error
+	interrupted bool
+}
+
+// readinessPoll waits for a cluster to be ready, sending the requests to the server.
+type readinessPoll func(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error)
+
+// readinessFetch gets a cluster that is known to be ready, sending one request to the server.
+type readinessFetch func(ctx context.Context, clusterId string) (*cmv1.Cluster, error)
+
+// NewClusterReadiness creates a tracker that doesn't know about any cluster yet.
+func NewClusterReadiness() *ClusterReadiness {
+	return &ClusterReadiness{
+		ready: map[string]bool{},
+		waits: map[string]*readinessWait{},
+	}
+}
+
+// Wait fetches the cluster at once if it is known to be ready. Otherwise the first caller polls it,
+// and the callers that come while it does wait for its result. When the wait of the first caller
+// stops because of its own timeout or cancellation, another caller polls the cluster with the time
+// that it has left. A cluster that will not become ready isn't remembered, so it is checked again
+// by the next caller.
+func (t *ClusterReadiness) Wait(ctx context.Context, clusterId string, timeout time.Duration,
+	poll readinessPoll, fetch readinessFetch) (*cmv1.Cluster, error) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	for {
+		t.lock.Lock()
+		if t.ready[clusterId] {
+			t.lock.Unlock()
+			tflog.Debug(ctx, fmt.Sprintf("Cluster '%s' is already known to be ready", clusterId))
+			return fetch(ctx, clusterId)
+		}
+		wait, inProgress := t.waits[clusterId]
+		if !inProgress {
+			wait = &readinessWait{
+				done: make(chan struct{}),
+			}
+			t.waits[clusterId] = wait
+			t.lock.Unlock()
+			wait.cluster, wait.err = poll(ctx, clusterId, time.Until(deadline))
+			wait.interrupted = wait.err != nil && (ctx.Err() != nil || !time.Now().Before(deadline))
+			t.lock.Lock()
+			delete(t.waits, clusterId)
+			if wait.err == nil {
+				t.ready[clusterId] = true
+			}
+			close(wait.done)
+			t.lock.Unlock()
+			return wait.cluster, wait.err
+		}
+		t.lock.Unlock()
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for the result of the wait in progress for cluster '%s'", clusterId))
+		timer := time.NewTimer(time.Until(deadline))
+		select {
+		case <-wait.done:
+			timer.Stop()
+			if !wait.interrupted {
+				return wait.cluster, wait.err
+			}
+			if ctx.Err() != nil || !time.Now().Before(deadline) {
+				return wait.cluster, waitError(ctx, clusterId, wait.cluster, time.Since(start))
+			}
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, waitError(ctx, clusterId, nil, time.Since(start))
+		case <-timer.C:
+			return nil, waitError(ctx, clusterId, nil, time.Since(start))
+		}
+	}
+}
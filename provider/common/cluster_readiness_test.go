@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Cluster readiness", func() {
+	var (
+		ctx       context.Context
+		readiness *ClusterReadiness
+		polls     atomic.Int32
+		fetches   atomic.Int32
+		release   chan struct{}
+		cluster   *cmv1.Cluster
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		readiness = NewClusterReadiness()
+		polls.Store(0)
+		fetches.Store(0)
+		release = make(chan struct{})
+		var err error
+		cluster, err = cmv1.NewCluster().ID("123").State(cmv1.ClusterStateReady).Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	// poll waits until the test releases it and then reports the cluster as ready, unless the
+	// context of the caller is done before.
+	poll := func(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error) {
+		polls.Add(1)
+		select {
+		case <-release:
+			return cluster, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// fetch returns a new copy of the cluster each time.
+	fetch := func(ctx context.Context, clusterId string) (*cmv1.Cluster, error) {
+		fetches.Add(1)
+		return cmv1.NewCluster().Copy(cluster).Build()
+	}
+
+	It("Polls once for the callers that wait at the same time", func() {
+		var group sync.WaitGroup
+		results := make([]*cmv1.Cluster, 5)
+		for i := range results {
+			group.Add(1)
+			go func(i int) {
+				defer group.Done()
+				defer GinkgoRecover()
+				result, err := readiness.Wait(ctx, "123", time.Minute, poll, fetch)
+				Expect(err).ToNot(HaveOccurred())
+				results[i] = result
+			}(i)
+		}
+		Eventually(polls.Load).Should(BeEquivalentTo(1))
+		close(release)
+		group.Wait()
+		Expect(polls.Load()).To(BeEquivalentTo(1))
+		for _, result := range results {
+			Expect(result).To(BeIdenticalTo(cluster))
+		}
+	})
+
+	It("Fetches again instead of polling a cluster that is known to be ready", func() {
+		close(release)
+		_, err := readiness.Wait(ctx, "123", time.Minute, poll, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		result, err := readiness.Wait(ctx, "123", time.Minute, poll, fetch)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).ToNot(BeIdenticalTo(cluster))
+		Expect(result.ID()).To(Equal(cluster.ID()))
+		Expect(polls.Load()).To(BeEquivalentTo(1))
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("Polls again a cluster that wasn't ready", func() {
+		failing := func(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error) {
+			polls.Add(1)
+			return nil, &ClusterStateError{ClusterID: clusterId, State: cmv1.ClusterStateError}
+		}
+		_, err := readiness.Wait(ctx, "123", time.Minute, failing, fetch)
+		Expect(err).To(HaveOccurred())
+		_, err = readiness.Wait(ctx, "123", time.Minute, failing, fetch)
+		Expect(err).To(HaveOccurred())
+		Expect(polls.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("Polls again when the first caller is interrupted", func() {
+		firstCtx, cancel := context.WithCancel(ctx)
+		firstDone := make(chan error)
+		go func() {
+			_, err := readiness.Wait(firstCtx, "123", time.Minute, poll, fetch)
+			firstDone <- err
+		}()
+		Eventually(polls.Load).Should(BeEquivalentTo(1))
+		secondDone := make(chan error)
+		go func() {
+			_, err := readiness.Wait(ctx, "123", time.Minute, poll, fetch)
+			secondDone <- err
+		}()
+		cancel()
+		Expect(<-firstDone).To(MatchError(context.Canceled))
+		Eventually(polls.Load).Should(BeEquivalentTo(2))
+		close(release)
+		Expect(<-secondDone).ToNot(HaveOccurred())
+	})
+
+	It("Stops waiting for the result of another caller when the timeout expires", func() {
+		go func() {
+			_, _ = readiness.Wait(ctx, "123", time.Minute, poll, fetch)
+		}()
+		Eventually(polls.Load).Should(BeEquivalentTo(1))
+		_, err := readiness.Wait(ctx, "123", 10*time.Millisecond, poll, fetch)
+		Expect(err).To(MatchError(ContainSubstring("timed out after")))
+		close(release)
+	})
+})
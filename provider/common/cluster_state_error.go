@@ -0,0 +1,108 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// installLogTailLines is the number of lines from the end of the install log that are added to
+// the details of a failed installation.
+const installLogTailLines = 20
+
+// ClusterStateError is returned when a cluster reaches a state from which it will never become
+// ready. It contains what OCM knows about the failure, so that it can be reported in the
+// diagnostics instead of having to look for it in the console or with the rosa CLI.
+type ClusterStateError struct {
+	ClusterID             string
+	State                 cmv1.ClusterState
+	Description           string
+	ProvisionErrorCode    string
+	ProvisionErrorMessage string
+
+	// FailedInflightChecks contains the name and the details of each network verification that
+	// failed before the installation started.
+	FailedInflightChecks []string
+
+	// InstallLogTail contains the last lines of the install log.
+	InstallLogTail string
+}
+
+func (e *ClusterStateError) Error() string {
+	return fmt.Sprintf("Cluster '%s' is in state '%s' and will not become ready", e.ClusterID, e.State)
+}
+
+// Details returns the explanation of the failure, to be added to the detail of the diagnostics.
+// It is empty if OCM didn't give any.
+func (e *ClusterStateError) Details() string {
+	lines := []string{}
+	if e.Description != "" {
+		lines = append(lines, fmt.Sprintf("Status: %s", e.Description))
+	}
+	if e.ProvisionErrorCode != "" || e.ProvisionErrorMessage != "" {
+		lines = append(lines, fmt.Sprintf("Provision error: %s",
+			strings.TrimSpace(e.ProvisionErrorCode+" "+e.ProvisionErrorMessage)))
+	}
+	if len(e.FailedInflightChecks) > 0 {
+		lines = append(lines, "Failed inflight checks:")
+		for _, check := range e.FailedInflightChecks {
+			lines = append(lines, "  - "+check)
+		}
+	}
+	if e.InstallLogTail != "" {
+		lines = append(lines, fmt.Sprintf("Install log (last %d lines):", installLogTailLines))
+		for _, line := range strings.Split(strings.TrimRight(e.InstallLogTail, "\n"), "\n") {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NewClusterStateError creates the error for a cluster that will not become ready. For clusters
+// whose installation failed it also fetches the failed inflight checks and the tail of the install
+// log. Failing to fetch them isn't an error, they are just left out.
+func NewClusterStateError(ctx context.Context, client *cmv1.ClusterClient, cluster *cmv1.Cluster) *ClusterStateError {
+	result := &ClusterStateError{
+		ClusterID:             cluster.ID(),
+		State:                 cluster.State(),
+		Description:           cluster.Status().Description(),
+		ProvisionErrorCode:    cluster.Status().ProvisionErrorCode(),
+		ProvisionErrorMessage: cluster.Status().ProvisionErrorMessage(),
+	}
+	if cluster.State() != cmv1.ClusterStateError {
+		return result
+	}
+	checks, err := client.InflightChecks().List().SendContext(ctx)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Can't get the inflight checks of cluster '%s': %v", cluster.ID(), err))
+	} else {
+		checks.Items().Each(func(check *cmv1.InflightCheck) bool {
+			if check.State() == cmv1.InflightCheckStateFailed {
+				result.FailedInflightChecks = append(result.FailedInflightChecks, describeInflightCheck(check))
+			}
+			return true
+		})
+	}
+	installLog, err := client.Logs().Install().Get().Tail(installLogTailLines).SendContext(ctx)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Can't get the install log of cluster '%s': %v", cluster.ID(), err))
+	} else {
+		result.InstallLogTail = installLog.Body().Content()
+	}
+	return result
+}
+
+func describeInflightCheck(check *cmv1.InflightCheck) string {
+	if check.Details() == nil {
+		return check.Name()
+	}
+	details, err := json.Marshal(check.Details())
+	if err != nil {
+		return check.Name()
+	}
+	return fmt.Sprintf("%s: %s", check.Name(), details)
+}
@@ -2,193 +2,239 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
-const pollingIntervalInMinutes = 2
+// DefaultClusterWaitInterval is the time between the requests sent to check the cluster while
+// waiting for it, when the provider doesn't configure another one.
+const DefaultClusterWaitInterval = 2 * time.Minute
+
+// clusterWaitAttempts is the number of consecutive failed requests after which the wait gives up.
+const clusterWaitAttempts = 3
+
+// CacheControlHeader and NoCache are added to the requests that need the current state of the
+// cluster, like the ones used to wait for it, so that they go to the server instead of using
+// the cache of the provider.
+const (
+	CacheControlHeader = "Cache-Control"
+	NoCache            = "no-cache"
+)
+
+// ClusterCondition checks if a cluster reached the condition that a wait expects. The cluster is
+// nil when it doesn't exist. Returning an error stops the wait.
+type ClusterCondition func(ctx context.Context, cluster *cmv1.Cluster) (bool, error)
 
 //go:generate mockgen -source=cluster_waiter.go -package=common -destination=mock_clusterwait.go
 type ClusterWait interface {
-	WaitForClusterToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*cmv1.Cluster, error)
-	WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*cmv1.Cluster, error)
+	WaitForClusterToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error)
+	WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error)
+	WaitForCondition(ctx context.Context, clusterId string, timeout time.Duration, condition ClusterCondition) (*cmv1.Cluster, error)
 }
 
 type DefaultClusterWait struct {
 	collection *cmv1.ClustersClient
-	connection *sdk.Connection
+	interval   time.Duration
+	readiness  *ClusterReadiness
 }
 
-func NewClusterWait(collection *cmv1.ClustersClient, connection *sdk.Connection) ClusterWait {
+// NewClusterWait creates a waiter that checks the cluster with the given interval, or with
+// DefaultClusterWaitInterval when it is zero. The waits for clusters to be ready are shared
+// through the given tracker, if any, with the other waiters that use it.
+func NewClusterWait(collection *cmv1.ClustersClient, interval time.Duration,
+	readiness *ClusterReadiness) ClusterWait {
+	if interval <= 0 {
+		interval = DefaultClusterWaitInterval
+	}
 	return &DefaultClusterWait{
 		collection: collection,
-		connection: connection,
+		interval:   interval,
+		readiness:  readiness,
 	}
 }
 
-func (dw *DefaultClusterWait) WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*cmv1.Cluster, error) {
-	resource := dw.collection.Cluster(clusterId)
-	resp, err := resource.Get().SendContext(ctx)
-	if err != nil && resp.Status() == http.StatusNotFound {
-		message := fmt.Sprintf("Failed to get Cluster '%s', with error: %v", clusterId, err)
-		tflog.Error(ctx, message)
-		return nil, fmt.Errorf(message)
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("WaitForStdComputeNodesToBeReady: Cluster '%s' is expected to initiate with %d worker replicas."+
-		" Waiting for the current amount of replicas to reach disired value - timeout %d minutes",
-		clusterId, resp.Body().Nodes().Compute(), waitTimeoutMin))
-
-	if resp.Body().Nodes().Compute() == resp.Body().Status().CurrentCompute() {
-		tflog.Info(ctx, fmt.Sprintf("WaitForStdComputeNodesToBeReady: Wait done for cluster '%s' with %d/%d", clusterId,
-			resp.Body().Nodes().Compute(), resp.Body().Status().CurrentCompute()))
-		return resp.Body(), nil
-	}
-
-	backoffAttempts := 3
-	backoffSleep := 30 * time.Second
-	var cluster *cmv1.Cluster
-	for cluster == nil {
-		tflog.Debug(ctx, fmt.Sprintf("Updating tokens for cluster %s", clusterId))
-		dw.connection.Tokens()
-		cluster, err = pollClusterCurrentCompute(clusterId, ctx, waitTimeoutMin, dw.collection)
-		if err != nil {
-			backoffAttempts--
-			if backoffAttempts == 0 {
-				return nil, fmt.Errorf("polling cluster state failed with error %v", err)
-			}
-			time.Sleep(backoffSleep)
-		}
+func (dw *DefaultClusterWait) WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error) {
+	tflog.Info(ctx, fmt.Sprintf("WaitForStdComputeNodesToBeReady: Waiting for the compute nodes of cluster '%s' "+
+		"to reach the desired amount - timeout %s", clusterId, timeout))
+	cluster, err := dw.waitFor(ctx, clusterId, timeout, func(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+		return cluster != nil && cluster.Status().CurrentCompute() == cluster.Nodes().Compute(), nil
+	})
+	if err != nil {
+		return cluster, err
 	}
 	tflog.Info(ctx, fmt.Sprintf("WaitForStdComputeNodesToBeReady: Wait done for cluster '%s' with %d/%d", clusterId,
-		cluster.Nodes().Compute(), cluster.Status().CurrentCompute()))
-	if cluster.Nodes().Compute() != cluster.Status().CurrentCompute() {
-		return cluster, fmt.Errorf("cluster did not reach the desired amount of compute nodes within the timeout")
-	}
+		cluster.Status().CurrentCompute(), cluster.Nodes().Compute()))
 	return cluster, nil
 }
 
-func (dw *DefaultClusterWait) WaitForClusterToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*cmv1.Cluster, error) {
-	resource := dw.collection.Cluster(clusterId)
+func (dw *DefaultClusterWait) WaitForClusterToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error) {
+	if dw.readiness == nil {
+		return dw.waitForClusterToBeReady(ctx, clusterId, timeout)
+	}
+	return dw.readiness.Wait(ctx, clusterId, timeout, dw.waitForClusterToBeReady, dw.getCluster)
+}
 
-	// First try to get the cluster and check its state
-	// Return an error in case:
-	// * Cluster not found
-	// * Cluster found but its state is "ERROR" or "UNINSTALLING" (will never become to "READY")
-	// In case the state is "READY" return the cluster
-	resp, err := resource.Get().SendContext(ctx)
-	if err != nil && resp.Status() == http.StatusNotFound {
+// getCluster gets the current state of a cluster that is known to be ready.
+func (dw *DefaultClusterWait) getCluster(ctx context.Context, clusterId string) (*cmv1.Cluster, error) {
+	response, err := dw.collection.Cluster(clusterId).Get().Header(CacheControlHeader, NoCache).SendContext(ctx)
+	if err != nil {
 		message := fmt.Sprintf("Failed to get Cluster '%s', with error: %v", clusterId, err)
 		tflog.Error(ctx, message)
-		return nil, fmt.Errorf(message)
+		return nil, errors.New(message)
 	}
-	currentState := resp.Body().State()
-	if currentState == cmv1.ClusterStateError || currentState == cmv1.ClusterStateUninstalling {
-		message := fmt.Sprintf("Cluster '%s' is in state '%s' and will not become ready", clusterId, currentState)
-		tflog.Error(ctx, message)
-		return resp.Body(), fmt.Errorf(message)
+	return response.Body(), nil
+}
+
+func (dw *DefaultClusterWait) waitForClusterToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*cmv1.Cluster, error) {
+	tflog.Info(ctx, fmt.Sprintf("WaitForClusterToBeReady: Waiting for the state of cluster '%s' to become 'READY' "+
+		"- timeout %s", clusterId, timeout))
+
+	// The clusters in state "ERROR" or "UNINSTALLING" will never become ready, so the wait stops
+	// there too:
+	cluster, err := dw.waitFor(ctx, clusterId, timeout, func(ctx context.Context, cluster *cmv1.Cluster) (bool, error) {
+		switch cluster.State() {
+		case cmv1.ClusterStateReady,
+			cmv1.ClusterStateError,
+			cmv1.ClusterStateUninstalling:
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return cluster, err
 	}
-	if currentState == cmv1.ClusterStateReady {
-		tflog.Info(ctx, fmt.Sprintf("WaitForClusterToBeReady: Cluster '%s' is with state \"READY\"", clusterId))
-		return resp.Body(), nil
+	if cluster.State() != cmv1.ClusterStateReady {
+		stateErr := NewClusterStateError(ctx, dw.collection.Cluster(clusterId), cluster)
+		tflog.Error(ctx, stateErr.Error(), map[string]interface{}{
+			"details": stateErr.Details(),
+		})
+		return cluster, stateErr
 	}
+	tflog.Info(ctx, fmt.Sprintf("WaitForClusterToBeReady: Wait done for cluster '%s' with state '%s'", clusterId,
+		cluster.State()))
+	return cluster, nil
+}
 
-	tflog.Info(ctx, fmt.Sprintf("WaitForClusterToBeReady: Cluster '%s' is with state '%s', Wait for the state to become 'READY' with timeout %d minutes",
-		clusterId, currentState, waitTimeoutMin))
+// WaitForCondition waits until the given condition is true for the cluster. When the condition
+// accepts a cluster that doesn't exist the result is nil.
+func (dw *DefaultClusterWait) WaitForCondition(ctx context.Context, clusterId string, timeout time.Duration,
+	condition ClusterCondition) (*cmv1.Cluster, error) {
+	return dw.waitFor(ctx, clusterId, timeout, condition)
+}
 
-	backoffAttempts := 3
-	backoffSleep := 30 * time.Second
+// waitFor gets the cluster every interval until the condition is true for it. It reports the
+// progress of the cluster after each request, and stops as soon as the timeout expires or the
+// context is cancelled, returning the last cluster received, if any, with the error.
+func (dw *DefaultClusterWait) waitFor(ctx context.Context, clusterId string, timeout time.Duration,
+	condition ClusterCondition) (*cmv1.Cluster, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	resource := dw.collection.Cluster(clusterId)
 	var cluster *cmv1.Cluster
-	for cluster == nil {
-		tflog.Debug(ctx, fmt.Sprintf("Updating tokens for cluster %s", clusterId))
-		dw.connection.Tokens()
-		cluster, err = pollClusterState(clusterId, ctx, waitTimeoutMin, dw.collection)
-		if err != nil {
-			backoffAttempts--
-			if backoffAttempts == 0 {
-				return nil, fmt.Errorf("polling cluster state failed with error %v", err)
+	failures := 0
+	for {
+		response, err := resource.Get().Header(CacheControlHeader, NoCache).SendContext(waitCtx)
+		switch {
+		case err == nil:
+			failures = 0
+			cluster = response.Body()
+			reportClusterProgress(ctx, cluster, time.Since(start))
+			done, err := condition(waitCtx, cluster)
+			if err != nil {
+				return cluster, err
+			}
+			if done {
+				return cluster, nil
+			}
+		case response.Status() == http.StatusNotFound:
+			done, conditionErr := condition(waitCtx, nil)
+			if conditionErr == nil && done {
+				tflog.Info(ctx, fmt.Sprintf("Cluster '%s' doesn't exist after %s", clusterId,
+					time.Since(start).Round(time.Second)))
+				return nil, nil
+			}
+			message := fmt.Sprintf("Failed to get Cluster '%s', with error: %v", clusterId, err)
+			tflog.Error(ctx, message)
+			return nil, errors.New(message)
+		case waitCtx.Err() != nil:
+			// The error is reported below, when the wait notices that the context is done.
+		default:
+			failures++
+			tflog.Warn(ctx, fmt.Sprintf("Failed to get cluster '%s', attempt %d of %d: %v", clusterId,
+				failures, clusterWaitAttempts, err))
+			if failures == clusterWaitAttempts {
+				return cluster, fmt.Errorf("polling cluster state failed with error %v", err)
 			}
-			time.Sleep(backoffSleep)
+		}
+		err = Sleep(waitCtx, dw.interval)
+		if err != nil {
+			return cluster, waitError(ctx, clusterId, cluster, time.Since(start))
 		}
 	}
-
-	tflog.Info(ctx, fmt.Sprintf("WaitForClusterToBeReady: Wait done for cluster '%s' with state '%s'", clusterId, currentState))
-
-	// If Cluster is ready without ERROR
-	// Otherwise return with ERROR
-	if cluster.State() == cmv1.ClusterStateReady {
-		return cluster, nil
-	}
-	return cluster, fmt.Errorf("cluster '%s' is in state '%s'", clusterId, cluster.State())
 }
 
-func pollClusterCurrentCompute(clusterId string, ctx context.Context, timeout int64, clusterCollection *cmv1.ClustersClient) (*cmv1.Cluster, error) {
-	client := clusterCollection.Cluster(clusterId)
-	var object *cmv1.Cluster
-	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
-	defer cancel()
-	_, err := client.Poll().
-		Interval(pollingIntervalInMinutes * time.Minute).
-		Predicate(func(getClusterResponse *cmv1.ClusterGetResponse) bool {
-			object = getClusterResponse.Body()
-			tflog.Debug(ctx, "polled cluster compute", map[string]interface{}{
-				"currentCompute": object.Status().CurrentCompute(),
-			})
-			switch object.Status().CurrentCompute() {
-			case object.Nodes().Compute():
-				return true
-			}
-			return false
-		}).
-		StartContext(pollCtx)
-	if err != nil {
-		tflog.Error(ctx, "Failed polling cluster compute")
-		return nil, err
+// waitError explains why a wait stopped before the cluster reached the expected state, which is
+// either the cancellation of the context, for example when the user presses Ctrl-C, or the expiry
+// of the timeout.
+func waitError(ctx context.Context, clusterId string, cluster *cmv1.Cluster, elapsed time.Duration) error {
+	var err error
+	if ctx.Err() != nil {
+		err = fmt.Errorf("waiting for cluster '%s' was interrupted after %s: %w", clusterId,
+			elapsed.Round(time.Second), ctx.Err())
+	} else {
+		err = fmt.Errorf("timed out after %s waiting for cluster '%s'", elapsed.Round(time.Second), clusterId)
 	}
+	if cluster != nil {
+		err = fmt.Errorf("%w, the last %s", err, describeClusterProgress(cluster))
+	}
+	tflog.Error(ctx, err.Error())
+	return err
+}
 
-	return object, nil
+// reportClusterProgress writes the state of the cluster and the time waited for it to the log, so
+// that long waits don't look like the provider is hung.
+func reportClusterProgress(ctx context.Context, cluster *cmv1.Cluster, elapsed time.Duration) {
+	elapsed = elapsed.Round(time.Second)
+	tflog.Info(ctx, fmt.Sprintf("Still waiting for cluster '%s' after %s, %s", cluster.ID(), elapsed,
+		describeClusterProgress(cluster)), map[string]interface{}{
+		"state":                cluster.State(),
+		"status_description":   cluster.Status().Description(),
+		"provision_error_code": cluster.Status().ProvisionErrorCode(),
+		"current_compute":      cluster.Status().CurrentCompute(),
+		"elapsed":              elapsed.String(),
+	})
 }
 
-func pollClusterState(clusterId string, ctx context.Context, timeout int64, clusterCollection *cmv1.ClustersClient) (*cmv1.Cluster, error) {
-	client := clusterCollection.Cluster(clusterId)
-	var object *cmv1.Cluster
-	pollCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Minute)
-	defer cancel()
-	_, err := client.Poll().
-		Interval(pollingIntervalInMinutes * time.Minute).
-		Predicate(func(getClusterResponse *cmv1.ClusterGetResponse) bool {
-			object = getClusterResponse.Body()
-			tflog.Debug(ctx, "polled cluster state", map[string]interface{}{
-				"state": object.State(),
-			})
-			switch object.State() {
-			case cmv1.ClusterStateReady,
-				cmv1.ClusterStateError,
-				cmv1.ClusterStateUninstalling:
-				return true
-			}
-			return false
-		}).
-		StartContext(pollCtx)
-	if err != nil {
-		tflog.Error(ctx, "Failed polling cluster state")
-		return nil, err
+func describeClusterProgress(cluster *cmv1.Cluster) string {
+	details := []string{
+		fmt.Sprintf("state is '%s'", cluster.State()),
+		fmt.Sprintf("compute nodes are %d/%d", cluster.Status().CurrentCompute(), cluster.Nodes().Compute()),
 	}
-
-	return object, nil
+	if description := cluster.Status().Description(); description != "" {
+		details = append(details, fmt.Sprintf("status is '%s'", description))
+	}
+	if code := cluster.Status().ProvisionErrorCode(); code != "" {
+		details = append(details, fmt.Sprintf("provision error code is '%s'", code))
+	}
+	return strings.Join(details, ", ")
 }
 
-func ValidateTimeout(timeOut *int64, defaultTimeout int64) (*int64, error) {
-	if timeOut == nil {
-		return &defaultTimeout, nil
-	}
-	if *timeOut <= 0 {
-		return nil, fmt.Errorf("timeout must be greater than 0 minutes")
+// Sleep waits for the given duration, or until the context is done, in which case it returns the
+// error of the context.
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	return timeOut, nil
 }
@@ -0,0 +1,212 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/fake"
+)
+
+var _ = Describe("Cluster wait", func() {
+	const clusterPath = "/api/clusters_mgmt/v1/clusters/123"
+
+	var (
+		ctx        context.Context
+		now        time.Time
+		server     *fake.Server
+		listener   *httptest.Server
+		connection *sdk.Connection
+		wait       ClusterWait
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		server = fake.NewServer().
+			ReadyAfter(10 * time.Minute).
+			Clock(func() time.Time {
+				// Each request moves the clock a minute forward, so the clusters become ready
+				// after some checks without waiting for real:
+				now = now.Add(time.Minute)
+				return now
+			}).
+			Build()
+		listener = httptest.NewServer(server)
+		var err error
+		connection, err = sdk.NewConnectionBuilder().
+			URL(listener.URL).
+			Tokens(fake.Token()).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		wait = NewClusterWait(connection.ClustersMgmt().V1().Clusters(), time.Millisecond, nil)
+	})
+
+	AfterEach(func() {
+		connection.Close()
+		listener.Close()
+	})
+
+	createCluster := func() string {
+		cluster, err := cmv1.NewCluster().Name("mycluster").
+			Nodes(cmv1.NewClusterNodes().Compute(3)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		response, err := connection.ClustersMgmt().V1().Clusters().Add().Body(cluster).SendContext(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		return response.Body().ID()
+	}
+
+	It("Uses the default interval when none is given", func() {
+		wait = NewClusterWait(connection.ClustersMgmt().V1().Clusters(), 0, nil)
+		Expect(wait.(*DefaultClusterWait).interval).To(Equal(DefaultClusterWaitInterval))
+	})
+
+	It("Waits until the cluster is ready", func() {
+		id := createCluster()
+		cluster, err := wait.WaitForClusterToBeReady(ctx, id, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.State()).To(Equal(cmv1.ClusterStateReady))
+	})
+
+	It("Gets the current cluster when it is already known to be ready", func() {
+		wait = NewClusterWait(connection.ClustersMgmt().V1().Clusters(), time.Millisecond,
+			NewClusterReadiness())
+		id := createCluster()
+		_, err := wait.WaitForClusterToBeReady(ctx, id, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		server.Put("/api/clusters_mgmt/v1/clusters/"+id, map[string]interface{}{
+			"state": "ready",
+			"nodes": map[string]interface{}{
+				"compute": 5,
+			},
+		})
+		cluster, err := wait.WaitForClusterToBeReady(ctx, id, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Nodes().Compute()).To(Equal(5))
+	})
+
+	It("Waits until the compute nodes are ready", func() {
+		id := createCluster()
+		cluster, err := wait.WaitForStdComputeNodesToBeReady(ctx, id, time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.Status().CurrentCompute()).To(Equal(3))
+	})
+
+	It("Stops when the cluster will not become ready", func() {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "error",
+			"status": map[string]interface{}{
+				"state":                "error",
+				"description":          "Install failed",
+				"provision_error_code": "OCM3055",
+			},
+		})
+		cluster, err := wait.WaitForClusterToBeReady(ctx, "123", time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("is in state 'error' and will not become ready")))
+		Expect(cluster).ToNot(BeNil())
+		Expect(cluster.Status().ProvisionErrorCode()).To(Equal("OCM3055"))
+	})
+
+	It("Reports the details of a failed installation", func() {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "error",
+			"status": map[string]interface{}{
+				"state":                   "error",
+				"description":             "Install failed",
+				"provision_error_code":    "OCM3055",
+				"provision_error_message": "Egress is blocked",
+			},
+		})
+		server.Put(clusterPath+"/inflight_checks/1", map[string]interface{}{
+			"name":  "egress",
+			"state": "failed",
+			"details": map[string]interface{}{
+				"subnet": "subnet-1",
+			},
+		})
+		server.Put(clusterPath+"/inflight_checks/2", map[string]interface{}{
+			"name":  "dns",
+			"state": "passed",
+		})
+		server.Put(clusterPath+"/logs/install", map[string]interface{}{
+			"content": "level=info msg=Waiting\nlevel=error msg=Bootstrap failed\n",
+		})
+		_, err := wait.WaitForClusterToBeReady(ctx, "123", time.Minute)
+		var stateErr *ClusterStateError
+		Expect(errors.As(err, &stateErr)).To(BeTrue())
+		Expect(stateErr.State).To(Equal(cmv1.ClusterStateError))
+		Expect(stateErr.ProvisionErrorMessage).To(Equal("Egress is blocked"))
+		Expect(stateErr.FailedInflightChecks).To(Equal([]string{`egress: {"subnet":"subnet-1"}`}))
+		Expect(stateErr.Details()).To(Equal("Status: Install failed\n" +
+			"Provision error: OCM3055 Egress is blocked\n" +
+			"Failed inflight checks:\n" +
+			"  - egress: {\"subnet\":\"subnet-1\"}\n" +
+			"Install log (last 20 lines):\n" +
+			"  level=info msg=Waiting\n" +
+			"  level=error msg=Bootstrap failed"))
+	})
+
+	It("Doesn't fail when the details of a failed installation can't be fetched", func() {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "error",
+		})
+		_, err := wait.WaitForClusterToBeReady(ctx, "123", time.Minute)
+		var stateErr *ClusterStateError
+		Expect(errors.As(err, &stateErr)).To(BeTrue())
+		Expect(stateErr.InstallLogTail).To(BeEmpty())
+		Expect(stateErr.Details()).To(BeEmpty())
+	})
+
+	It("Fails when the cluster doesn't exist", func() {
+		cluster, err := wait.WaitForClusterToBeReady(ctx, "456", time.Minute)
+		Expect(err).To(MatchError(ContainSubstring("Failed to get Cluster '456'")))
+		Expect(cluster).To(BeNil())
+	})
+
+	It("Stops when the timeout expires and reports the last state", func() {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "installing",
+			"status": map[string]interface{}{
+				"state":       "installing",
+				"description": "Waiting for OIDC configuration",
+			},
+		})
+		cluster, err := wait.WaitForClusterToBeReady(ctx, "123", 50*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out after")))
+		Expect(err).To(MatchError(ContainSubstring("state is 'installing'")))
+		Expect(err).To(MatchError(ContainSubstring("status is 'Waiting for OIDC configuration'")))
+		Expect(cluster.State()).To(Equal(cmv1.ClusterStateInstalling))
+	})
+
+	It("Stops at once when the context is cancelled", func() {
+		server.Put(clusterPath, map[string]interface{}{
+			"state": "installing",
+		})
+		wait = NewClusterWait(connection.ClustersMgmt().V1().Clusters(), time.Hour, nil)
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := wait.WaitForClusterToBeReady(cancelCtx, "123", time.Hour)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(err).To(MatchError(ContainSubstring("was interrupted after")))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+	})
+})
+
+var _ = Describe("Sleep", func() {
+	It("Returns when the duration passes", func() {
+		Expect(Sleep(context.Background(), time.Millisecond)).To(Succeed())
+	})
+
+	It("Returns the error of the context when it is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(Sleep(ctx, time.Hour)).To(MatchError(context.Canceled))
+	})
+})
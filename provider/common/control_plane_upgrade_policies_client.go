@@ -0,0 +1,108 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ControlPlaneUpgradePoliciesClient manages the upgrade policies of the control plane of the hosted
+// control plane clusters and the agreements to the version gates that the upgrades require.
+//
+//go:generate mockgen -source=control_plane_upgrade_policies_client.go -package=common -destination=mock_controlplaneupgradepoliciesclient.go
+type ControlPlaneUpgradePoliciesClient interface {
+	List(ctx context.Context, clusterId string) ([]*cmv1.ControlPlaneUpgradePolicy, error)
+	Get(ctx context.Context, clusterId string, policyId string) (*cmv1.ControlPlaneUpgradePolicy, error)
+	Create(ctx context.Context, clusterId string,
+		policy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error)
+	Delete(ctx context.Context, clusterId string, policyId string) error
+	MissingGateAgreements(ctx context.Context, clusterId string,
+		policy *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error)
+	AckVersionGate(ctx context.Context, clusterId string, gateId string) error
+}
+
+type DefaultControlPlaneUpgradePoliciesClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewControlPlaneUpgradePoliciesClient(client *cmv1.ClustersClient) ControlPlaneUpgradePoliciesClient {
+	return &DefaultControlPlaneUpgradePoliciesClient{client: client}
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) collection(clusterId string) *cmv1.ControlPlaneUpgradePoliciesClient {
+	return c.client.Cluster(clusterId).ControlPlane().UpgradePolicies()
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) List(ctx context.Context,
+	clusterId string) ([]*cmv1.ControlPlaneUpgradePolicy, error) {
+	result := []*cmv1.ControlPlaneUpgradePolicy{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) Get(ctx context.Context, clusterId string,
+	policyId string) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	resp, err := c.collection(clusterId).ControlPlaneUpgradePolicy(policyId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) Create(ctx context.Context, clusterId string,
+	policy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	resp, err := c.collection(clusterId).Add().Body(policy).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) Delete(ctx context.Context, clusterId string, policyId string) error {
+	_, err := c.collection(clusterId).ControlPlaneUpgradePolicy(policyId).Delete().SendContext(ctx)
+	return err
+}
+
+// MissingGateAgreements creates the policy in dry run mode, which fails listing the version gates
+// that haven't been agreed yet, if any.
+func (c *DefaultControlPlaneUpgradePoliciesClient) MissingGateAgreements(ctx context.Context, clusterId string,
+	policy *cmv1.ControlPlaneUpgradePolicy) ([]*cmv1.VersionGate, error) {
+	response, err := c.collection(clusterId).Add().Parameter("dryRun", true).Body(policy).SendContext(ctx)
+	if err != nil && response.Error() != nil {
+		// parse gates list
+		errorDetails, ok := response.Error().GetDetails()
+		if !ok {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		data, err := json.Marshal(errorDetails)
+		if err != nil {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		gates, err := cmv1.UnmarshalVersionGateList(data)
+		if err != nil {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		// return original error if invaild version gate detected
+		if len(gates) > 0 && gates[0].ID() == "" {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		return gates, nil
+	}
+	return []*cmv1.VersionGate{}, nil
+}
+
+func (c *DefaultControlPlaneUpgradePoliciesClient) AckVersionGate(ctx context.Context, clusterId string,
+	gateId string) error {
+	return ackVersionGate(ctx, c.client, clusterId, gateId)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/pkg/errors"
+	"github.com/zgalor/weberr"
+)
+
+// ErrorKind classifies the errors returned by OCM that have a known remediation.
+type ErrorKind string
+
+const (
+	QuotaError          ErrorKind = "quota"
+	RolesError          ErrorKind = "roles"
+	SubnetsError        ErrorKind = "subnets"
+	VersionError        ErrorKind = "version"
+	AuthenticationError ErrorKind = "authentication"
+	PermissionError     ErrorKind = "permission"
+	ThrottlingError     ErrorKind = "throttling"
+)
+
+// ErrorAttribute tells the translation of the errors which attribute of the resource contains the
+// values that cause the errors of a kind, so that Terraform can point to it.
+type ErrorAttribute struct {
+	Kind ErrorKind
+	Path path.Path
+}
+
+type errorHint struct {
+	kind   ErrorKind
+	status int
+	reason *regexp.Regexp
+	hint   string
+}
+
+// errorHints are checked in order, and the first one that matches the status or the reason of the
+// error is used.
+var errorHints = []errorHint{
+	{
+		kind:   AuthenticationError,
+		status: http.StatusUnauthorized,
+		hint: "The OCM token is invalid or has expired. Get a new one from " +
+			"https://console.redhat.com/openshift/token/rosa and set it in the `token` attribute " +
+			"of the provider or in the `RHCS_TOKEN` environment variable.",
+	},
+	{
+		kind:   ThrottlingError,
+		status: http.StatusTooManyRequests,
+		hint: "OCM is throttling the requests of the organization. Try again later, or reduce the " +
+			"parallelism of Terraform or the `rate_limit` of the provider.",
+	},
+	{
+		kind:   QuotaError,
+		status: http.StatusPaymentRequired,
+		reason: regexp.MustCompile(`(?i)quota`),
+		hint: "The organization doesn't have enough quota for this request. Check the quota of " +
+			"the organization at https://console.redhat.com/openshift/quota, and the service " +
+			"quotas of the AWS account with `rosa verify quota`.",
+	},
+	{
+		kind:   RolesError,
+		reason: regexp.MustCompile(`(?i)(role|arn:aws:iam).*(not found|does ?n[o']t exist|assume|not valid|invalid|missing|not authorized)`),
+		hint: "Check that the account roles, the operator roles and the OIDC provider exist in " +
+			"the AWS account and have the expected policies, for example with `rosa list " +
+			"account-roles` and `rosa list operator-roles`.",
+	},
+	{
+		kind:   SubnetsError,
+		reason: regexp.MustCompile(`(?i)subnet`),
+		hint: "Check that the subnets exist in the region of the cluster, that they belong to the " +
+			"same VPC, that they are in the selected availability zones, and that there are " +
+			"public and private subnets unless the cluster is private.",
+	},
+	{
+		kind:   VersionError,
+		reason: regexp.MustCompile(`(?i)version.*(not (supported|available|enabled|found|valid)|unsupported|invalid)`),
+		hint: "The version isn't available for this kind of cluster or channel. The `rhcs_versions` " +
+			"data source lists the versions that can be used.",
+	},
+	{
+		kind:   PermissionError,
+		status: http.StatusForbidden,
+		hint: "The user or service account doesn't have the permissions needed in the organization. " +
+			"Ask an organization administrator to grant them.",
+	},
+}
+
+// ocmError keeps the error returned by OCM, so that the translation can use its details, while
+// keeping the message and type that HandleErr always returned.
+type ocmError struct {
+	error
+	ocm *ocmerrors.Error
+}
+
+func (e *ocmError) Unwrap() error {
+	return e.ocm
+}
+
+// HandleErr converts the error of an OCM response into an error containing the reason. The
+// details of the OCM error are kept, so that AddError can report them.
+func HandleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" && err != nil {
+		msg = err.Error()
+	}
+	errType := weberr.ErrorType(res.Status())
+	result := errType.Set(errors.Errorf("%s", msg))
+	if res == nil {
+		return result
+	}
+	return &ocmError{
+		error: result,
+		ocm:   res,
+	}
+}
+
+// AsOCMError returns the error returned by OCM contained in the given error, if any.
+func AsOCMError(err error) (*ocmerrors.Error, bool) {
+	var result *ocmerrors.Error
+	if err == nil || !errors.As(err, &result) || result == nil {
+		return nil, false
+	}
+	return result, true
+}
+
+// ErrorDiagnostic creates the diagnostic for a failure. When the error was returned by OCM the
+// detail is completed with its code, its operation identifier, which the support team needs to
+// find the request, and a hint for the common problems. If the hint points to one of the given
+// attributes the diagnostic is attached to it. When the error is a ClusterStateError the detail is
+// completed with the explanation of the failure of the cluster.
+func ErrorDiagnostic(summary, detail string, err error, attributes ...ErrorAttribute) diag.Diagnostic {
+	var stateErr *ClusterStateError
+	if errors.As(err, &stateErr) {
+		if details := stateErr.Details(); details != "" {
+			detail = detail + "\n\n" + details
+		}
+	}
+	ocmErr, ok := AsOCMError(err)
+	if !ok {
+		return diag.NewErrorDiagnostic(summary, detail)
+	}
+	lines := []string{}
+	if code := ocmErr.Code(); code != "" {
+		lines = append(lines, fmt.Sprintf("Error code: %s", code))
+	}
+	if operationID := ocmErr.OperationID(); operationID != "" {
+		lines = append(lines, fmt.Sprintf("Operation ID: %s", operationID))
+	}
+	hint, found := findErrorHint(ocmErr)
+	if found {
+		lines = append(lines, fmt.Sprintf("Hint: %s", hint.hint))
+	}
+	if len(lines) > 0 {
+		detail = detail + "\n\n" + strings.Join(lines, "\n")
+	}
+	if found {
+		for _, attribute := range attributes {
+			if attribute.Kind == hint.kind {
+				return diag.NewAttributeErrorDiagnostic(attribute.Path, summary, detail)
+			}
+		}
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
+
+// AddError adds to the diagnostics the error for a failure, translated with ErrorDiagnostic.
+func AddError(diags *diag.Diagnostics, summary, detail string, err error, attributes ...ErrorAttribute) {
+	diags.Append(ErrorDiagnostic(summary, detail, err, attributes...))
+}
+
+func findErrorHint(err *ocmerrors.Error) (errorHint, bool) {
+	for _, hint := range errorHints {
+		if hint.status != 0 && hint.status == err.Status() {
+			return hint, true
+		}
+		if hint.reason != nil && hint.reason.MatchString(err.Reason()) {
+			return hint, true
+		}
+	}
+	return errorHint{}, false
+}
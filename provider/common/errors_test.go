@@ -0,0 +1,77 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+var _ = Describe("Error translation", func() {
+	newOCMError := func(status int, code, reason string) *ocmerrors.Error {
+		err, buildErr := ocmerrors.NewError().
+			Status(status).
+			Code(code).
+			Reason(reason).
+			OperationID("op-123").
+			Build()
+		Expect(buildErr).ToNot(HaveOccurred())
+		return err
+	}
+
+	It("Leaves other errors unchanged", func() {
+		err := fmt.Errorf("something failed")
+		result := ErrorDiagnostic("Can't create cluster", "Can't create cluster: something failed", err)
+		Expect(result).To(Equal(diag.NewErrorDiagnostic("Can't create cluster", "Can't create cluster: something failed")))
+	})
+
+	It("Adds the code, the operation identifier and the hint", func() {
+		err := newOCMError(http.StatusBadRequest, "CLUSTERS-MGMT-400", "The subnet 'subnet-1' doesn't exist")
+		result := ErrorDiagnostic("Can't create cluster", "Can't create cluster", err)
+		Expect(result.Detail()).To(HavePrefix("Can't create cluster\n\nError code: CLUSTERS-MGMT-400\nOperation ID: op-123\nHint: "))
+		Expect(result.Detail()).To(ContainSubstring("same VPC"))
+		_, ok := result.(diag.DiagnosticWithPath)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Points to the attribute that caused the error", func() {
+		err := newOCMError(http.StatusBadRequest, "CLUSTERS-MGMT-400", "Version 'openshift-v4.1.0' is not supported")
+		result := ErrorDiagnostic("Can't create cluster", "Can't create cluster", err,
+			ErrorAttribute{Kind: SubnetsError, Path: path.Root("aws_subnet_ids")},
+			ErrorAttribute{Kind: VersionError, Path: path.Root("version")},
+		)
+		withPath, ok := result.(diag.DiagnosticWithPath)
+		Expect(ok).To(BeTrue())
+		Expect(withPath.Path()).To(Equal(path.Root("version")))
+	})
+
+	It("Selects the hints by status", func() {
+		err := newOCMError(http.StatusPaymentRequired, "ACCT-MGMT-11", "Insufficient resources")
+		Expect(ErrorDiagnostic("Failed", "Failed", err).Detail()).To(ContainSubstring("enough quota"))
+		err = newOCMError(http.StatusUnauthorized, "", "")
+		Expect(ErrorDiagnostic("Failed", "Failed", err).Detail()).To(ContainSubstring("RHCS_TOKEN"))
+	})
+
+	It("Keeps the details of the errors converted by HandleErr", func() {
+		ocmErr := newOCMError(http.StatusBadRequest, "CLUSTERS-MGMT-400", "Invalid upgrade")
+		err := HandleErr(ocmErr, ocmErr)
+		Expect(err.Error()).To(Equal("Invalid upgrade"))
+		found, ok := AsOCMError(fmt.Errorf("can't upgrade: %w", err))
+		Expect(ok).To(BeTrue())
+		Expect(found.OperationID()).To(Equal("op-123"))
+	})
+
+	It("Adds the explanation of the failure of the cluster", func() {
+		err := fmt.Errorf("can't poll cluster: %w", &ClusterStateError{
+			ClusterID:          "123",
+			State:              "error",
+			ProvisionErrorCode: "OCM3055",
+		})
+		Expect(ErrorDiagnostic("Failed", "Failed", err).Detail()).To(Equal(
+			"Failed\n\nProvision error: OCM3055"))
+	})
+})
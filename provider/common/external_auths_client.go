@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ExternalAuthsClient manages the external authentication providers of the hosted control plane
+// clusters that have them enabled.
+//
+//go:generate mockgen -source=external_auths_client.go -package=common -destination=mock_externalauthsclient.go
+type ExternalAuthsClient interface {
+	Get(ctx context.Context, clusterId string, externalAuthId string) (*cmv1.ExternalAuth, error)
+	Exists(ctx context.Context, clusterId string, externalAuthId string) (bool, *cmv1.ExternalAuth, error)
+	Create(ctx context.Context, clusterId string, externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error)
+	Update(ctx context.Context, clusterId string, externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error)
+	Delete(ctx context.Context, clusterId string, externalAuthId string) error
+}
+
+type DefaultExternalAuthsClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewExternalAuthsClient(client *cmv1.ClustersClient) ExternalAuthsClient {
+	return &DefaultExternalAuthsClient{client: client}
+}
+
+func (c *DefaultExternalAuthsClient) collection(clusterId string) *cmv1.ExternalAuthsClient {
+	return c.client.Cluster(clusterId).ExternalAuthConfig().ExternalAuths()
+}
+
+func (c *DefaultExternalAuthsClient) Get(ctx context.Context, clusterId string,
+	externalAuthId string) (*cmv1.ExternalAuth, error) {
+	resp, err := c.collection(clusterId).ExternalAuth(externalAuthId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultExternalAuthsClient) Exists(ctx context.Context, clusterId string,
+	externalAuthId string) (bool, *cmv1.ExternalAuth, error) {
+	resp, err := c.collection(clusterId).ExternalAuth(externalAuthId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultExternalAuthsClient) Create(ctx context.Context, clusterId string,
+	externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error) {
+	resp, err := c.collection(clusterId).Add().Body(externalAuth).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultExternalAuthsClient) Update(ctx context.Context, clusterId string,
+	externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error) {
+	resp, err := c.collection(clusterId).ExternalAuth(externalAuth.ID()).Update().Body(externalAuth).
+		SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultExternalAuthsClient) Delete(ctx context.Context, clusterId string, externalAuthId string) error {
+	_, err := c.collection(clusterId).ExternalAuth(externalAuthId).Delete().SendContext(ctx)
+	return err
+}
@@ -27,9 +27,6 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
-	"github.com/pkg/errors"
-	"github.com/zgalor/weberr"
 )
 
 const (
@@ -138,15 +135,6 @@ func IsGreaterThanOrEqual(version1, version2 string) (bool, error) {
 	return v1.GreaterThanOrEqual(v2), nil
 }
 
-func HandleErr(res *ocmerrors.Error, err error) error {
-	msg := res.Reason()
-	if msg == "" {
-		msg = err.Error()
-	}
-	errType := weberr.ErrorType(res.Status())
-	return errType.Set(errors.Errorf("%s", msg))
-}
-
 // HasValue checks if the given terraform value is set.
 func HasValue(val attr.Value) bool {
 	return !val.IsUnknown() && !val.IsNull()
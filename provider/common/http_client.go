@@ -1,14 +1,60 @@
 package common
 
-import "net/http"
+import (
+	"context"
+	// nolint:gosec
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
 
+// HttpClient sends the requests to the services other than OCM. The requests are cancelled when
+// the context is done.
 type HttpClient interface {
-	Get(url string) (resp *http.Response, err error)
+	Get(ctx context.Context, url string) (resp *http.Response, err error)
 }
 
+// DefaultHttpClient sends the requests with the given client, or with the default client of the
+// http package if it is nil.
 type DefaultHttpClient struct {
+	Client *http.Client
 }
 
-func (c DefaultHttpClient) Get(url string) (resp *http.Response, err error) {
-	return http.Get(url)
+func (c DefaultHttpClient) Get(ctx context.Context, url string) (resp *http.Response, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Client != nil {
+		return c.Client.Do(request)
+	}
+	return http.DefaultClient.Do(request)
+}
+
+// FetchThumbprint returns the SHA1 fingerprint of the top intermediate CA of the OIDC endpoint,
+// as expected by AWS, using the given client so that the proxy settings of the provider are
+// honored.
+func FetchThumbprint(ctx context.Context, httpClient HttpClient, oidcEndpointURL string) (string, error) {
+	connect, err := url.ParseRequestURI(oidcEndpointURL)
+	if err != nil {
+		return "", err
+	}
+	response, err := httpClient.Get(ctx, fmt.Sprintf("https://%s:443", connect.Host))
+	if err != nil {
+		return "", err
+	}
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response.TLS == nil || len(response.TLS.PeerCertificates) == 0 {
+		return "", fmt.Errorf("OIDC endpoint '%s' didn't present any certificate", connect.Host)
+	}
+	// https://docs.aws.amazon.com/IAM/latest/UserGuide/id_roles_providers_create_oidc_verify-thumbprint.html
+	// The last certificate of the chain is the one of the top intermediate CA:
+	certChain := response.TLS.PeerCertificates
+	// nolint:gosec
+	hash := sha1.Sum(certChain[len(certChain)-1].Raw)
+	return hex.EncodeToString(hash[:]), nil
 }
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// IdentityProvidersClient manages the identity providers of the clusters and the users of the
+// HTPasswd identity providers.
+//
+//go:generate mockgen -source=identity_providers_client.go -package=common -destination=mock_identityprovidersclient.go
+type IdentityProvidersClient interface {
+	Get(ctx context.Context, clusterId string, idpId string) (*cmv1.IdentityProvider, error)
+	Exists(ctx context.Context, clusterId string, idpId string) (bool, *cmv1.IdentityProvider, error)
+	Create(ctx context.Context, clusterId string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error)
+	Delete(ctx context.Context, clusterId string, idpId string) error
+	List(ctx context.Context, clusterId string) ([]*cmv1.IdentityProvider, error)
+	ListHTPasswdUsers(ctx context.Context, clusterId string, idpId string) ([]*cmv1.HTPasswdUser, error)
+	AddHTPasswdUser(ctx context.Context, clusterId string, idpId string, user *cmv1.HTPasswdUser) error
+	UpdateHTPasswdUser(ctx context.Context, clusterId string, idpId string, userId string, user *cmv1.HTPasswdUser) error
+	DeleteHTPasswdUser(ctx context.Context, clusterId string, idpId string, userId string) error
+}
+
+type DefaultIdentityProvidersClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewIdentityProvidersClient(client *cmv1.ClustersClient) IdentityProvidersClient {
+	return &DefaultIdentityProvidersClient{client: client}
+}
+
+func (c *DefaultIdentityProvidersClient) collection(clusterId string) *cmv1.IdentityProvidersClient {
+	return c.client.Cluster(clusterId).IdentityProviders()
+}
+
+func (c *DefaultIdentityProvidersClient) Get(ctx context.Context, clusterId string, idpId string) (*cmv1.IdentityProvider, error) {
+	resp, err := c.collection(clusterId).IdentityProvider(idpId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultIdentityProvidersClient) Exists(ctx context.Context, clusterId string, idpId string) (bool, *cmv1.IdentityProvider, error) {
+	resp, err := c.collection(clusterId).IdentityProvider(idpId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultIdentityProvidersClient) Create(ctx context.Context, clusterId string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	resp, err := c.collection(clusterId).Add().Body(idp).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultIdentityProvidersClient) Delete(ctx context.Context, clusterId string, idpId string) error {
+	_, err := c.collection(clusterId).IdentityProvider(idpId).Delete().SendContext(ctx)
+	return err
+}
+
+func (c *DefaultIdentityProvidersClient) List(ctx context.Context, clusterId string) ([]*cmv1.IdentityProvider, error) {
+	result := []*cmv1.IdentityProvider{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultIdentityProvidersClient) ListHTPasswdUsers(ctx context.Context, clusterId string, idpId string) ([]*cmv1.HTPasswdUser, error) {
+	result := []*cmv1.HTPasswdUser{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).IdentityProvider(idpId).HtpasswdUsers().List().
+			Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultIdentityProvidersClient) AddHTPasswdUser(ctx context.Context, clusterId string, idpId string, user *cmv1.HTPasswdUser) error {
+	_, err := c.collection(clusterId).IdentityProvider(idpId).HtpasswdUsers().Add().Body(user).SendContext(ctx)
+	return err
+}
+
+func (c *DefaultIdentityProvidersClient) UpdateHTPasswdUser(ctx context.Context, clusterId string, idpId string,
+	userId string, user *cmv1.HTPasswdUser) error {
+	_, err := c.collection(clusterId).IdentityProvider(idpId).HtpasswdUsers().HtpasswdUser(userId).Update().
+		Body(user).SendContext(ctx)
+	return err
+}
+
+func (c *DefaultIdentityProvidersClient) DeleteHTPasswdUser(ctx context.Context, clusterId string, idpId string, userId string) error {
+	_, err := c.collection(clusterId).IdentityProvider(idpId).HtpasswdUsers().HtpasswdUser(userId).Delete().SendContext(ctx)
+	return err
+}
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// IngressesClient manages the ingresses of the clusters.
+//
+//go:generate mockgen -source=ingresses_client.go -package=common -destination=mock_ingressesclient.go
+type IngressesClient interface {
+	Get(ctx context.Context, clusterId string, ingressId string) (*cmv1.Ingress, error)
+	Exists(ctx context.Context, clusterId string, ingressId string) (bool, *cmv1.Ingress, error)
+	List(ctx context.Context, clusterId string) ([]*cmv1.Ingress, error)
+	Update(ctx context.Context, clusterId string, ingressId string, ingress *cmv1.Ingress) (*cmv1.Ingress, error)
+}
+
+type DefaultIngressesClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewIngressesClient(client *cmv1.ClustersClient) IngressesClient {
+	return &DefaultIngressesClient{client: client}
+}
+
+func (c *DefaultIngressesClient) collection(clusterId string) *cmv1.IngressesClient {
+	return c.client.Cluster(clusterId).Ingresses()
+}
+
+func (c *DefaultIngressesClient) Get(ctx context.Context, clusterId string, ingressId string) (*cmv1.Ingress, error) {
+	resp, err := c.collection(clusterId).Ingress(ingressId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultIngressesClient) Exists(ctx context.Context, clusterId string, ingressId string) (bool, *cmv1.Ingress, error) {
+	resp, err := c.collection(clusterId).Ingress(ingressId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultIngressesClient) List(ctx context.Context, clusterId string) ([]*cmv1.Ingress, error) {
+	result := []*cmv1.Ingress{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultIngressesClient) Update(ctx context.Context, clusterId string, ingressId string,
+	ingress *cmv1.Ingress) (*cmv1.Ingress, error) {
+	resp, err := c.collection(clusterId).Ingress(ingressId).Update().Body(ingress).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
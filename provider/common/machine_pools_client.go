@@ -0,0 +1,107 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// MachinePoolsClient manages the machine pools of the classic clusters. The pools returned by the
+// client only contain the tags set by the user, not the ones added by the service.
+//
+//go:generate mockgen -source=machine_pools_client.go -package=common -destination=mock_machinepoolsclient.go
+type MachinePoolsClient interface {
+	Get(ctx context.Context, clusterId string, machinePoolId string) (*cmv1.MachinePool, error)
+	Exists(ctx context.Context, clusterId string, machinePoolId string) (bool, *cmv1.MachinePool, error)
+	Create(ctx context.Context, clusterId string, machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error)
+	Update(ctx context.Context, clusterId string, machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error)
+	Delete(ctx context.Context, clusterId string, machinePoolId string) error
+	Count(ctx context.Context, clusterId string) (int, error)
+	List(ctx context.Context, clusterId string) ([]*cmv1.MachinePool, error)
+}
+
+type DefaultMachinePoolsClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewMachinePoolsClient(client *cmv1.ClustersClient) MachinePoolsClient {
+	return &DefaultMachinePoolsClient{client: client}
+}
+
+func (c *DefaultMachinePoolsClient) collection(clusterId string) *cmv1.MachinePoolsClient {
+	return c.client.Cluster(clusterId).MachinePools()
+}
+
+func (c *DefaultMachinePoolsClient) Get(ctx context.Context, clusterId string, machinePoolId string) (*cmv1.MachinePool, error) {
+	resp, err := c.collection(clusterId).MachinePool(machinePoolId).Get().
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultMachinePoolsClient) Exists(ctx context.Context, clusterId string, machinePoolId string) (bool, *cmv1.MachinePool, error) {
+	resp, err := c.collection(clusterId).MachinePool(machinePoolId).Get().
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultMachinePoolsClient) Create(ctx context.Context, clusterId string,
+	machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error) {
+	resp, err := c.collection(clusterId).Add().Body(machinePool).
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+// Update sends the changes of the pool, which must contain at least the identifier of the pool.
+func (c *DefaultMachinePoolsClient) Update(ctx context.Context, clusterId string,
+	machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error) {
+	resp, err := c.collection(clusterId).MachinePool(machinePool.ID()).Update().Body(machinePool).
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultMachinePoolsClient) Delete(ctx context.Context, clusterId string, machinePoolId string) error {
+	_, err := c.collection(clusterId).MachinePool(machinePoolId).Delete().SendContext(ctx)
+	return err
+}
+
+// Count returns the number of machine pools of the cluster.
+func (c *DefaultMachinePoolsClient) Count(ctx context.Context, clusterId string) (int, error) {
+	resp, err := c.collection(clusterId).List().SendContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Size(), nil
+}
+
+// List returns all the machine pools of the cluster.
+func (c *DefaultMachinePoolsClient) List(ctx context.Context, clusterId string) ([]*cmv1.MachinePool, error) {
+	result := []*cmv1.MachinePool{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: break_glass_credentials_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=break_glass_credentials_client.go -package=common -destination=mock_breakglasscredentialsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBreakGlassCredentialsClient is a mock of BreakGlassCredentialsClient interface.
+type MockBreakGlassCredentialsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakGlassCredentialsClientMockRecorder
+}
+
+// MockBreakGlassCredentialsClientMockRecorder is the mock recorder for MockBreakGlassCredentialsClient.
+type MockBreakGlassCredentialsClientMockRecorder struct {
+	mock *MockBreakGlassCredentialsClient
+}
+
+// NewMockBreakGlassCredentialsClient creates a new mock instance.
+func NewMockBreakGlassCredentialsClient(ctrl *gomock.Controller) *MockBreakGlassCredentialsClient {
+	mock := &MockBreakGlassCredentialsClient{ctrl: ctrl}
+	mock.recorder = &MockBreakGlassCredentialsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakGlassCredentialsClient) EXPECT() *MockBreakGlassCredentialsClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBreakGlassCredentialsClient) Create(ctx context.Context, clusterId string, credential *v1.BreakGlassCredential) (*v1.BreakGlassCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, credential)
+	ret0, _ := ret[0].(*v1.BreakGlassCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBreakGlassCredentialsClientMockRecorder) Create(ctx, clusterId, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBreakGlassCredentialsClient)(nil).Create), ctx, clusterId, credential)
+}
+
+// Exists mocks base method.
+func (m *MockBreakGlassCredentialsClient) Exists(ctx context.Context, clusterId, credentialId string) (bool, *v1.BreakGlassCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, credentialId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.BreakGlassCredential)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockBreakGlassCredentialsClientMockRecorder) Exists(ctx, clusterId, credentialId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockBreakGlassCredentialsClient)(nil).Exists), ctx, clusterId, credentialId)
+}
+
+// Get mocks base method.
+func (m *MockBreakGlassCredentialsClient) Get(ctx context.Context, clusterId, credentialId string) (*v1.BreakGlassCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, credentialId)
+	ret0, _ := ret[0].(*v1.BreakGlassCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBreakGlassCredentialsClientMockRecorder) Get(ctx, clusterId, credentialId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBreakGlassCredentialsClient)(nil).Get), ctx, clusterId, credentialId)
+}
+
+// List mocks base method.
+func (m *MockBreakGlassCredentialsClient) List(ctx context.Context, clusterId string) ([]*v1.BreakGlassCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.BreakGlassCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBreakGlassCredentialsClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBreakGlassCredentialsClient)(nil).List), ctx, clusterId)
+}
+
+// RevokeAll mocks base method.
+func (m *MockBreakGlassCredentialsClient) RevokeAll(ctx context.Context, clusterId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, clusterId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockBreakGlassCredentialsClientMockRecorder) RevokeAll(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockBreakGlassCredentialsClient)(nil).RevokeAll), ctx, clusterId)
+}
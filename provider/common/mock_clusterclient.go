@@ -5,6 +5,7 @@
 //
 //	mockgen -source=cluster_client.go -package=common -destination=mock_clusterclient.go
 //
+
 // Package common is a generated GoMock package.
 package common
 
@@ -39,6 +40,22 @@ func (m *MockClusterClient) EXPECT() *MockClusterClientMockRecorder {
 	return m.recorder
 }
 
+// Exists mocks base method.
+func (m *MockClusterClient) Exists(ctx context.Context, clusterId string) (bool, *v1.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.Cluster)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockClusterClientMockRecorder) Exists(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockClusterClient)(nil).Exists), ctx, clusterId)
+}
+
 // FetchCluster mocks base method.
 func (m *MockClusterClient) FetchCluster(ctx context.Context, clusterId string) (*v1.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -5,12 +5,14 @@
 //
 //	mockgen -source=cluster_waiter.go -package=common -destination=mock_clusterwait.go
 //
+
 // Package common is a generated GoMock package.
 package common
 
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	gomock "go.uber.org/mock/gomock"
@@ -40,31 +42,46 @@ func (m *MockClusterWait) EXPECT() *MockClusterWaitMockRecorder {
 }
 
 // WaitForClusterToBeReady mocks base method.
-func (m *MockClusterWait) WaitForClusterToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*v1.Cluster, error) {
+func (m *MockClusterWait) WaitForClusterToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*v1.Cluster, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForClusterToBeReady", ctx, clusterId, waitTimeoutMin)
+	ret := m.ctrl.Call(m, "WaitForClusterToBeReady", ctx, clusterId, timeout)
 	ret0, _ := ret[0].(*v1.Cluster)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WaitForClusterToBeReady indicates an expected call of WaitForClusterToBeReady.
-func (mr *MockClusterWaitMockRecorder) WaitForClusterToBeReady(ctx, clusterId, waitTimeoutMin any) *gomock.Call {
+func (mr *MockClusterWaitMockRecorder) WaitForClusterToBeReady(ctx, clusterId, timeout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForClusterToBeReady", reflect.TypeOf((*MockClusterWait)(nil).WaitForClusterToBeReady), ctx, clusterId, timeout)
+}
+
+// WaitForCondition mocks base method.
+func (m *MockClusterWait) WaitForCondition(ctx context.Context, clusterId string, timeout time.Duration, condition ClusterCondition) (*v1.Cluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForCondition", ctx, clusterId, timeout, condition)
+	ret0, _ := ret[0].(*v1.Cluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForCondition indicates an expected call of WaitForCondition.
+func (mr *MockClusterWaitMockRecorder) WaitForCondition(ctx, clusterId, timeout, condition any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForClusterToBeReady", reflect.TypeOf((*MockClusterWait)(nil).WaitForClusterToBeReady), ctx, clusterId, waitTimeoutMin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForCondition", reflect.TypeOf((*MockClusterWait)(nil).WaitForCondition), ctx, clusterId, timeout, condition)
 }
 
 // WaitForStdComputeNodesToBeReady mocks base method.
-func (m *MockClusterWait) WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, waitTimeoutMin int64) (*v1.Cluster, error) {
+func (m *MockClusterWait) WaitForStdComputeNodesToBeReady(ctx context.Context, clusterId string, timeout time.Duration) (*v1.Cluster, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitForStdComputeNodesToBeReady", ctx, clusterId, waitTimeoutMin)
+	ret := m.ctrl.Call(m, "WaitForStdComputeNodesToBeReady", ctx, clusterId, timeout)
 	ret0, _ := ret[0].(*v1.Cluster)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WaitForStdComputeNodesToBeReady indicates an expected call of WaitForStdComputeNodesToBeReady.
-func (mr *MockClusterWaitMockRecorder) WaitForStdComputeNodesToBeReady(ctx, clusterId, waitTimeoutMin any) *gomock.Call {
+func (mr *MockClusterWaitMockRecorder) WaitForStdComputeNodesToBeReady(ctx, clusterId, timeout any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForStdComputeNodesToBeReady", reflect.TypeOf((*MockClusterWait)(nil).WaitForStdComputeNodesToBeReady), ctx, clusterId, waitTimeoutMin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForStdComputeNodesToBeReady", reflect.TypeOf((*MockClusterWait)(nil).WaitForStdComputeNodesToBeReady), ctx, clusterId, timeout)
 }
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: control_plane_upgrade_policies_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=control_plane_upgrade_policies_client.go -package=common -destination=mock_controlplaneupgradepoliciesclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockControlPlaneUpgradePoliciesClient is a mock of ControlPlaneUpgradePoliciesClient interface.
+type MockControlPlaneUpgradePoliciesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockControlPlaneUpgradePoliciesClientMockRecorder
+}
+
+// MockControlPlaneUpgradePoliciesClientMockRecorder is the mock recorder for MockControlPlaneUpgradePoliciesClient.
+type MockControlPlaneUpgradePoliciesClientMockRecorder struct {
+	mock *MockControlPlaneUpgradePoliciesClient
+}
+
+// NewMockControlPlaneUpgradePoliciesClient creates a new mock instance.
+func NewMockControlPlaneUpgradePoliciesClient(ctrl *gomock.Controller) *MockControlPlaneUpgradePoliciesClient {
+	mock := &MockControlPlaneUpgradePoliciesClient{ctrl: ctrl}
+	mock.recorder = &MockControlPlaneUpgradePoliciesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockControlPlaneUpgradePoliciesClient) EXPECT() *MockControlPlaneUpgradePoliciesClientMockRecorder {
+	return m.recorder
+}
+
+// AckVersionGate mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) AckVersionGate(ctx context.Context, clusterId, gateId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AckVersionGate", ctx, clusterId, gateId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckVersionGate indicates an expected call of AckVersionGate.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) AckVersionGate(ctx, clusterId, gateId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckVersionGate", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).AckVersionGate), ctx, clusterId, gateId)
+}
+
+// Create mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) Create(ctx context.Context, clusterId string, policy *v1.ControlPlaneUpgradePolicy) (*v1.ControlPlaneUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, policy)
+	ret0, _ := ret[0].(*v1.ControlPlaneUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) Create(ctx, clusterId, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).Create), ctx, clusterId, policy)
+}
+
+// Delete mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) Delete(ctx context.Context, clusterId, policyId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, policyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) Delete(ctx, clusterId, policyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).Delete), ctx, clusterId, policyId)
+}
+
+// Get mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) Get(ctx context.Context, clusterId, policyId string) (*v1.ControlPlaneUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, policyId)
+	ret0, _ := ret[0].(*v1.ControlPlaneUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) Get(ctx, clusterId, policyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).Get), ctx, clusterId, policyId)
+}
+
+// List mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) List(ctx context.Context, clusterId string) ([]*v1.ControlPlaneUpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.ControlPlaneUpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).List), ctx, clusterId)
+}
+
+// MissingGateAgreements mocks base method.
+func (m *MockControlPlaneUpgradePoliciesClient) MissingGateAgreements(ctx context.Context, clusterId string, policy *v1.ControlPlaneUpgradePolicy) ([]*v1.VersionGate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MissingGateAgreements", ctx, clusterId, policy)
+	ret0, _ := ret[0].([]*v1.VersionGate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MissingGateAgreements indicates an expected call of MissingGateAgreements.
+func (mr *MockControlPlaneUpgradePoliciesClientMockRecorder) MissingGateAgreements(ctx, clusterId, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MissingGateAgreements", reflect.TypeOf((*MockControlPlaneUpgradePoliciesClient)(nil).MissingGateAgreements), ctx, clusterId, policy)
+}
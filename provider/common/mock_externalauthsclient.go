@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: external_auths_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=external_auths_client.go -package=common -destination=mock_externalauthsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExternalAuthsClient is a mock of ExternalAuthsClient interface.
+type MockExternalAuthsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalAuthsClientMockRecorder
+}
+
+// MockExternalAuthsClientMockRecorder is the mock recorder for MockExternalAuthsClient.
+type MockExternalAuthsClientMockRecorder struct {
+	mock *MockExternalAuthsClient
+}
+
+// NewMockExternalAuthsClient creates a new mock instance.
+func NewMockExternalAuthsClient(ctrl *gomock.Controller) *MockExternalAuthsClient {
+	mock := &MockExternalAuthsClient{ctrl: ctrl}
+	mock.recorder = &MockExternalAuthsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalAuthsClient) EXPECT() *MockExternalAuthsClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExternalAuthsClient) Create(ctx context.Context, clusterId string, externalAuth *v1.ExternalAuth) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, externalAuth)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExternalAuthsClientMockRecorder) Create(ctx, clusterId, externalAuth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExternalAuthsClient)(nil).Create), ctx, clusterId, externalAuth)
+}
+
+// Delete mocks base method.
+func (m *MockExternalAuthsClient) Delete(ctx context.Context, clusterId, externalAuthId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, externalAuthId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExternalAuthsClientMockRecorder) Delete(ctx, clusterId, externalAuthId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExternalAuthsClient)(nil).Delete), ctx, clusterId, externalAuthId)
+}
+
+// Exists mocks base method.
+func (m *MockExternalAuthsClient) Exists(ctx context.Context, clusterId, externalAuthId string) (bool, *v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, externalAuthId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.ExternalAuth)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockExternalAuthsClientMockRecorder) Exists(ctx, clusterId, externalAuthId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockExternalAuthsClient)(nil).Exists), ctx, clusterId, externalAuthId)
+}
+
+// Get mocks base method.
+func (m *MockExternalAuthsClient) Get(ctx context.Context, clusterId, externalAuthId string) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, externalAuthId)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockExternalAuthsClientMockRecorder) Get(ctx, clusterId, externalAuthId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockExternalAuthsClient)(nil).Get), ctx, clusterId, externalAuthId)
+}
+
+// Update mocks base method.
+func (m *MockExternalAuthsClient) Update(ctx context.Context, clusterId string, externalAuth *v1.ExternalAuth) (*v1.ExternalAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, externalAuth)
+	ret0, _ := ret[0].(*v1.ExternalAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExternalAuthsClientMockRecorder) Update(ctx, clusterId, externalAuth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExternalAuthsClient)(nil).Update), ctx, clusterId, externalAuth)
+}
@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: identity_providers_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=identity_providers_client.go -package=common -destination=mock_identityprovidersclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdentityProvidersClient is a mock of IdentityProvidersClient interface.
+type MockIdentityProvidersClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProvidersClientMockRecorder
+}
+
+// MockIdentityProvidersClientMockRecorder is the mock recorder for MockIdentityProvidersClient.
+type MockIdentityProvidersClientMockRecorder struct {
+	mock *MockIdentityProvidersClient
+}
+
+// NewMockIdentityProvidersClient creates a new mock instance.
+func NewMockIdentityProvidersClient(ctrl *gomock.Controller) *MockIdentityProvidersClient {
+	mock := &MockIdentityProvidersClient{ctrl: ctrl}
+	mock.recorder = &MockIdentityProvidersClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProvidersClient) EXPECT() *MockIdentityProvidersClientMockRecorder {
+	return m.recorder
+}
+
+// AddHTPasswdUser mocks base method.
+func (m *MockIdentityProvidersClient) AddHTPasswdUser(ctx context.Context, clusterId, idpId string, user *v1.HTPasswdUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHTPasswdUser", ctx, clusterId, idpId, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddHTPasswdUser indicates an expected call of AddHTPasswdUser.
+func (mr *MockIdentityProvidersClientMockRecorder) AddHTPasswdUser(ctx, clusterId, idpId, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHTPasswdUser", reflect.TypeOf((*MockIdentityProvidersClient)(nil).AddHTPasswdUser), ctx, clusterId, idpId, user)
+}
+
+// Create mocks base method.
+func (m *MockIdentityProvidersClient) Create(ctx context.Context, clusterId string, idp *v1.IdentityProvider) (*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, idp)
+	ret0, _ := ret[0].(*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdentityProvidersClientMockRecorder) Create(ctx, clusterId, idp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdentityProvidersClient)(nil).Create), ctx, clusterId, idp)
+}
+
+// Delete mocks base method.
+func (m *MockIdentityProvidersClient) Delete(ctx context.Context, clusterId, idpId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, idpId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdentityProvidersClientMockRecorder) Delete(ctx, clusterId, idpId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdentityProvidersClient)(nil).Delete), ctx, clusterId, idpId)
+}
+
+// DeleteHTPasswdUser mocks base method.
+func (m *MockIdentityProvidersClient) DeleteHTPasswdUser(ctx context.Context, clusterId, idpId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHTPasswdUser", ctx, clusterId, idpId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHTPasswdUser indicates an expected call of DeleteHTPasswdUser.
+func (mr *MockIdentityProvidersClientMockRecorder) DeleteHTPasswdUser(ctx, clusterId, idpId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHTPasswdUser", reflect.TypeOf((*MockIdentityProvidersClient)(nil).DeleteHTPasswdUser), ctx, clusterId, idpId, userId)
+}
+
+// Exists mocks base method.
+func (m *MockIdentityProvidersClient) Exists(ctx context.Context, clusterId, idpId string) (bool, *v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, idpId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.IdentityProvider)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockIdentityProvidersClientMockRecorder) Exists(ctx, clusterId, idpId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockIdentityProvidersClient)(nil).Exists), ctx, clusterId, idpId)
+}
+
+// Get mocks base method.
+func (m *MockIdentityProvidersClient) Get(ctx context.Context, clusterId, idpId string) (*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, idpId)
+	ret0, _ := ret[0].(*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdentityProvidersClientMockRecorder) Get(ctx, clusterId, idpId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdentityProvidersClient)(nil).Get), ctx, clusterId, idpId)
+}
+
+// List mocks base method.
+func (m *MockIdentityProvidersClient) List(ctx context.Context, clusterId string) ([]*v1.IdentityProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.IdentityProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIdentityProvidersClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIdentityProvidersClient)(nil).List), ctx, clusterId)
+}
+
+// ListHTPasswdUsers mocks base method.
+func (m *MockIdentityProvidersClient) ListHTPasswdUsers(ctx context.Context, clusterId, idpId string) ([]*v1.HTPasswdUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTPasswdUsers", ctx, clusterId, idpId)
+	ret0, _ := ret[0].([]*v1.HTPasswdUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHTPasswdUsers indicates an expected call of ListHTPasswdUsers.
+func (mr *MockIdentityProvidersClientMockRecorder) ListHTPasswdUsers(ctx, clusterId, idpId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTPasswdUsers", reflect.TypeOf((*MockIdentityProvidersClient)(nil).ListHTPasswdUsers), ctx, clusterId, idpId)
+}
+
+// UpdateHTPasswdUser mocks base method.
+func (m *MockIdentityProvidersClient) UpdateHTPasswdUser(ctx context.Context, clusterId, idpId, userId string, user *v1.HTPasswdUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHTPasswdUser", ctx, clusterId, idpId, userId, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHTPasswdUser indicates an expected call of UpdateHTPasswdUser.
+func (mr *MockIdentityProvidersClientMockRecorder) UpdateHTPasswdUser(ctx, clusterId, idpId, userId, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHTPasswdUser", reflect.TypeOf((*MockIdentityProvidersClient)(nil).UpdateHTPasswdUser), ctx, clusterId, idpId, userId, user)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingresses_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingresses_client.go -package=common -destination=mock_ingressesclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIngressesClient is a mock of IngressesClient interface.
+type MockIngressesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngressesClientMockRecorder
+}
+
+// MockIngressesClientMockRecorder is the mock recorder for MockIngressesClient.
+type MockIngressesClientMockRecorder struct {
+	mock *MockIngressesClient
+}
+
+// NewMockIngressesClient creates a new mock instance.
+func NewMockIngressesClient(ctrl *gomock.Controller) *MockIngressesClient {
+	mock := &MockIngressesClient{ctrl: ctrl}
+	mock.recorder = &MockIngressesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngressesClient) EXPECT() *MockIngressesClientMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockIngressesClient) Exists(ctx context.Context, clusterId, ingressId string) (bool, *v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, ingressId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.Ingress)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockIngressesClientMockRecorder) Exists(ctx, clusterId, ingressId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockIngressesClient)(nil).Exists), ctx, clusterId, ingressId)
+}
+
+// Get mocks base method.
+func (m *MockIngressesClient) Get(ctx context.Context, clusterId, ingressId string) (*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, ingressId)
+	ret0, _ := ret[0].(*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngressesClientMockRecorder) Get(ctx, clusterId, ingressId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngressesClient)(nil).Get), ctx, clusterId, ingressId)
+}
+
+// List mocks base method.
+func (m *MockIngressesClient) List(ctx context.Context, clusterId string) ([]*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockIngressesClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIngressesClient)(nil).List), ctx, clusterId)
+}
+
+// Update mocks base method.
+func (m *MockIngressesClient) Update(ctx context.Context, clusterId, ingressId string, ingress *v1.Ingress) (*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, ingressId, ingress)
+	ret0, _ := ret[0].(*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIngressesClientMockRecorder) Update(ctx, clusterId, ingressId, ingress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIngressesClient)(nil).Update), ctx, clusterId, ingressId, ingress)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: machine_pools_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=machine_pools_client.go -package=common -destination=mock_machinepoolsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMachinePoolsClient is a mock of MachinePoolsClient interface.
+type MockMachinePoolsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachinePoolsClientMockRecorder
+}
+
+// MockMachinePoolsClientMockRecorder is the mock recorder for MockMachinePoolsClient.
+type MockMachinePoolsClientMockRecorder struct {
+	mock *MockMachinePoolsClient
+}
+
+// NewMockMachinePoolsClient creates a new mock instance.
+func NewMockMachinePoolsClient(ctrl *gomock.Controller) *MockMachinePoolsClient {
+	mock := &MockMachinePoolsClient{ctrl: ctrl}
+	mock.recorder = &MockMachinePoolsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachinePoolsClient) EXPECT() *MockMachinePoolsClientMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockMachinePoolsClient) Count(ctx context.Context, clusterId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, clusterId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockMachinePoolsClientMockRecorder) Count(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockMachinePoolsClient)(nil).Count), ctx, clusterId)
+}
+
+// Create mocks base method.
+func (m *MockMachinePoolsClient) Create(ctx context.Context, clusterId string, machinePool *v1.MachinePool) (*v1.MachinePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, machinePool)
+	ret0, _ := ret[0].(*v1.MachinePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMachinePoolsClientMockRecorder) Create(ctx, clusterId, machinePool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMachinePoolsClient)(nil).Create), ctx, clusterId, machinePool)
+}
+
+// Delete mocks base method.
+func (m *MockMachinePoolsClient) Delete(ctx context.Context, clusterId, machinePoolId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, machinePoolId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMachinePoolsClientMockRecorder) Delete(ctx, clusterId, machinePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMachinePoolsClient)(nil).Delete), ctx, clusterId, machinePoolId)
+}
+
+// Exists mocks base method.
+func (m *MockMachinePoolsClient) Exists(ctx context.Context, clusterId, machinePoolId string) (bool, *v1.MachinePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, machinePoolId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.MachinePool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockMachinePoolsClientMockRecorder) Exists(ctx, clusterId, machinePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockMachinePoolsClient)(nil).Exists), ctx, clusterId, machinePoolId)
+}
+
+// Get mocks base method.
+func (m *MockMachinePoolsClient) Get(ctx context.Context, clusterId, machinePoolId string) (*v1.MachinePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, machinePoolId)
+	ret0, _ := ret[0].(*v1.MachinePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMachinePoolsClientMockRecorder) Get(ctx, clusterId, machinePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMachinePoolsClient)(nil).Get), ctx, clusterId, machinePoolId)
+}
+
+// List mocks base method.
+func (m *MockMachinePoolsClient) List(ctx context.Context, clusterId string) ([]*v1.MachinePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.MachinePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMachinePoolsClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMachinePoolsClient)(nil).List), ctx, clusterId)
+}
+
+// Update mocks base method.
+func (m *MockMachinePoolsClient) Update(ctx context.Context, clusterId string, machinePool *v1.MachinePool) (*v1.MachinePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, machinePool)
+	ret0, _ := ret[0].(*v1.MachinePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMachinePoolsClientMockRecorder) Update(ctx, clusterId, machinePool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMachinePoolsClient)(nil).Update), ctx, clusterId, machinePool)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: node_pools_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=node_pools_client.go -package=common -destination=mock_nodepoolsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNodePoolsClient is a mock of NodePoolsClient interface.
+type MockNodePoolsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodePoolsClientMockRecorder
+}
+
+// MockNodePoolsClientMockRecorder is the mock recorder for MockNodePoolsClient.
+type MockNodePoolsClientMockRecorder struct {
+	mock *MockNodePoolsClient
+}
+
+// NewMockNodePoolsClient creates a new mock instance.
+func NewMockNodePoolsClient(ctrl *gomock.Controller) *MockNodePoolsClient {
+	mock := &MockNodePoolsClient{ctrl: ctrl}
+	mock.recorder = &MockNodePoolsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodePoolsClient) EXPECT() *MockNodePoolsClientMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockNodePoolsClient) Count(ctx context.Context, clusterId string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, clusterId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockNodePoolsClientMockRecorder) Count(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockNodePoolsClient)(nil).Count), ctx, clusterId)
+}
+
+// Create mocks base method.
+func (m *MockNodePoolsClient) Create(ctx context.Context, clusterId string, nodePool *v1.NodePool) (*v1.NodePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, nodePool)
+	ret0, _ := ret[0].(*v1.NodePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNodePoolsClientMockRecorder) Create(ctx, clusterId, nodePool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNodePoolsClient)(nil).Create), ctx, clusterId, nodePool)
+}
+
+// Delete mocks base method.
+func (m *MockNodePoolsClient) Delete(ctx context.Context, clusterId, nodePoolId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, nodePoolId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNodePoolsClientMockRecorder) Delete(ctx, clusterId, nodePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNodePoolsClient)(nil).Delete), ctx, clusterId, nodePoolId)
+}
+
+// Exists mocks base method.
+func (m *MockNodePoolsClient) Exists(ctx context.Context, clusterId, nodePoolId string) (bool, *v1.NodePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, clusterId, nodePoolId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.NodePool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockNodePoolsClientMockRecorder) Exists(ctx, clusterId, nodePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockNodePoolsClient)(nil).Exists), ctx, clusterId, nodePoolId)
+}
+
+// Get mocks base method.
+func (m *MockNodePoolsClient) Get(ctx context.Context, clusterId, nodePoolId string) (*v1.NodePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, clusterId, nodePoolId)
+	ret0, _ := ret[0].(*v1.NodePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNodePoolsClientMockRecorder) Get(ctx, clusterId, nodePoolId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNodePoolsClient)(nil).Get), ctx, clusterId, nodePoolId)
+}
+
+// Update mocks base method.
+func (m *MockNodePoolsClient) Update(ctx context.Context, clusterId string, nodePool *v1.NodePool) (*v1.NodePool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, clusterId, nodePool)
+	ret0, _ := ret[0].(*v1.NodePool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNodePoolsClientMockRecorder) Update(ctx, clusterId, nodePool any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNodePoolsClient)(nil).Update), ctx, clusterId, nodePool)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: oidc_configs_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=oidc_configs_client.go -package=common -destination=mock_oidcconfigsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOidcConfigsClient is a mock of OidcConfigsClient interface.
+type MockOidcConfigsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockOidcConfigsClientMockRecorder
+}
+
+// MockOidcConfigsClientMockRecorder is the mock recorder for MockOidcConfigsClient.
+type MockOidcConfigsClientMockRecorder struct {
+	mock *MockOidcConfigsClient
+}
+
+// NewMockOidcConfigsClient creates a new mock instance.
+func NewMockOidcConfigsClient(ctrl *gomock.Controller) *MockOidcConfigsClient {
+	mock := &MockOidcConfigsClient{ctrl: ctrl}
+	mock.recorder = &MockOidcConfigsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOidcConfigsClient) EXPECT() *MockOidcConfigsClientMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOidcConfigsClient) Create(ctx context.Context, oidcConfig *v1.OidcConfig) (*v1.OidcConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, oidcConfig)
+	ret0, _ := ret[0].(*v1.OidcConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOidcConfigsClientMockRecorder) Create(ctx, oidcConfig any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOidcConfigsClient)(nil).Create), ctx, oidcConfig)
+}
+
+// Delete mocks base method.
+func (m *MockOidcConfigsClient) Delete(ctx context.Context, oidcConfigId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, oidcConfigId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOidcConfigsClientMockRecorder) Delete(ctx, oidcConfigId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOidcConfigsClient)(nil).Delete), ctx, oidcConfigId)
+}
+
+// Exists mocks base method.
+func (m *MockOidcConfigsClient) Exists(ctx context.Context, oidcConfigId string) (bool, *v1.OidcConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, oidcConfigId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*v1.OidcConfig)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockOidcConfigsClientMockRecorder) Exists(ctx, oidcConfigId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockOidcConfigsClient)(nil).Exists), ctx, oidcConfigId)
+}
+
+// Get mocks base method.
+func (m *MockOidcConfigsClient) Get(ctx context.Context, oidcConfigId string) (*v1.OidcConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, oidcConfigId)
+	ret0, _ := ret[0].(*v1.OidcConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOidcConfigsClientMockRecorder) Get(ctx, oidcConfigId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOidcConfigsClient)(nil).Get), ctx, oidcConfigId)
+}
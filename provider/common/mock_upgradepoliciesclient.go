@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: upgrade_policies_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=upgrade_policies_client.go -package=common -destination=mock_upgradepoliciesclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUpgradePoliciesClient is a mock of UpgradePoliciesClient interface.
+type MockUpgradePoliciesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpgradePoliciesClientMockRecorder
+}
+
+// MockUpgradePoliciesClientMockRecorder is the mock recorder for MockUpgradePoliciesClient.
+type MockUpgradePoliciesClientMockRecorder struct {
+	mock *MockUpgradePoliciesClient
+}
+
+// NewMockUpgradePoliciesClient creates a new mock instance.
+func NewMockUpgradePoliciesClient(ctrl *gomock.Controller) *MockUpgradePoliciesClient {
+	mock := &MockUpgradePoliciesClient{ctrl: ctrl}
+	mock.recorder = &MockUpgradePoliciesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpgradePoliciesClient) EXPECT() *MockUpgradePoliciesClientMockRecorder {
+	return m.recorder
+}
+
+// AckVersionGate mocks base method.
+func (m *MockUpgradePoliciesClient) AckVersionGate(ctx context.Context, clusterId, gateId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AckVersionGate", ctx, clusterId, gateId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckVersionGate indicates an expected call of AckVersionGate.
+func (mr *MockUpgradePoliciesClientMockRecorder) AckVersionGate(ctx, clusterId, gateId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckVersionGate", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).AckVersionGate), ctx, clusterId, gateId)
+}
+
+// Create mocks base method.
+func (m *MockUpgradePoliciesClient) Create(ctx context.Context, clusterId string, policy *v1.UpgradePolicy) (*v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, clusterId, policy)
+	ret0, _ := ret[0].(*v1.UpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUpgradePoliciesClientMockRecorder) Create(ctx, clusterId, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).Create), ctx, clusterId, policy)
+}
+
+// Delete mocks base method.
+func (m *MockUpgradePoliciesClient) Delete(ctx context.Context, clusterId, policyId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, clusterId, policyId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUpgradePoliciesClientMockRecorder) Delete(ctx, clusterId, policyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).Delete), ctx, clusterId, policyId)
+}
+
+// GetState mocks base method.
+func (m *MockUpgradePoliciesClient) GetState(ctx context.Context, clusterId, policyId string) (*v1.UpgradePolicyState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetState", ctx, clusterId, policyId)
+	ret0, _ := ret[0].(*v1.UpgradePolicyState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetState indicates an expected call of GetState.
+func (mr *MockUpgradePoliciesClientMockRecorder) GetState(ctx, clusterId, policyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetState", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).GetState), ctx, clusterId, policyId)
+}
+
+// List mocks base method.
+func (m *MockUpgradePoliciesClient) List(ctx context.Context, clusterId string) ([]*v1.UpgradePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, clusterId)
+	ret0, _ := ret[0].([]*v1.UpgradePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUpgradePoliciesClientMockRecorder) List(ctx, clusterId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).List), ctx, clusterId)
+}
+
+// MissingGateAgreements mocks base method.
+func (m *MockUpgradePoliciesClient) MissingGateAgreements(ctx context.Context, clusterId string, policy *v1.UpgradePolicy) ([]*v1.VersionGate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MissingGateAgreements", ctx, clusterId, policy)
+	ret0, _ := ret[0].([]*v1.VersionGate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MissingGateAgreements indicates an expected call of MissingGateAgreements.
+func (mr *MockUpgradePoliciesClientMockRecorder) MissingGateAgreements(ctx, clusterId, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MissingGateAgreements", reflect.TypeOf((*MockUpgradePoliciesClient)(nil).MissingGateAgreements), ctx, clusterId, policy)
+}
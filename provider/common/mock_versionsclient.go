@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: versions_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=versions_client.go -package=common -destination=mock_versionsclient.go
+//
+
+// Package common is a generated GoMock package.
+package common
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVersionsClient is a mock of VersionsClient interface.
+type MockVersionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionsClientMockRecorder
+}
+
+// MockVersionsClientMockRecorder is the mock recorder for MockVersionsClient.
+type MockVersionsClientMockRecorder struct {
+	mock *MockVersionsClient
+}
+
+// NewMockVersionsClient creates a new mock instance.
+func NewMockVersionsClient(ctrl *gomock.Controller) *MockVersionsClient {
+	mock := &MockVersionsClient{ctrl: ctrl}
+	mock.recorder = &MockVersionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionsClient) EXPECT() *MockVersionsClientMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockVersionsClient) Get(ctx context.Context, versionId string) (*v1.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, versionId)
+	ret0, _ := ret[0].(*v1.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVersionsClientMockRecorder) Get(ctx, versionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVersionsClient)(nil).Get), ctx, versionId)
+}
+
+// List mocks base method.
+func (m *MockVersionsClient) List(ctx context.Context, search string) ([]*v1.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, search)
+	ret0, _ := ret[0].([]*v1.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockVersionsClientMockRecorder) List(ctx, search any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockVersionsClient)(nil).List), ctx, search)
+}
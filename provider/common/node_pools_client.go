@@ -0,0 +1,89 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// NodePoolsClient manages the node pools of the hosted control plane clusters. The pools returned by the
+// client only contain the tags set by the user, not the ones added by the service.
+//
+//go:generate mockgen -source=node_pools_client.go -package=common -destination=mock_nodepoolsclient.go
+type NodePoolsClient interface {
+	Get(ctx context.Context, clusterId string, nodePoolId string) (*cmv1.NodePool, error)
+	Exists(ctx context.Context, clusterId string, nodePoolId string) (bool, *cmv1.NodePool, error)
+	Create(ctx context.Context, clusterId string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error)
+	Update(ctx context.Context, clusterId string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error)
+	Delete(ctx context.Context, clusterId string, nodePoolId string) error
+	Count(ctx context.Context, clusterId string) (int, error)
+}
+
+type DefaultNodePoolsClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewNodePoolsClient(client *cmv1.ClustersClient) NodePoolsClient {
+	return &DefaultNodePoolsClient{client: client}
+}
+
+func (c *DefaultNodePoolsClient) collection(clusterId string) *cmv1.NodePoolsClient {
+	return c.client.Cluster(clusterId).NodePools()
+}
+
+func (c *DefaultNodePoolsClient) Get(ctx context.Context, clusterId string, nodePoolId string) (*cmv1.NodePool, error) {
+	resp, err := c.collection(clusterId).NodePool(nodePoolId).Get().
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultNodePoolsClient) Exists(ctx context.Context, clusterId string, nodePoolId string) (bool, *cmv1.NodePool, error) {
+	resp, err := c.collection(clusterId).NodePool(nodePoolId).Get().
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultNodePoolsClient) Create(ctx context.Context, clusterId string,
+	nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
+	resp, err := c.collection(clusterId).Add().Body(nodePool).
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+// Update sends the changes of the pool, which must contain at least the identifier of the pool.
+func (c *DefaultNodePoolsClient) Update(ctx context.Context, clusterId string,
+	nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
+	resp, err := c.collection(clusterId).NodePool(nodePool.ID()).Update().Body(nodePool).
+		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultNodePoolsClient) Delete(ctx context.Context, clusterId string, nodePoolId string) error {
+	_, err := c.collection(clusterId).NodePool(nodePoolId).Delete().SendContext(ctx)
+	return err
+}
+
+// Count returns the number of node pools of the cluster.
+func (c *DefaultNodePoolsClient) Count(ctx context.Context, clusterId string) (int, error) {
+	resp, err := c.collection(clusterId).List().SendContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Size(), nil
+}
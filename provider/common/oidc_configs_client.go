@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// OidcConfigsClient manages the OIDC configurations used by the STS clusters.
+//
+//go:generate mockgen -source=oidc_configs_client.go -package=common -destination=mock_oidcconfigsclient.go
+type OidcConfigsClient interface {
+	Get(ctx context.Context, oidcConfigId string) (*cmv1.OidcConfig, error)
+	Exists(ctx context.Context, oidcConfigId string) (bool, *cmv1.OidcConfig, error)
+	Create(ctx context.Context, oidcConfig *cmv1.OidcConfig) (*cmv1.OidcConfig, error)
+	Delete(ctx context.Context, oidcConfigId string) error
+}
+
+type DefaultOidcConfigsClient struct {
+	client *cmv1.OidcConfigsClient
+}
+
+func NewOidcConfigsClient(client *cmv1.OidcConfigsClient) OidcConfigsClient {
+	return &DefaultOidcConfigsClient{client: client}
+}
+
+func (c *DefaultOidcConfigsClient) Get(ctx context.Context, oidcConfigId string) (*cmv1.OidcConfig, error) {
+	resp, err := c.client.OidcConfig(oidcConfigId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultOidcConfigsClient) Exists(ctx context.Context, oidcConfigId string) (bool, *cmv1.OidcConfig, error) {
+	resp, err := c.client.OidcConfig(oidcConfigId).Get().SendContext(ctx)
+	if err != nil {
+		if resp.Status() == http.StatusNotFound {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, resp.Body(), nil
+}
+
+func (c *DefaultOidcConfigsClient) Create(ctx context.Context, oidcConfig *cmv1.OidcConfig) (*cmv1.OidcConfig, error) {
+	resp, err := c.client.Add().Body(oidcConfig).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultOidcConfigsClient) Delete(ctx context.Context, oidcConfigId string) error {
+	_, err := c.client.OidcConfig(oidcConfigId).Delete().SendContext(ctx)
+	return err
+}
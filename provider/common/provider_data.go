@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// ProviderData is what the provider passes to the resources and data sources when they are
+// configured. It contains the connection to OCM and the provider wide settings that they share.
+type ProviderData struct {
+	// Connection is shared by all the resources and data sources, and so are the cache, the retry
+	// policy and the rate limits applied by its transport.
+	Connection *sdk.Connection
+
+	// HttpClient is used for the requests sent to services other than OCM, and honors the
+	// outbound proxy configuration of the provider.
+	HttpClient HttpClient
+
+	// DefaultTags are merged into the AWS tags of the clusters and machine pools. Tags set in the
+	// resources take precedence.
+	DefaultTags map[string]string
+
+	// ClusterWaitInterval is the time between the requests sent to check a cluster while waiting
+	// for it. When it is zero DefaultClusterWaitInterval is used.
+	ClusterWaitInterval time.Duration
+
+	// ClusterReadiness is shared by all the cluster waiters, so that each cluster is polled only
+	// once while it isn't ready, and not polled anymore once it is.
+	ClusterReadiness *ClusterReadiness
+}
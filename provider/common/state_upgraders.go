@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// StateUpgrader converts the JSON state written with one version of the schema of a resource to
+// the layout of the next version. It modifies the state in place.
+type StateUpgrader func(ctx context.Context, state map[string]interface{}) error
+
+// StateUpgraders builds the upgraders of a resource from a chain where the first upgrader converts
+// version 0 of the state to version 1, the second version 1 to version 2, and so on, so the schema
+// version of the resource must be the length of the chain. Version 0 is the state written by the
+// releases that didn't version the schema. The state of each prior version goes through the rest
+// of the chain and is then decoded with the current schema, ignoring the attributes that no longer
+// exist and leaving null the ones that didn't exist yet. So a version that only added or removed
+// attributes doesn't need to convert anything, and its upgrader is UnchangedState.
+//
+// When the layout of the state of a resource changes, add an upgrader at the end of its chain, and
+// never modify or remove the existing ones, as states of every prior version may still exist.
+func StateUpgraders(chain ...StateUpgrader) map[int64]resource.StateUpgrader {
+	result := map[int64]resource.StateUpgrader{}
+	for i := range chain {
+		remaining := chain[i:]
+		result[int64(i)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest,
+				resp *resource.UpgradeStateResponse) {
+				err := upgradeState(ctx, remaining, req, resp)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Can't upgrade state",
+						fmt.Sprintf("Can't upgrade state from version %d: %v", len(chain)-len(remaining), err),
+					)
+				}
+			},
+		}
+	}
+	return result
+}
+
+func upgradeState(ctx context.Context, chain []StateUpgrader, req resource.UpgradeStateRequest,
+	resp *resource.UpgradeStateResponse) error {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		return fmt.Errorf("state isn't in JSON format")
+	}
+	state := map[string]interface{}{}
+	err := json.Unmarshal(req.RawState.JSON, &state)
+	if err != nil {
+		return err
+	}
+	for _, upgrader := range chain {
+		err = upgrader(ctx, state)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	value, err := tftypes.ValueFromJSONWithOpts(data, resp.State.Schema.Type().TerraformType(ctx),
+		tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+	if err != nil {
+		return err
+	}
+	resp.State.Raw = value
+	return nil
+}
+
+// UnchangedState is the upgrader of the versions of a state that have the same layout than the
+// next one, except for added or removed attributes.
+func UnchangedState(ctx context.Context, state map[string]interface{}) error {
+	return nil
+}
+
+// CopyStateAttributeIfNull copies the value of an attribute of a JSON state to another attribute
+// when the latter is missing or null. It is intended for attributes added later that, for the
+// states written before, have the same value than an existing attribute.
+func CopyStateAttributeIfNull(state map[string]interface{}, from, to string) {
+	if state[to] == nil {
+		state[to] = state[from]
+	}
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("State upgraders", func() {
+	type testState struct {
+		Name     types.String `tfsdk:"name"`
+		Size     types.Int64  `tfsdk:"size"`
+		Replicas types.Int64  `tfsdk:"replicas"`
+	}
+
+	testSchema := schema.Schema{
+		Version: 2,
+		Attributes: map[string]schema.Attribute{
+			"name":     schema.StringAttribute{Required: true},
+			"size":     schema.Int64Attribute{Optional: true},
+			"replicas": schema.Int64Attribute{Optional: true},
+		},
+	}
+
+	// The first version had the size in the `old_size` attribute, the second version had the
+	// `name` in upper case:
+	renameSize := func(ctx context.Context, state map[string]interface{}) error {
+		state["size"] = state["old_size"]
+		delete(state, "old_size")
+		return nil
+	}
+	lowerName := func(ctx context.Context, state map[string]interface{}) error {
+		state["name"] = "lower"
+		return nil
+	}
+
+	upgrade := func(upgraders map[int64]resource.StateUpgrader, version int64, json string) (*testState, *resource.UpgradeStateResponse) {
+		ctx := context.Background()
+		upgrader, ok := upgraders[version]
+		Expect(ok).To(BeTrue())
+		resp := &resource.UpgradeStateResponse{
+			State: tfsdk.State{Schema: testSchema},
+		}
+		upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{
+			RawState: &tfprotov6.RawState{JSON: []byte(json)},
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, resp
+		}
+		state := &testState{}
+		Expect(resp.State.Get(ctx, state).HasError()).To(BeFalse())
+		return state, resp
+	}
+
+	It("Runs the rest of the chain for each prior version", func() {
+		upgraders := StateUpgraders(renameSize, lowerName)
+		Expect(upgraders).To(HaveLen(2))
+
+		state, _ := upgrade(upgraders, 0, `{"name": "UPPER", "old_size": 3}`)
+		Expect(state.Name.ValueString()).To(Equal("lower"))
+		Expect(state.Size.ValueInt64()).To(BeEquivalentTo(3))
+
+		state, _ = upgrade(upgraders, 1, `{"name": "UPPER", "size": 4}`)
+		Expect(state.Name.ValueString()).To(Equal("lower"))
+		Expect(state.Size.ValueInt64()).To(BeEquivalentTo(4))
+	})
+
+	It("Drops the removed attributes and leaves null the new ones", func() {
+		upgraders := StateUpgraders(lowerName)
+
+		state, _ := upgrade(upgraders, 0, `{"name": "UPPER", "size": 5, "removed": true}`)
+		Expect(state.Size.ValueInt64()).To(BeEquivalentTo(5))
+		Expect(state.Replicas.IsNull()).To(BeTrue())
+	})
+
+	It("Reports the errors of the upgraders", func() {
+		upgraders := StateUpgraders(func(ctx context.Context, state map[string]interface{}) error {
+			return errors.New("boom")
+		})
+
+		_, resp := upgrade(upgraders, 0, `{"name": "UPPER"}`)
+		Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		Expect(resp.Diagnostics[0].Detail()).To(Equal("Can't upgrade state from version 0: boom"))
+	})
+
+	It("Copies an attribute when the target is missing or null", func() {
+		state := map[string]interface{}{"tags": map[string]interface{}{"a": "b"}}
+		CopyStateAttributeIfNull(state, "tags", "tags_all")
+		Expect(state["tags_all"]).To(Equal(map[string]interface{}{"a": "b"}))
+
+		state = map[string]interface{}{"tags": "x", "tags_all": "y"}
+		CopyStateAttributeIfNull(state, "tags", "tags_all")
+		Expect(state["tags_all"]).To(Equal("y"))
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const TagsAllDescription = "All the tags applied to the AWS resources, including the `default_tags` of the provider. " +
+	"Tags set in the resource take precedence over the default tags."
+
+// MergeStringMaps returns a new map containing the defaults and the values, the values take
+// precedence when a key is in both maps. The result is nil when both maps are empty.
+func MergeStringMaps(defaults, values map[string]string) map[string]string {
+	if len(defaults) == 0 && len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(defaults)+len(values))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range values {
+		result[k] = v
+	}
+	return result
+}
+
+// MergeDefaultTags calculates the effective tags of a resource from the default tags of the
+// provider and the tags set in the resource. The result is unknown when the resource tags aren't
+// known yet and null when there are no tags at all.
+func MergeDefaultTags(ctx context.Context, defaultTags map[string]string, tags types.Map) (types.Map, error) {
+	if tags.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+	for _, v := range tags.Elements() {
+		if v.IsUnknown() {
+			return types.MapUnknown(types.StringType), nil
+		}
+	}
+	tagsMap, err := OptionalMap(ctx, tags)
+	if err != nil {
+		return types.MapNull(types.StringType), err
+	}
+	merged := MergeStringMaps(defaultTags, tagsMap)
+	if merged == nil {
+		return types.MapNull(types.StringType), nil
+	}
+	return ConvertStringMapToMapType(merged)
+}
+
+// ModifyPlanTagsAll sets in the plan the effective tags of a resource, calculated from the
+// default tags of the provider and the tags at the given path. AWS tags can't be changed after
+// the resource is created, so when only the default tags changed the current value is kept and a
+// warning is reported instead.
+func ModifyPlanTagsAll(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+	defaultTags map[string]string, tagsPath, tagsAllPath path.Path, kind string) {
+	// Nothing to calculate when the resource is being destroyed:
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, tagsPath, &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll, err := MergeDefaultTags(ctx, defaultTags, tags)
+	if err != nil {
+		resp.Diagnostics.AddError("Can't merge the default tags", err.Error())
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var stateTags, stateTagsAll types.Map
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsPath, &stateTags)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tagsAllPath, &stateTagsAll)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// Imported resources don't have the merged tags, because it isn't possible to tell which
+		// of their tags came from the default tags of the provider:
+		if stateTagsAll.IsNull() && stateTags.Equal(tags) {
+			tagsAll = stateTagsAll
+		}
+		if stateTags.Equal(tags) && !tagsAll.IsUnknown() && !stateTagsAll.Equal(tagsAll) {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Default tags can't be applied to an existing %s", kind),
+				fmt.Sprintf(
+					"The AWS tags of the %s are %v but with the default tags of the provider "+
+						"they would be %v. The tags can't be changed after the %s is created.",
+					kind, stateTagsAll, tagsAll, kind,
+				),
+			)
+			tagsAll = stateTagsAll
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAllPath, tagsAll)...)
+}
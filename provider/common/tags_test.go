@@ -0,0 +1,109 @@
+package common
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Default tags", func() {
+	ctx := context.Background()
+	defaults := map[string]string{
+		"cost-center": "1234",
+		"owner":       "team-a",
+	}
+
+	Context("MergeStringMaps", func() {
+		It("Gives precedence to the values", func() {
+			merged := MergeStringMaps(defaults, map[string]string{"owner": "team-b", "env": "prod"})
+			Expect(merged).To(Equal(map[string]string{
+				"cost-center": "1234",
+				"owner":       "team-b",
+				"env":         "prod",
+			}))
+		})
+		It("Doesn't modify the input maps", func() {
+			values := map[string]string{"env": "prod"}
+			MergeStringMaps(defaults, values)
+			Expect(defaults).To(HaveLen(2))
+			Expect(values).To(HaveLen(1))
+		})
+		It("Returns nil when there is nothing to merge", func() {
+			Expect(MergeStringMaps(nil, map[string]string{})).To(BeNil())
+		})
+	})
+
+	Context("MergeDefaultTags", func() {
+		It("Merges the default tags into the resource tags", func() {
+			tags, _ := ConvertStringMapToMapType(map[string]string{"owner": "team-b"})
+			merged, err := MergeDefaultTags(ctx, defaults, tags)
+			Expect(err).ToNot(HaveOccurred())
+			expected, _ := ConvertStringMapToMapType(map[string]string{
+				"cost-center": "1234",
+				"owner":       "team-b",
+			})
+			Expect(merged).To(Equal(expected))
+		})
+		It("Uses the default tags when the resource has no tags", func() {
+			merged, err := MergeDefaultTags(ctx, defaults, types.MapNull(types.StringType))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(merged.Elements()).To(HaveLen(2))
+		})
+		It("Returns null when there are no tags", func() {
+			merged, err := MergeDefaultTags(ctx, nil, types.MapNull(types.StringType))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(merged.IsNull()).To(BeTrue())
+		})
+		It("Returns unknown when the resource tags aren't known", func() {
+			merged, err := MergeDefaultTags(ctx, defaults, types.MapUnknown(types.StringType))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(merged.IsUnknown()).To(BeTrue())
+		})
+	})
+
+	Context("ModifyPlanTagsAll", func() {
+		tagsSchema := schema.Schema{
+			Attributes: map[string]schema.Attribute{
+				"tags":     schema.MapAttribute{ElementType: types.StringType, Optional: true},
+				"tags_all": schema.MapAttribute{ElementType: types.StringType, Computed: true},
+			},
+		}
+		type tagsState struct {
+			Tags    types.Map `tfsdk:"tags"`
+			TagsAll types.Map `tfsdk:"tags_all"`
+		}
+		tags, _ := ConvertStringMapToMapType(map[string]string{"env": "prod"})
+
+		modifyPlan := func(state *tagsState) (types.Map, *resource.ModifyPlanResponse) {
+			req := resource.ModifyPlanRequest{
+				Plan:  tfsdk.Plan{Schema: tagsSchema},
+				State: tfsdk.State{Schema: tagsSchema},
+			}
+			Expect(req.Plan.Set(ctx, &tagsState{Tags: tags, TagsAll: types.MapUnknown(types.StringType)}).HasError()).To(BeFalse())
+			Expect(req.State.Set(ctx, state).HasError()).To(BeFalse())
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			ModifyPlanTagsAll(ctx, req, resp, defaults, path.Root("tags"), path.Root("tags_all"), "machine pool")
+			var tagsAll types.Map
+			Expect(resp.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll).HasError()).To(BeFalse())
+			return tagsAll, resp
+		}
+
+		It("Keeps the current tags and warns when only the default tags changed", func() {
+			tagsAll, resp := modifyPlan(&tagsState{Tags: tags, TagsAll: tags})
+			Expect(tagsAll).To(Equal(tags))
+			Expect(resp.Diagnostics.WarningsCount()).To(Equal(1))
+		})
+
+		It("Doesn't warn about imported resources without the merged tags", func() {
+			tagsAll, resp := modifyPlan(&tagsState{Tags: tags, TagsAll: types.MapNull(types.StringType)})
+			Expect(tagsAll.IsNull()).To(BeTrue())
+			Expect(resp.Diagnostics).To(BeEmpty())
+		})
+	})
+})
@@ -71,6 +71,18 @@ func StringListToArray(ctx context.Context, tfVal types.List) ([]string, error)
 	return result, nil
 }
 
+func StringSetToArray(ctx context.Context, tfVal types.Set) ([]string, error) {
+	if !HasValue(tfVal) {
+		return nil, nil
+	}
+	result := make([]string, len(tfVal.Elements()))
+	d := tfVal.ElementsAs(ctx, &result, false)
+	if d.HasError() {
+		return nil, fmt.Errorf("error converting to set object %v", d.Errors()[0].Detail())
+	}
+	return result, nil
+}
+
 func ConvertStringMapToMapType(stringMap map[string]string) (types.Map, error) {
 	elements := map[string]attr.Value{}
 	for k, v := range stringMap {
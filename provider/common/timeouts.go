@@ -0,0 +1,147 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeout is the timeout of the create, update and delete operations of the resources that
+// wait for clusters, when neither the `timeouts` block nor a deprecated timeout attribute is set.
+const DefaultTimeout = 60 * time.Minute
+
+// TimeoutsDeprecationFormat is the deprecation message of the integer timeout attributes that
+// were replaced by an entry of the `timeouts` block.
+const TimeoutsDeprecationFormat = "Use the `%s` timeout of the `timeouts` block instead."
+
+var timeoutsOpts = timeouts.Opts{
+	Create:            true,
+	Update:            true,
+	Delete:            true,
+	CreateDescription: "Maximum duration of the creation, including the wait for the cluster to be ready, for example `90m`.",
+	UpdateDescription: "Maximum duration of the update, for example `30m`.",
+	DeleteDescription: "Maximum duration of the deletion, including the wait for the resource to be removed, for example `90m`.",
+}
+
+// TimeoutsBlock returns the `timeouts` block of the resources with long running create, update
+// and delete operations.
+func TimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeoutsOpts)
+}
+
+// CreateTimeoutsBlock returns the `timeouts` block of the resources that only wait for the cluster
+// when they are created.
+func CreateTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		CreateDescription: timeoutsOpts.CreateDescription,
+	})
+}
+
+// CreateUpdateTimeoutsBlock returns the `timeouts` block of the resources that wait for the cluster
+// when they are created or updated, but not when they are deleted.
+func CreateUpdateTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Update:            true,
+		CreateDescription: timeoutsOpts.CreateDescription,
+		UpdateDescription: timeoutsOpts.UpdateDescription,
+	})
+}
+
+// TimeoutsDataSourceAttribute returns the `timeouts` attribute of the data sources that share the
+// state of a resource with a `timeouts` block. It is always null, data sources don't wait.
+func TimeoutsDataSourceAttribute(ctx context.Context, description string) dsschema.Attribute {
+	return dsschema.SingleNestedAttribute{
+		Description: description,
+		Attributes: map[string]dsschema.Attribute{
+			"create": dsschema.StringAttribute{Computed: true},
+			"update": dsschema.StringAttribute{Computed: true},
+			"delete": dsschema.StringAttribute{Computed: true},
+		},
+		CustomType: timeoutsType(),
+		Computed:   true,
+	}
+}
+
+// NullTimeouts returns the null value of the `timeouts` block, for the states that aren't read
+// from a plan or a prior state.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(timeoutsType().AttrTypes),
+	}
+}
+
+func timeoutsType() timeouts.Type {
+	return timeouts.Type{
+		ObjectType: types.ObjectType{
+			AttrTypes: map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"delete": types.StringType,
+			},
+		},
+	}
+}
+
+// TimeoutFromMinutes returns the duration of a deprecated timeout attribute expressed in minutes,
+// or the given default when the attribute isn't set. It is intended to compute the default that
+// is passed to the methods of the `timeouts` block, so that the block takes precedence.
+func TimeoutFromMinutes(minutes types.Int64, defaultTimeout time.Duration) (time.Duration, error) {
+	if !HasValue(minutes) {
+		return defaultTimeout, nil
+	}
+	if minutes.ValueInt64() <= 0 {
+		return defaultTimeout, fmt.Errorf("timeout must be greater than 0 minutes")
+	}
+	return time.Duration(minutes.ValueInt64()) * time.Minute, nil
+}
+
+// TimeoutEntry is one of the Create, Update and Delete methods of the `timeouts` block, that return
+// the duration of the entry, or the given default when the entry isn't set.
+type TimeoutEntry func(ctx context.Context, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics)
+
+// TimeoutWithDeprecatedMinutes returns the duration of an entry of the `timeouts` block whose
+// default is a deprecated timeout attribute expressed in minutes, or the given default when neither
+// is set. The errors of the block are added to the diagnostics. A non positive deprecated attribute
+// is returned as an error, and then the entry falls back to the given default.
+func TimeoutWithDeprecatedMinutes(ctx context.Context, entry TimeoutEntry, minutes types.Int64,
+	defaultTimeout time.Duration, diags *diag.Diagnostics) (time.Duration, error) {
+	timeout, err := TimeoutFromMinutes(minutes, defaultTimeout)
+	timeout, entryDiags := entry(ctx, timeout)
+	diags.Append(entryDiags...)
+	return timeout, err
+}
+
+// WithUpdateTimeout returns a context bounded by the update timeout of the `timeouts` block. It is
+// intended for the updates that don't wait for the cluster, so the timeout applies to their
+// requests. When the block is invalid the error is added to the diagnostics and the context is
+// returned unchanged.
+func WithUpdateTimeout(ctx context.Context, value timeouts.Value,
+	diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, value.Update, diags)
+}
+
+// WithDeleteTimeout is the equivalent of WithUpdateTimeout for the deletions that don't wait for
+// the cluster.
+func WithDeleteTimeout(ctx context.Context, value timeouts.Value,
+	diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, value.Delete, diags)
+}
+
+func withTimeout(ctx context.Context, entry TimeoutEntry,
+	diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	timeout, entryDiags := entry(ctx, DefaultTimeout)
+	diags.Append(entryDiags...)
+	if entryDiags.HasError() {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
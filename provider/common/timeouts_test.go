@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2/dsl/core" // nolint
+	. "github.com/onsi/gomega"             // nolint
+)
+
+var _ = Describe("Timeouts", func() {
+	ctx := context.Background()
+
+	Context("TimeoutFromMinutes", func() {
+		It("Returns the default when the attribute isn't set", func() {
+			timeout, err := TimeoutFromMinutes(types.Int64Null(), DefaultTimeout)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(timeout).To(Equal(DefaultTimeout))
+		})
+		It("Converts the minutes of the attribute", func() {
+			timeout, err := TimeoutFromMinutes(types.Int64Value(90), DefaultTimeout)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(timeout).To(Equal(90 * time.Minute))
+		})
+		It("Fails with a non positive value and returns the default", func() {
+			timeout, err := TimeoutFromMinutes(types.Int64Value(0), DefaultTimeout)
+			Expect(err).To(HaveOccurred())
+			Expect(timeout).To(Equal(DefaultTimeout))
+		})
+	})
+
+	Context("NullTimeouts", func() {
+		It("Uses the defaults", func() {
+			value := NullTimeouts()
+			Expect(value.IsNull()).To(BeTrue())
+			timeout, diags := value.Delete(ctx, 45*time.Minute)
+			Expect(diags.HasError()).To(BeFalse())
+			Expect(timeout).To(Equal(45 * time.Minute))
+		})
+		It("Has the type of the block", func() {
+			block := TimeoutsBlock(ctx)
+			Expect(NullTimeouts().Type(ctx).Equal(block.Type())).To(BeTrue())
+		})
+	})
+
+	It("Gives precedence to the block over the deprecated attribute", func() {
+		value := timeouts.Value{
+			Object: types.ObjectValueMust(
+				NullTimeouts().AttributeTypes(ctx),
+				map[string]attr.Value{
+					"create": types.StringValue("2h"),
+					"update": types.StringNull(),
+					"delete": types.StringNull(),
+				},
+			),
+		}
+		diags := diag.Diagnostics{}
+		timeout, err := TimeoutWithDeprecatedMinutes(ctx, value.Create, types.Int64Value(30), DefaultTimeout, &diags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(timeout).To(Equal(2 * time.Hour))
+		timeout, err = TimeoutWithDeprecatedMinutes(ctx, value.Update, types.Int64Value(30), DefaultTimeout, &diags)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(timeout).To(Equal(30 * time.Minute))
+	})
+
+	It("Falls back to the default with a non positive deprecated attribute", func() {
+		diags := diag.Diagnostics{}
+		timeout, err := TimeoutWithDeprecatedMinutes(ctx, NullTimeouts().Delete, types.Int64Value(-1), DefaultTimeout, &diags)
+		Expect(err).To(HaveOccurred())
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(timeout).To(Equal(DefaultTimeout))
+	})
+
+	Context("WithUpdateTimeout", func() {
+		It("Bounds the context with the timeout of the block", func() {
+			value := timeouts.Value{
+				Object: types.ObjectValueMust(
+					NullTimeouts().AttributeTypes(ctx),
+					map[string]attr.Value{
+						"create": types.StringNull(),
+						"update": types.StringValue("5m"),
+						"delete": types.StringNull(),
+					},
+				),
+			}
+			diags := diag.Diagnostics{}
+			updateCtx, cancel := WithUpdateTimeout(ctx, value, &diags)
+			defer cancel()
+			Expect(diags.HasError()).To(BeFalse())
+			deadline, ok := updateCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(time.Until(deadline)).To(BeNumerically("~", 5*time.Minute, time.Second))
+		})
+		It("Reports an invalid timeout", func() {
+			value := timeouts.Value{
+				Object: types.ObjectValueMust(
+					NullTimeouts().AttributeTypes(ctx),
+					map[string]attr.Value{
+						"create": types.StringNull(),
+						"update": types.StringValue("soon"),
+						"delete": types.StringNull(),
+					},
+				),
+			}
+			diags := diag.Diagnostics{}
+			updateCtx, cancel := WithUpdateTimeout(ctx, value, &diags)
+			defer cancel()
+			Expect(diags.HasError()).To(BeTrue())
+			_, ok := updateCtx.Deadline()
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	It("Bounds the context with the default delete timeout", func() {
+		diags := diag.Diagnostics{}
+		deleteCtx, cancel := WithDeleteTimeout(ctx, NullTimeouts(), &diags)
+		defer cancel()
+		Expect(diags.HasError()).To(BeFalse())
+		deadline, ok := deleteCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(time.Until(deadline)).To(BeNumerically("~", DefaultTimeout, time.Second))
+	})
+})
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// UpgradePoliciesClient manages the upgrade policies of the classic clusters and the agreements
+// to the version gates that the upgrades require.
+//
+//go:generate mockgen -source=upgrade_policies_client.go -package=common -destination=mock_upgradepoliciesclient.go
+type UpgradePoliciesClient interface {
+	List(ctx context.Context, clusterId string) ([]*cmv1.UpgradePolicy, error)
+	GetState(ctx context.Context, clusterId string, policyId string) (*cmv1.UpgradePolicyState, error)
+	Create(ctx context.Context, clusterId string, policy *cmv1.UpgradePolicy) (*cmv1.UpgradePolicy, error)
+	Delete(ctx context.Context, clusterId string, policyId string) error
+	MissingGateAgreements(ctx context.Context, clusterId string, policy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error)
+	AckVersionGate(ctx context.Context, clusterId string, gateId string) error
+}
+
+type DefaultUpgradePoliciesClient struct {
+	client *cmv1.ClustersClient
+}
+
+func NewUpgradePoliciesClient(client *cmv1.ClustersClient) UpgradePoliciesClient {
+	return &DefaultUpgradePoliciesClient{client: client}
+}
+
+func (c *DefaultUpgradePoliciesClient) collection(clusterId string) *cmv1.UpgradePoliciesClient {
+	return c.client.Cluster(clusterId).UpgradePolicies()
+}
+
+func (c *DefaultUpgradePoliciesClient) List(ctx context.Context, clusterId string) ([]*cmv1.UpgradePolicy, error) {
+	result := []*cmv1.UpgradePolicy{}
+	page := 1
+	for {
+		resp, err := c.collection(clusterId).List().Page(page).Size(ListPageSize).SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
+
+func (c *DefaultUpgradePoliciesClient) GetState(ctx context.Context, clusterId string, policyId string) (*cmv1.UpgradePolicyState, error) {
+	resp, err := c.collection(clusterId).UpgradePolicy(policyId).State().Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultUpgradePoliciesClient) Create(ctx context.Context, clusterId string, policy *cmv1.UpgradePolicy) (*cmv1.UpgradePolicy, error) {
+	resp, err := c.collection(clusterId).Add().Body(policy).SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultUpgradePoliciesClient) Delete(ctx context.Context, clusterId string, policyId string) error {
+	_, err := c.collection(clusterId).UpgradePolicy(policyId).Delete().SendContext(ctx)
+	return err
+}
+
+// MissingGateAgreements creates the policy in dry run mode, which fails listing the version gates
+// that haven't been agreed yet, if any.
+func (c *DefaultUpgradePoliciesClient) MissingGateAgreements(ctx context.Context, clusterId string,
+	policy *cmv1.UpgradePolicy) ([]*cmv1.VersionGate, error) {
+	response, err := c.collection(clusterId).Add().Parameter("dryRun", true).Body(policy).SendContext(ctx)
+	if err != nil && response.Error() != nil {
+		// parse gates list
+		errorDetails, ok := response.Error().GetDetails()
+		if !ok {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		data, err := json.Marshal(errorDetails)
+		if err != nil {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		gates, err := cmv1.UnmarshalVersionGateList(data)
+		if err != nil {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		// return original error if invaild version gate detected
+		if len(gates) > 0 && gates[0].ID() == "" {
+			return []*cmv1.VersionGate{}, HandleErr(response.Error(), err)
+		}
+		return gates, nil
+	}
+	return []*cmv1.VersionGate{}, nil
+}
+
+func (c *DefaultUpgradePoliciesClient) AckVersionGate(ctx context.Context, clusterId string, gateId string) error {
+	return ackVersionGate(ctx, c.client, clusterId, gateId)
+}
+
+// ackVersionGate agrees to a version gate for the upgrades of a cluster, both for the upgrades of
+// the classic clusters and for the ones of the control plane of the hosted control plane clusters.
+func ackVersionGate(ctx context.Context, client *cmv1.ClustersClient, clusterId string, gateId string) error {
+	agreement, err := cmv1.NewVersionGateAgreement().
+		VersionGate(cmv1.NewVersionGate().ID(gateId)).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(clusterId).GateAgreements().Add().Body(agreement).SendContext(ctx)
+	if err != nil {
+		return HandleErr(response.Error(), err)
+	}
+	return nil
+}
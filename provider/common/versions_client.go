@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// VersionsClient reads the OpenShift versions offered by OCM.
+//
+//go:generate mockgen -source=versions_client.go -package=common -destination=mock_versionsclient.go
+type VersionsClient interface {
+	Get(ctx context.Context, versionId string) (*cmv1.Version, error)
+	List(ctx context.Context, search string) ([]*cmv1.Version, error)
+}
+
+type DefaultVersionsClient struct {
+	client *cmv1.VersionsClient
+}
+
+func NewVersionsClient(client *cmv1.VersionsClient) VersionsClient {
+	return &DefaultVersionsClient{client: client}
+}
+
+func (c *DefaultVersionsClient) Get(ctx context.Context, versionId string) (*cmv1.Version, error) {
+	resp, err := c.client.Version(versionId).Get().SendContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(), nil
+}
+
+func (c *DefaultVersionsClient) List(ctx context.Context, search string) ([]*cmv1.Version, error) {
+	result := []*cmv1.Version{}
+	page := 1
+	for {
+		request := c.client.List().Page(page).Size(ListPageSize)
+		if search != "" {
+			request.Search(search)
+		}
+		resp, err := request.SendContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resp.Items().Slice()...)
+		if resp.Size() < ListPageSize {
+			return result, nil
+		}
+		page++
+	}
+}
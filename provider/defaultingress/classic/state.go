@@ -1,6 +1,9 @@
 package classic
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type DefaultIngress struct {
 	Cluster                  types.String `tfsdk:"cluster"`
@@ -15,4 +18,6 @@ type DefaultIngress struct {
 	// Soon to be deprecated
 	ClusterRoutesHostname     types.String `tfsdk:"cluster_routes_hostname"`
 	ClusterRoutesTlsSecretRef types.String `tfsdk:"cluster_routes_tls_secret_ref"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -16,7 +16,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -27,6 +26,7 @@ var validListeningMethods = []string{string(cmv1.ListeningMethodExternal), strin
 
 type DefaultIngressResource struct {
 	collection  *cmv1.ClustersClient
+	ingresses   common.IngressesClient
 	clusterWait common.ClusterWait
 }
 
@@ -45,6 +45,7 @@ func (r *DefaultIngressResource) Metadata(ctx context.Context, req resource.Meta
 func (r *DefaultIngressResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Edit a cluster ingress (load balancer)",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
 				Description: "Identifier of the cluster. " + common.ValueCannotBeChangedStringDescription,
@@ -69,6 +70,9 @@ func (r *DefaultIngressResource) Schema(ctx context.Context, req resource.Schema
 				Validators: []validator.String{attrvalidators.EnumValueValidator(validListeningMethods)},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 	return
 }
@@ -78,16 +82,19 @@ func (r *DefaultIngressResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
-	r.clusterWait = common.NewClusterWait(r.collection, connection)
+	r.ingresses = common.NewIngressesClient(r.collection)
+	r.clusterWait = common.NewClusterWait(r.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func (r *DefaultIngressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,25 +106,34 @@ func (r *DefaultIngressResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// Wait till the cluster is ready:
-	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), 60)
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), timeout)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot poll cluster state",
 			fmt.Sprintf(
 				"Cannot poll state of cluster with identifier '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-	err = r.updateIngress(ctx, nil, plan, plan.Cluster.ValueString(), r.collection)
+	err = r.updateIngress(ctx, nil, plan, plan.Cluster.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Failed building cluster default ingress",
 			fmt.Sprintf(
 				"Failed building default ingress for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -136,12 +152,14 @@ func (r *DefaultIngressResource) Read(ctx context.Context, req resource.ReadRequ
 
 	err := r.populateDefaultIngress(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Failed getting cluster default ingress",
 			fmt.Sprintf(
 				"Failed getting default ingress for cluster '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -171,20 +189,28 @@ func (r *DefaultIngressResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// assert cluster attribute wasn't changed:
 	common.ValidateStateAndPlanEquals(state.Cluster, plan.Cluster, "cluster", &diags)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := r.updateIngress(ctx, state, plan, plan.Cluster.ValueString(), r.collection)
+	err := r.updateIngress(ctx, state, plan, plan.Cluster.ValueString())
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update default ingress",
 			fmt.Sprintf(
 				"Cannot update default ingress for "+
 					"cluster '%s': %v", state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 	}
 
@@ -242,22 +268,21 @@ func (r *DefaultIngressResource) populateDefaultIngress(
 			return err
 		}
 	} else {
-		ingressResp, err := r.collection.Cluster(state.Cluster.ValueString()).Ingresses().Ingress(state.Id.ValueString()).Get().SendContext(ctx)
+		ingress, err = r.ingresses.Get(ctx, state.Cluster.ValueString(), state.Id.ValueString())
 		if err != nil {
 			return err
 		}
-		ingress = ingressResp.Body()
 	}
 
 	return r.populateState(ingress, state)
 }
 
 func (r *DefaultIngressResource) populateDefaultIngressFromList(ctx context.Context, state *DefaultIngress) (*cmv1.Ingress, error) {
-	ingresses, err := r.collection.Cluster(state.Cluster.ValueString()).Ingresses().List().SendContext(ctx)
+	ingresses, err := r.ingresses.List(ctx, state.Cluster.ValueString())
 	if err != nil {
 		return nil, err
 	}
-	for _, ingress := range ingresses.Items().Slice() {
+	for _, ingress := range ingresses {
 		if ingress.Default() {
 			return ingress, nil
 		}
@@ -277,7 +302,7 @@ func (r *DefaultIngressResource) populateState(ingress *cmv1.Ingress, state *Def
 }
 
 func (r *DefaultIngressResource) updateIngress(ctx context.Context, state, plan *DefaultIngress,
-	clusterId string, clusterCollection *cmv1.ClustersClient) error {
+	clusterId string) error {
 
 	if state == nil {
 		state = &DefaultIngress{Cluster: plan.Cluster}
@@ -303,13 +328,12 @@ func (r *DefaultIngressResource) updateIngress(ctx context.Context, state, plan
 			return err
 		}
 
-		ingressResp, err := clusterCollection.Cluster(clusterId).Ingresses().Ingress(state.Id.ValueString()).Update().
-			Body(ingress).SendContext(ctx)
+		ingress, err = r.ingresses.Update(ctx, clusterId, state.Id.ValueString(), ingress)
 
 		if err != nil {
 			return err
 		}
-		if err := r.populateState(ingressResp.Body(), plan); err != nil {
+		if err := r.populateState(ingress, plan); err != nil {
 			return err
 		}
 	}
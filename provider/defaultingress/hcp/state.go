@@ -1,9 +1,14 @@
 package hcp
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type DefaultIngress struct {
 	Id              types.String `tfsdk:"id"`
 	Cluster         types.String `tfsdk:"cluster"`
 	ListeningMethod types.String `tfsdk:"listening_method"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
@@ -26,9 +26,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmr "github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/resource"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type DNSDomainResource struct {
@@ -68,14 +68,15 @@ func (r *DNSDomainResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().DNSDomains()
 }
@@ -100,12 +101,14 @@ func (r *DNSDomainResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			fmt.Sprintf(
 				"Can't find DNS domain with identifier '%s'",
 				state.ID.ValueString(),
 			),
 			err.Error(),
+			err,
 		)
 		return
 	}
@@ -144,7 +147,7 @@ func (r *DNSDomainResource) Create(ctx context.Context, req resource.CreateReque
 	createResp, err := dnsDomain.Create()
 
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create DNS Domain.", err.Error())
+		common.AddError(&resp.Diagnostics, "Failed to create DNS Domain.", err.Error(), err)
 		return
 	}
 
@@ -173,9 +176,11 @@ func (r *DNSDomainResource) Delete(ctx context.Context, req resource.DeleteReque
 	err := dnsDomain.Delete(state.ID.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			fmt.Sprintf("Can't delete DNS domain with identifier '%s'", state.ID.ValueString()),
 			err.Error(),
+			err,
 		)
 		return
 	}
@@ -0,0 +1,575 @@
+package externalauthprovider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
+)
+
+const (
+	failedToCreateSummary = "Failed to create external authentication provider"
+	failedToReadSummary   = "Failed to read external authentication provider"
+	failedToUpdateSummary = "Failed to update external authentication provider"
+	failedToDeleteSummary = "Failed to delete external authentication provider"
+)
+
+// The console is the only component of the cluster whose client can be configured.
+const (
+	consoleComponentName      = "console"
+	consoleComponentNamespace = "openshift-console"
+)
+
+const prefixPolicyPrefix = "Prefix"
+
+var validPrefixPolicies = []string{"NoPrefix", prefixPolicyPrefix}
+
+type ExternalAuthProviderResource struct {
+	externalAuths common.ExternalAuthsClient
+	clusterWait   common.ClusterWait
+}
+
+var _ resource.Resource = &ExternalAuthProviderResource{}
+var _ resource.ResourceWithConfigure = &ExternalAuthProviderResource{}
+var _ resource.ResourceWithImportState = &ExternalAuthProviderResource{}
+
+func New() resource.Resource {
+	return &ExternalAuthProviderResource{}
+}
+
+func (r *ExternalAuthProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_external_auth_provider"
+}
+
+func (r *ExternalAuthProviderResource) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "External OIDC identity provider that authenticates the users of the API of a hosted " +
+			"control plane cluster, instead of the built-in OAuth server. The cluster must be created with " +
+			"'external_auth_providers_enabled' set to true.",
+		Attributes: map[string]schema.Attribute{
+			"cluster": schema.StringAttribute{
+				Description: "Identifier of the cluster. " + common.ValueCannotBeChangedStringDescription,
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "cluster ID may not be empty/blank string"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the external authentication provider.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the external authentication provider. " +
+					common.ValueCannotBeChangedStringDescription,
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`.*\S.*`), "name may not be empty/blank string"),
+				},
+			},
+			"issuer": schema.SingleNestedAttribute{
+				Description: "Issuer of the tokens.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "URL of the token issuer. It must use the https scheme.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^https://\S+$`),
+								"issuer URL must use the https scheme"),
+						},
+					},
+					"audiences": schema.ListAttribute{
+						Description: "Audiences that the tokens must be issued for. A token is accepted if its " +
+							"'aud' claim contains at least one of them.",
+						ElementType: types.StringType,
+						Required:    true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"ca": schema.StringAttribute{
+						Description: "PEM encoded certificate authority bundle used to verify the certificate " +
+							"of the issuer, when it isn't signed by a publicly trusted authority.",
+						Optional: true,
+					},
+				},
+				Required: true,
+			},
+			"claim": schema.SingleNestedAttribute{
+				Description: "Rules that map the claims of the tokens to the users of the cluster and validate them.",
+				Attributes: map[string]schema.Attribute{
+					"mappings": schema.SingleNestedAttribute{
+						Description: "Claims used to build the identity of the users.",
+						Attributes: map[string]schema.Attribute{
+							"username": schema.SingleNestedAttribute{
+								Description: "Claim used as the name of the user.",
+								Attributes: map[string]schema.Attribute{
+									"claim": schema.StringAttribute{
+										Description: "Name of the claim.",
+										Required:    true,
+									},
+									"prefix": schema.StringAttribute{
+										Description: "Prefix added to the value of the claim. It requires " +
+											"'prefix_policy' to be 'Prefix'.",
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.AlsoRequires(
+												path.MatchRelative().AtParent().AtName("prefix_policy"),
+											),
+											usernamePrefixValidator(),
+										},
+									},
+									"prefix_policy": schema.StringAttribute{
+										Description: "How the value of the claim is prefixed. Valid values " +
+											"are 'NoPrefix' and 'Prefix'.",
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(validPrefixPolicies...),
+										},
+									},
+								},
+								Optional: true,
+							},
+							"groups": schema.SingleNestedAttribute{
+								Description: "Claim used as the groups of the user.",
+								Attributes: map[string]schema.Attribute{
+									"claim": schema.StringAttribute{
+										Description: "Name of the claim.",
+										Required:    true,
+									},
+									"prefix": schema.StringAttribute{
+										Description: "Prefix added to the name of each group.",
+										Optional:    true,
+									},
+								},
+								Optional: true,
+							},
+						},
+						Optional: true,
+					},
+					"validation_rules": schema.ListNestedAttribute{
+						Description: "Rules that the claims of the tokens must satisfy to be accepted.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"claim": schema.StringAttribute{
+									Description: "Name of the claim.",
+									Required:    true,
+								},
+								"required_value": schema.StringAttribute{
+									Description: "Value that the claim must have.",
+									Required:    true,
+								},
+							},
+						},
+						Optional: true,
+					},
+				},
+				Optional: true,
+			},
+			"console_client": schema.SingleNestedAttribute{
+				Description: "OIDC client used by the console of the cluster to log in the users.",
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						Description: "Identifier of the client, as registered in the identity provider.",
+						Required:    true,
+					},
+					"client_secret": schema.StringAttribute{
+						Description: "Secret of the client. It isn't returned by the service, so changes made " +
+							"outside of Terraform aren't detected.",
+						Optional:  true,
+						Sensitive: true,
+					},
+					"extra_scopes": schema.ListAttribute{
+						Description: "Scopes requested in addition to 'openid' when logging in.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+				},
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.CreateTimeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ExternalAuthProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*common.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	collection := providerData.Connection.ClustersMgmt().V1().Clusters()
+	r.externalAuths = common.NewExternalAuthsClient(collection)
+	r.clusterWait = common.NewClusterWait(collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+}
+
+func (r *ExternalAuthProviderResource) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	plan := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := plan.Cluster.ValueString()
+
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, clusterId, timeout)
+	if err != nil {
+		common.AddError(
+			&resp.Diagnostics,
+			"Cluster is not ready",
+			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+			err,
+		)
+		return
+	}
+	if !cluster.ExternalAuthConfig().Enabled() {
+		resp.Diagnostics.AddError(
+			failedToCreateSummary,
+			fmt.Sprintf("External authentication providers aren't enabled in cluster '%s'. They can only be "+
+				"used by hosted control plane clusters created with 'external_auth_providers_enabled' set "+
+				"to true.", clusterId),
+		)
+		return
+	}
+
+	externalAuth, err := buildExternalAuth(ctx, plan)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to build external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, err), err)
+		return
+	}
+	created, err := r.externalAuths.Create(ctx, clusterId, externalAuth)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to create external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, err), err)
+		return
+	}
+
+	resp.Diagnostics.Append(populateState(ctx, created, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ExternalAuthProviderResource) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := state.Cluster.ValueString()
+
+	exists, externalAuth, err := r.externalAuths.Exists(ctx, clusterId, state.ID.ValueString())
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToReadSummary,
+			fmt.Sprintf("Cannot read external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), clusterId, err), err)
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("External authentication provider '%s' for cluster '%s' not found, "+
+			"removing from state", state.ID.ValueString(), clusterId))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(populateState(ctx, externalAuth, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ExternalAuthProviderResource) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	common.ValidateStateAndPlanEquals(state.Cluster, plan.Cluster, "cluster", &resp.Diagnostics)
+	common.ValidateStateAndPlanEquals(state.Name, plan.Name, "name", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterId := plan.Cluster.ValueString()
+
+	externalAuth, err := buildExternalAuth(ctx, plan)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary,
+			fmt.Sprintf("Failed to build external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, err), err)
+		return
+	}
+	updated, err := r.externalAuths.Update(ctx, clusterId, externalAuth)
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary,
+			fmt.Sprintf("Failed to update external authentication provider '%s' for cluster '%s': %v",
+				plan.Name.ValueString(), clusterId, err), err)
+		return
+	}
+
+	resp.Diagnostics.Append(populateState(ctx, updated, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ExternalAuthProviderResource) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	state := &ExternalAuthProviderState{}
+	resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.externalAuths.Delete(ctx, state.Cluster.ValueString(), state.ID.ValueString())
+	if err != nil {
+		common.AddError(&resp.Diagnostics, failedToDeleteSummary,
+			fmt.Sprintf("Failed to delete external authentication provider '%s' for cluster '%s': %v",
+				state.ID.ValueString(), state.Cluster.ValueString(), err), err)
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *ExternalAuthProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	fields := strings.Split(req.ID, ",")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identifier",
+			"External authentication provider to import should be specified as <cluster_id>,<provider_name>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster"), fields[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fields[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), fields[1])...)
+}
+
+// usernamePrefixValidator checks that the prefix of the username is only set with the 'Prefix'
+// policy, as OCM ignores it with the other policies.
+func usernamePrefixValidator() validator.String {
+	return attrvalidators.NewStringValidator("requires 'prefix_policy' to be 'Prefix'",
+		func(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+			if !common.HasValue(req.ConfigValue) {
+				return
+			}
+			policy := types.String{}
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx,
+				req.Path.ParentPath().AtName("prefix_policy"), &policy)...)
+			// A missing policy is reported by the 'AlsoRequires' validator:
+			if !common.HasValue(policy) {
+				return
+			}
+			if policy.ValueString() != prefixPolicyPrefix {
+				resp.Diagnostics.AddAttributeError(req.Path, "Invalid username prefix",
+					fmt.Sprintf("The username prefix requires 'prefix_policy' to be '%s', got '%s'",
+						prefixPolicyPrefix, policy.ValueString()))
+			}
+		})
+}
+
+// buildExternalAuth converts the state into the object sent to OCM.
+func buildExternalAuth(ctx context.Context, state *ExternalAuthProviderState) (*cmv1.ExternalAuth, error) {
+	builder := cmv1.NewExternalAuth().ID(state.Name.ValueString())
+
+	audiences, err := common.StringListToArray(ctx, state.Issuer.Audiences)
+	if err != nil {
+		return nil, err
+	}
+	issuer := cmv1.NewTokenIssuer().
+		URL(state.Issuer.URL.ValueString()).
+		Audiences(audiences...)
+	if common.HasValue(state.Issuer.CA) {
+		issuer.CA(state.Issuer.CA.ValueString())
+	}
+	builder.Issuer(issuer)
+
+	if state.Claim != nil {
+		claim := cmv1.NewExternalAuthClaim()
+		if mappings := state.Claim.Mappings; mappings != nil {
+			mappingsBuilder := cmv1.NewTokenClaimMappings()
+			if username := mappings.Username; username != nil {
+				usernameBuilder := cmv1.NewUsernameClaim().Claim(username.Claim.ValueString())
+				if common.HasValue(username.Prefix) {
+					usernameBuilder.Prefix(username.Prefix.ValueString())
+				}
+				if common.HasValue(username.PrefixPolicy) {
+					usernameBuilder.PrefixPolicy(username.PrefixPolicy.ValueString())
+				}
+				mappingsBuilder.UserName(usernameBuilder)
+			}
+			if groups := mappings.Groups; groups != nil {
+				groupsBuilder := cmv1.NewGroupsClaim().Claim(groups.Claim.ValueString())
+				if common.HasValue(groups.Prefix) {
+					groupsBuilder.Prefix(groups.Prefix.ValueString())
+				}
+				mappingsBuilder.Groups(groupsBuilder)
+			}
+			claim.Mappings(mappingsBuilder)
+		}
+		rules := []*cmv1.TokenClaimValidationRuleBuilder{}
+		for _, rule := range state.Claim.ValidationRules {
+			rules = append(rules, cmv1.NewTokenClaimValidationRule().
+				Claim(rule.Claim.ValueString()).
+				RequiredValue(rule.RequiredValue.ValueString()))
+		}
+		claim.ValidationRules(rules...)
+		builder.Claim(claim)
+	}
+
+	if state.ConsoleClient != nil {
+		client := cmv1.NewExternalAuthClientConfig().
+			ID(state.ConsoleClient.ClientID.ValueString()).
+			Component(cmv1.NewClientComponent().
+				Name(consoleComponentName).
+				Namespace(consoleComponentNamespace))
+		if common.HasValue(state.ConsoleClient.ClientSecret) {
+			client.Secret(state.ConsoleClient.ClientSecret.ValueString())
+		}
+		if common.HasValue(state.ConsoleClient.ExtraScopes) {
+			scopes, err := common.StringListToArray(ctx, state.ConsoleClient.ExtraScopes)
+			if err != nil {
+				return nil, err
+			}
+			client.ExtraScopes(scopes...)
+		}
+		builder.Clients(client)
+	}
+
+	return builder.Build()
+}
+
+// populateState copies the object returned by OCM into the state. The secret of the console
+// client isn't returned, so the one of the state is kept. OCM doesn't distinguish empty and
+// missing values either, so the empty claim and lists of the state are kept when OCM returns none.
+func populateState(ctx context.Context, externalAuth *cmv1.ExternalAuth,
+	state *ExternalAuthProviderState) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	prior := *state
+	state.ID = types.StringValue(externalAuth.ID())
+	state.Name = types.StringValue(externalAuth.ID())
+
+	audiences, d := types.ListValueFrom(ctx, types.StringType, externalAuth.Issuer().Audiences())
+	diags.Append(d...)
+	state.Issuer = &Issuer{
+		URL:       types.StringValue(externalAuth.Issuer().URL()),
+		Audiences: audiences,
+		CA:        common.EmptiableStringToStringType(externalAuth.Issuer().CA()),
+	}
+
+	state.Claim = nil
+	mappings := externalAuth.Claim().Mappings()
+	rules := externalAuth.Claim().ValidationRules()
+	if mappings != nil || len(rules) > 0 || prior.Claim != nil {
+		state.Claim = &Claim{}
+		if mappings != nil || (prior.Claim != nil && prior.Claim.Mappings != nil) {
+			state.Claim.Mappings = &ClaimMappings{}
+		}
+		if mappings != nil {
+			if username, ok := mappings.GetUserName(); ok {
+				state.Claim.Mappings.Username = &UsernameClaim{
+					Claim:        types.StringValue(username.Claim()),
+					Prefix:       common.EmptiableStringToStringType(username.Prefix()),
+					PrefixPolicy: common.EmptiableStringToStringType(username.PrefixPolicy()),
+				}
+			}
+			if groups, ok := mappings.GetGroups(); ok {
+				state.Claim.Mappings.Groups = &GroupsClaim{
+					Claim:  types.StringValue(groups.Claim()),
+					Prefix: common.EmptiableStringToStringType(groups.Prefix()),
+				}
+			}
+		}
+		if prior.Claim != nil && prior.Claim.ValidationRules != nil {
+			state.Claim.ValidationRules = []ValidationRule{}
+		}
+		for _, rule := range rules {
+			state.Claim.ValidationRules = append(state.Claim.ValidationRules, ValidationRule{
+				Claim:         types.StringValue(rule.Claim()),
+				RequiredValue: types.StringValue(rule.RequiredValue()),
+			})
+		}
+	}
+
+	secret := types.StringNull()
+	emptyScopes := false
+	if prior.ConsoleClient != nil {
+		secret = prior.ConsoleClient.ClientSecret
+		emptyScopes = common.HasValue(prior.ConsoleClient.ExtraScopes) &&
+			len(prior.ConsoleClient.ExtraScopes.Elements()) == 0
+	}
+	state.ConsoleClient = nil
+	for _, client := range externalAuth.Clients() {
+		if client.Component().Name() != consoleComponentName ||
+			client.Component().Namespace() != consoleComponentNamespace {
+			continue
+		}
+		scopes := types.ListNull(types.StringType)
+		if len(client.ExtraScopes()) > 0 {
+			scopes, d = types.ListValueFrom(ctx, types.StringType, client.ExtraScopes())
+			diags.Append(d...)
+		} else if emptyScopes {
+			scopes = types.ListValueMust(types.StringType, []attr.Value{})
+		}
+		state.ConsoleClient = &ConsoleClient{
+			ClientID:     types.StringValue(client.ID()),
+			ClientSecret: secret,
+			ExtraScopes:  scopes,
+		}
+	}
+
+	return diags
+}
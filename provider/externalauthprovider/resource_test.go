@@ -0,0 +1,375 @@
+package externalauthprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("External auth provider resource", func() {
+	const (
+		clusterId = "123"
+		name      = "my-sso"
+	)
+
+	var (
+		ctx           context.Context
+		externalAuths *common.MockExternalAuthsClient
+		clusterWait   *common.MockClusterWait
+		r             *ExternalAuthProviderResource
+		schemaResp    *resource.SchemaResponse
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		externalAuths = common.NewMockExternalAuthsClient(ctrl)
+		clusterWait = common.NewMockClusterWait(ctrl)
+		r = &ExternalAuthProviderResource{
+			externalAuths: externalAuths,
+			clusterWait:   clusterWait,
+		}
+		schemaResp = &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		Expect(schemaResp.Diagnostics.HasError()).To(BeFalse())
+	})
+
+	stringList := func(values ...string) types.List {
+		list, diags := types.ListValueFrom(ctx, types.StringType, values)
+		Expect(diags.HasError()).To(BeFalse())
+		return list
+	}
+
+	// newState returns the state of a provider with all the attributes set.
+	newState := func() *ExternalAuthProviderState {
+		return &ExternalAuthProviderState{
+			Cluster: types.StringValue(clusterId),
+			ID:      types.StringValue(name),
+			Name:    types.StringValue(name),
+			Issuer: &Issuer{
+				URL:       types.StringValue("https://sso.example.com"),
+				Audiences: stringList("openshift", "console"),
+				CA:        types.StringNull(),
+			},
+			Claim: &Claim{
+				Mappings: &ClaimMappings{
+					Username: &UsernameClaim{
+						Claim:        types.StringValue("email"),
+						Prefix:       types.StringNull(),
+						PrefixPolicy: types.StringValue("NoPrefix"),
+					},
+					Groups: &GroupsClaim{
+						Claim:  types.StringValue("groups"),
+						Prefix: types.StringValue("sso:"),
+					},
+				},
+				ValidationRules: []ValidationRule{{
+					Claim:         types.StringValue("tenant"),
+					RequiredValue: types.StringValue("acme"),
+				}},
+			},
+			ConsoleClient: &ConsoleClient{
+				ClientID:     types.StringValue("console-client"),
+				ClientSecret: types.StringValue("secret"),
+				ExtraScopes:  stringList("email"),
+			},
+			Timeouts: timeouts.Value{
+				Object: types.ObjectNull(schemaResp.Schema.Blocks["timeouts"].Type().(timeouts.Type).AttrTypes),
+			},
+		}
+	}
+
+	newPlan := func(state *ExternalAuthProviderState) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		Expect(plan.Set(ctx, state).HasError()).To(BeFalse())
+		return plan
+	}
+
+	newTfState := func(state *ExternalAuthProviderState) tfsdk.State {
+		tfState := tfsdk.State{Schema: schemaResp.Schema}
+		Expect(tfState.Set(ctx, state).HasError()).To(BeFalse())
+		return tfState
+	}
+
+	emptyTfState := func() tfsdk.State {
+		return tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+	}
+
+	readState := func(tfState tfsdk.State) *ExternalAuthProviderState {
+		result := &ExternalAuthProviderState{}
+		Expect(tfState.Get(ctx, result).HasError()).To(BeFalse())
+		return result
+	}
+
+	// returned is what OCM returns for the provider of newState, which doesn't contain the secret.
+	returned := func() *cmv1.ExternalAuth {
+		externalAuth, err := cmv1.NewExternalAuth().
+			ID(name).
+			Issuer(cmv1.NewTokenIssuer().URL("https://sso.example.com").Audiences("openshift", "console")).
+			Claim(cmv1.NewExternalAuthClaim().
+				Mappings(cmv1.NewTokenClaimMappings().
+					UserName(cmv1.NewUsernameClaim().Claim("email").PrefixPolicy("NoPrefix")).
+					Groups(cmv1.NewGroupsClaim().Claim("groups").Prefix("sso:"))).
+				ValidationRules(cmv1.NewTokenClaimValidationRule().Claim("tenant").RequiredValue("acme"))).
+			Clients(cmv1.NewExternalAuthClientConfig().
+				ID("console-client").
+				Component(cmv1.NewClientComponent().Name("console").Namespace("openshift-console")).
+				ExtraScopes("email")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return externalAuth
+	}
+
+	readyCluster := func(enabled bool) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().ID(clusterId).
+			Hypershift(cmv1.NewHypershift().Enabled(true)).
+			ExternalAuthConfig(cmv1.NewExternalAuthConfig().Enabled(enabled)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	Context("Username prefix", func() {
+		prefixPath := path.Root("claim").AtName("mappings").AtName("username").AtName("prefix")
+
+		validate := func(policy types.String) diag.Diagnostics {
+			state := newState()
+			state.Claim.Mappings.Username.Prefix = types.StringValue("sso:")
+			state.Claim.Mappings.Username.PrefixPolicy = policy
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: newPlan(state).Raw}
+			resp := &validator.StringResponse{}
+			usernamePrefixValidator().ValidateString(ctx, validator.StringRequest{
+				Path:        prefixPath,
+				ConfigValue: types.StringValue("sso:"),
+				Config:      config,
+			}, resp)
+			return resp.Diagnostics
+		}
+
+		It("Accepts the prefix with the 'Prefix' policy", func() {
+			Expect(validate(types.StringValue("Prefix")).HasError()).To(BeFalse())
+		})
+
+		It("Rejects the prefix with the 'NoPrefix' policy", func() {
+			diags := validate(types.StringValue("NoPrefix"))
+			Expect(diags.HasError()).To(BeTrue())
+			Expect(diags[0].Detail()).To(ContainSubstring("'prefix_policy' to be 'Prefix'"))
+		})
+
+		It("Leaves the missing policy to the schema", func() {
+			Expect(validate(types.StringNull()).HasError()).To(BeFalse())
+			prefix := schemaResp.Schema.Attributes["claim"].(schema.SingleNestedAttribute).
+				Attributes["mappings"].(schema.SingleNestedAttribute).
+				Attributes["username"].(schema.SingleNestedAttribute).
+				Attributes["prefix"].(schema.StringAttribute)
+			Expect(prefix.Validators).To(HaveLen(2))
+		})
+	})
+
+	Context("Create", func() {
+		It("Creates the provider once the cluster is ready", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(readyCluster(true), nil)
+			var sent *cmv1.ExternalAuth
+			externalAuths.EXPECT().Create(gomock.Any(), clusterId, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, clusterId string,
+					externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error) {
+					sent = externalAuth
+					return returned(), nil
+				})
+
+			plan := newState()
+			plan.ID = types.StringUnknown()
+			resp := &resource.CreateResponse{State: emptyTfState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(plan)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+
+			Expect(sent.ID()).To(Equal(name))
+			Expect(sent.Issuer().Audiences()).To(Equal([]string{"openshift", "console"}))
+			Expect(sent.Claim().Mappings().UserName().PrefixPolicy()).To(Equal("NoPrefix"))
+			Expect(sent.Claim().Mappings().Groups().Prefix()).To(Equal("sso:"))
+			Expect(sent.Claim().ValidationRules()).To(HaveLen(1))
+			Expect(sent.Clients()).To(HaveLen(1))
+			Expect(sent.Clients()[0].Component().Namespace()).To(Equal("openshift-console"))
+			Expect(sent.Clients()[0].Secret()).To(Equal("secret"))
+			Expect(readState(resp.State)).To(Equal(newState()))
+		})
+
+		It("Fails when the cluster doesn't have external authentication enabled", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(readyCluster(false), nil)
+
+			resp := &resource.CreateResponse{State: emptyTfState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+			Expect(resp.Diagnostics[0].Detail()).To(ContainSubstring("external_auth_providers_enabled"))
+		})
+
+		It("Fails when the cluster isn't ready", func() {
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Any(), clusterId, common.DefaultTimeout).
+				Return(nil, fmt.Errorf("cluster is not ready"))
+
+			resp := &resource.CreateResponse{State: emptyTfState()}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		})
+	})
+
+	Context("Read", func() {
+		It("Keeps the secret of the console client", func() {
+			externalAuths.EXPECT().Exists(gomock.Any(), clusterId, name).Return(true, returned(), nil)
+
+			resp := &resource.ReadResponse{State: emptyTfState()}
+			r.Read(ctx, resource.ReadRequest{State: newTfState(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			Expect(readState(resp.State)).To(Equal(newState()))
+		})
+
+		It("Leaves out the optional attributes that aren't set", func() {
+			externalAuth, err := cmv1.NewExternalAuth().
+				ID(name).
+				Issuer(cmv1.NewTokenIssuer().URL("https://sso.example.com").Audiences("openshift")).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			externalAuths.EXPECT().Exists(gomock.Any(), clusterId, name).Return(true, externalAuth, nil)
+
+			prior := newState()
+			prior.Claim = nil
+			resp := &resource.ReadResponse{State: emptyTfState()}
+			r.Read(ctx, resource.ReadRequest{State: newTfState(prior)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			state := readState(resp.State)
+			Expect(state.Claim).To(BeNil())
+			Expect(state.ConsoleClient).To(BeNil())
+			Expect(state.Issuer.CA.IsNull()).To(BeTrue())
+		})
+
+		It("Keeps the empty claim and lists that OCM doesn't return", func() {
+			externalAuth, err := cmv1.NewExternalAuth().
+				ID(name).
+				Issuer(cmv1.NewTokenIssuer().URL("https://sso.example.com").Audiences("openshift", "console")).
+				Clients(cmv1.NewExternalAuthClientConfig().
+					ID("console-client").
+					Component(cmv1.NewClientComponent().Name("console").Namespace("openshift-console"))).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			externalAuths.EXPECT().Exists(gomock.Any(), clusterId, name).Return(true, externalAuth, nil)
+			prior := newState()
+			prior.Claim = &Claim{ValidationRules: []ValidationRule{}}
+			prior.ConsoleClient.ExtraScopes = stringList()
+
+			resp := &resource.ReadResponse{State: emptyTfState()}
+			r.Read(ctx, resource.ReadRequest{State: newTfState(prior)}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			Expect(readState(resp.State)).To(Equal(prior))
+		})
+
+		It("Detects the rules removed outside of Terraform", func() {
+			externalAuth, err := cmv1.NewExternalAuth().
+				ID(name).
+				Issuer(cmv1.NewTokenIssuer().URL("https://sso.example.com").Audiences("openshift", "console")).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			externalAuths.EXPECT().Exists(gomock.Any(), clusterId, name).Return(true, externalAuth, nil)
+
+			resp := &resource.ReadResponse{State: emptyTfState()}
+			r.Read(ctx, resource.ReadRequest{State: newTfState(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			state := readState(resp.State)
+			Expect(state.Claim.ValidationRules).To(BeEmpty())
+			Expect(state.Claim.Mappings.Username).To(BeNil())
+		})
+
+		It("Removes the provider from the state when it doesn't exist", func() {
+			externalAuths.EXPECT().Exists(gomock.Any(), clusterId, name).Return(false, nil, nil)
+
+			resp := &resource.ReadResponse{State: newTfState(newState())}
+			r.Read(ctx, resource.ReadRequest{State: newTfState(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+	})
+
+	Context("Update", func() {
+		It("Sends the changed provider", func() {
+			plan := newState()
+			plan.Issuer.Audiences = stringList("openshift")
+			externalAuths.EXPECT().Update(gomock.Any(), clusterId, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, clusterId string,
+					externalAuth *cmv1.ExternalAuth) (*cmv1.ExternalAuth, error) {
+					Expect(externalAuth.ID()).To(Equal(name))
+					Expect(externalAuth.Issuer().Audiences()).To(Equal([]string{"openshift"}))
+					return externalAuth, nil
+				})
+
+			resp := &resource.UpdateResponse{State: newTfState(newState())}
+			r.Update(ctx, resource.UpdateRequest{
+				State: newTfState(newState()),
+				Plan:  newPlan(plan),
+			}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse(), "%v", resp.Diagnostics)
+			Expect(readState(resp.State).Issuer.Audiences).To(Equal(stringList("openshift")))
+		})
+
+		It("Doesn't allow changing the name", func() {
+			plan := newState()
+			plan.Name = types.StringValue("other")
+
+			resp := &resource.UpdateResponse{State: newTfState(newState())}
+			r.Update(ctx, resource.UpdateRequest{
+				State: newTfState(newState()),
+				Plan:  newPlan(plan),
+			}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		})
+	})
+
+	Context("Delete", func() {
+		It("Deletes the provider", func() {
+			externalAuths.EXPECT().Delete(gomock.Any(), clusterId, name).Return(nil)
+
+			resp := &resource.DeleteResponse{State: newTfState(newState())}
+			r.Delete(ctx, resource.DeleteRequest{State: newTfState(newState())}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			Expect(resp.State.Raw.IsNull()).To(BeTrue())
+		})
+	})
+
+	Context("Import", func() {
+		It("Sets the cluster and the name", func() {
+			resp := &resource.ImportStateResponse{State: emptyTfState()}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: clusterId + "," + name}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeFalse())
+			var cluster, id, importedName types.String
+			resp.State.GetAttribute(ctx, path.Root("cluster"), &cluster)
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			resp.State.GetAttribute(ctx, path.Root("name"), &importedName)
+			Expect(cluster.ValueString()).To(Equal(clusterId))
+			Expect(id.ValueString()).To(Equal(name))
+			Expect(importedName.ValueString()).To(Equal(name))
+		})
+
+		It("Fails when the identifier doesn't contain the cluster and the name", func() {
+			resp := &resource.ImportStateResponse{State: emptyTfState()}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: name}, resp)
+			Expect(resp.Diagnostics.HasError()).To(BeTrue())
+		})
+	})
+})
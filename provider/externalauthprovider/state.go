@@ -0,0 +1,54 @@
+package externalauthprovider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ExternalAuthProviderState struct {
+	Cluster       types.String   `tfsdk:"cluster"`
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	Issuer        *Issuer        `tfsdk:"issuer"`
+	Claim         *Claim         `tfsdk:"claim"`
+	ConsoleClient *ConsoleClient `tfsdk:"console_client"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type Issuer struct {
+	URL       types.String `tfsdk:"url"`
+	Audiences types.List   `tfsdk:"audiences"`
+	CA        types.String `tfsdk:"ca"`
+}
+
+type Claim struct {
+	Mappings        *ClaimMappings   `tfsdk:"mappings"`
+	ValidationRules []ValidationRule `tfsdk:"validation_rules"`
+}
+
+type ClaimMappings struct {
+	Username *UsernameClaim `tfsdk:"username"`
+	Groups   *GroupsClaim   `tfsdk:"groups"`
+}
+
+type UsernameClaim struct {
+	Claim        types.String `tfsdk:"claim"`
+	Prefix       types.String `tfsdk:"prefix"`
+	PrefixPolicy types.String `tfsdk:"prefix_policy"`
+}
+
+type GroupsClaim struct {
+	Claim  types.String `tfsdk:"claim"`
+	Prefix types.String `tfsdk:"prefix"`
+}
+
+type ValidationRule struct {
+	Claim         types.String `tfsdk:"claim"`
+	RequiredValue types.String `tfsdk:"required_value"`
+}
+
+type ConsoleClient struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	ExtraScopes  types.List   `tfsdk:"extra_scopes"`
+}
@@ -25,8 +25,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type GroupsDataSource struct {
@@ -91,7 +92,7 @@ func (g *GroupsDataSource) Configure(ctx context.Context, req datasource.Configu
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	g.collection = connection.ClustersMgmt().V1().Clusters()
@@ -115,9 +116,11 @@ func (g *GroupsDataSource) Read(ctx context.Context, request datasource.ReadRequ
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"Can't list groups",
 				err.Error(),
+				err,
 			)
 			return
 		}
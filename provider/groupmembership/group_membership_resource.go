@@ -27,7 +27,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -75,6 +74,9 @@ func (g *GroupMembershipResource) Schema(ctx context.Context, req resource.Schem
 				Required:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.CreateTimeoutsBlock(ctx),
+		},
 	}
 	return
 }
@@ -85,17 +87,19 @@ func (g *GroupMembershipResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	g.collection = connection.ClustersMgmt().V1().Clusters()
-	g.clusterWait = common.NewClusterWait(g.collection, connection)
+	g.clusterWait = common.NewClusterWait(g.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func (g *GroupMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,15 +112,21 @@ func (g *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// Wait till the cluster is ready:
-	waitTimeoutInMinutes := int64(60)
-	_, err := g.clusterWait.WaitForClusterToBeReady(ctx, state.Cluster.ValueString(), waitTimeoutInMinutes)
+	timeout, diags := state.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, err := g.clusterWait.WaitForClusterToBeReady(ctx, state.Cluster.ValueString(), timeout)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -126,24 +136,28 @@ func (g *GroupMembershipResource) Create(ctx context.Context, req resource.Creat
 	builder.ID(state.User.ValueString())
 	object, err := builder.Build()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't build group membership",
 			fmt.Sprintf(
 				"Can't build group membership for cluster '%s' and group '%s': %v",
 				state.Cluster.ValueString(), state.Group.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 	collection := g.collection.Cluster(state.Cluster.ValueString()).Groups().Group(state.Group.ValueString()).Users()
 	add, err := collection.Add().Body(object).SendContext(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't create group membership",
 			fmt.Sprintf(
 				"Can't create group membership for cluster '%s' and group '%s': %v",
 				state.Cluster.ValueString(), state.Group.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -170,13 +184,15 @@ func (g *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 		User(state.ID.ValueString())
 	get, err := obj.Get().SendContext(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't find group membership",
 			fmt.Sprintf(
 				"Can't find user group membership identifier '%s' for "+
 					"cluster '%s' and group '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), state.Group.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -190,8 +206,32 @@ func (g *GroupMembershipResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (g *GroupMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Until we support. return an informative error
-	resp.Diagnostics.AddError("Can't update group membership", "Update is currently not supported.")
+	// Get the state:
+	state := &GroupMembershipState{}
+	diags := req.State.Get(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get the plan:
+	plan := &GroupMembershipState{}
+	diags = req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Until we support. return an informative error, unless only the timeouts changed, as they
+	// don't exist in the server:
+	if !state.Cluster.Equal(plan.Cluster) || !state.Group.Equal(plan.Group) || !state.User.Equal(plan.User) {
+		resp.Diagnostics.AddError("Can't update group membership", "Update is currently not supported.")
+		return
+	}
+	plan.ID = state.ID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (g *GroupMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -209,13 +249,15 @@ func (g *GroupMembershipResource) Delete(ctx context.Context, req resource.Delet
 		User(state.ID.ValueString())
 	_, err := obj.Delete().SendContext(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't delete group membership",
 			fmt.Sprintf(
 				"Can't delete group membership with identifier '%s' for "+
 					"cluster '%s' and group '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), state.Group.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
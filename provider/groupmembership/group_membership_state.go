@@ -17,12 +17,14 @@ limitations under the License.
 package groupmembership
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type GroupMembershipState struct {
-	Cluster types.String `tfsdk:"cluster"`
-	Group   types.String `tfsdk:"group"`
-	ID      types.String `tfsdk:"id"`
-	User    types.String `tfsdk:"user"`
+	Cluster  types.String   `tfsdk:"cluster"`
+	Group    types.String   `tfsdk:"group"`
+	ID       types.String   `tfsdk:"id"`
+	User     types.String   `tfsdk:"user"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
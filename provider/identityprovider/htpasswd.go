@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common/attrvalidators"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider/htpasswd"
 
@@ -124,14 +125,14 @@ func uniqueUsernameValidator() validator.List {
 	})
 }
 
-func htPasswdUserListToStringMaps(ctx context.Context, users []HTPasswdUser,
-	resource *cmv1.IdentityProviderClient) (map[string]htpasswd.HtPasswdUserWithId, error) {
+func htPasswdUserListToStringMaps(ctx context.Context, users []HTPasswdUser, client common.IdentityProvidersClient,
+	clusterId string, idpId string) (map[string]htpasswd.HtPasswdUserWithId, error) {
 	csUserMap := make(map[string]htpasswd.HtPasswdUserWithId)
-	get, err := resource.HtpasswdUsers().List().SendContext(ctx)
+	items, err := client.ListHTPasswdUsers(ctx, clusterId, idpId)
 	if err != nil {
 		return nil, err
 	}
-	for _, user := range get.Items().Slice() {
+	for _, user := range items {
 		csUserMap[user.Username()] = htpasswd.HtPasswdUserWithId{user.ID(), user.Username(), ""}
 	}
 	for _, user := range users {
@@ -148,59 +149,75 @@ func htPasswdUserListToStringMaps(ctx context.Context, users []HTPasswdUser,
 	return finalUserMap, nil
 }
 
-func UpdateHTPasswd(ctx context.Context, resource *cmv1.IdentityProviderClient, state *IdentityProviderState,
+func UpdateHTPasswd(ctx context.Context, client common.IdentityProvidersClient, state *IdentityProviderState,
 	plan *IdentityProviderState, response *resource.UpdateResponse) {
 	if reflect.DeepEqual(state.HTPasswd.Users, plan.HTPasswd.Users) {
 		return
 	}
-	stateUserMap, err := htPasswdUserListToStringMaps(ctx, state.HTPasswd.Users, resource)
+	clusterId := state.Cluster.ValueString()
+	idpId := state.ID.ValueString()
+	stateUserMap, err := htPasswdUserListToStringMaps(ctx, state.HTPasswd.Users, client, clusterId, idpId)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't get identity provider user ID",
 			fmt.Sprintf(
 				"Can't get identity provider user ID with identifier for "+
 					"cluster '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-	planUserMap, err := htPasswdUserListToStringMaps(ctx, plan.HTPasswd.Users, resource)
+	planUserMap, err := htPasswdUserListToStringMaps(ctx, plan.HTPasswd.Users, client, clusterId, idpId)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't get identity provider user ID",
 			fmt.Sprintf(
 				"Can't get identity provider user ID with identifier for "+
 					"cluster '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	patchParams := htpasswd.PatchParams{
-		ctx, stateUserMap, planUserMap, resource,
-		[]string{}, state.Cluster.ValueString(), response,
+		Ctx:          ctx,
+		StateUserMap: stateUserMap,
+		PlanUserMap:  planUserMap,
+		Client:       client,
+		RemovedUsers: []string{},
+		ClusterId:    clusterId,
+		IdpId:        idpId,
+		Response:     response,
 	}
 
 	patchParams.RemovedUsers, err = htpasswd.DeleteUserFromState(patchParams)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't delete identity provider user",
 			fmt.Sprintf(
 				"Can't delete identity provider user: '%v'", err,
 			),
+			err,
 		)
 		return
 	}
 
 	err = htpasswd.PatchOrAddUserInState(patchParams)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't patch/add identity provider user",
 			fmt.Sprintf(
 				"Can't patch/add identity provider user: '%v'", err,
 			),
+			err,
 		)
 		return
 	}
@@ -4,33 +4,30 @@ import (
 	"context"
 
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
-func DeleteUser(ctx context.Context, id string, resource *v1.IdentityProviderClient) error {
-	userToDelete := resource.HtpasswdUsers().HtpasswdUser(id)
-	deleteRequest := userToDelete.Delete()
-	_, err := deleteRequest.SendContext(ctx)
-	return err
+func DeleteUser(ctx context.Context, client common.IdentityProvidersClient, clusterId string, idpId string,
+	id string) error {
+	return client.DeleteHTPasswdUser(ctx, clusterId, idpId, id)
 }
 
-func UpdateUser(ctx context.Context, password string, id string, resource *v1.IdentityProviderClient) error {
+func UpdateUser(ctx context.Context, client common.IdentityProvidersClient, clusterId string, idpId string,
+	id string, password string) error {
 	userToPatch, err := (&v1.HTPasswdUserBuilder{}).
 		Password(password).Build()
 	if err != nil {
 		return err
 	}
-	_, err = resource.HtpasswdUsers().HtpasswdUser(id).Update().
-		Body(userToPatch).SendContext(ctx)
-	return err
+	return client.UpdateHTPasswdUser(ctx, clusterId, idpId, id, userToPatch)
 }
 
-func AddUser(ctx context.Context, username string, password string, resource *v1.IdentityProviderClient) error {
+func AddUser(ctx context.Context, client common.IdentityProvidersClient, clusterId string, idpId string,
+	username string, password string) error {
 	userToAdd, err := (&v1.HTPasswdUserBuilder{}).Username(username).
 		Password(password).Build()
 	if err != nil {
 		return err
 	}
-	addRequest := resource.HtpasswdUsers().Add()
-	_, err = addRequest.Body(userToAdd).SendContext(ctx)
-	return err
+	return client.AddHTPasswdUser(ctx, clusterId, idpId, userToAdd)
 }
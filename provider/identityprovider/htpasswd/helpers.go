@@ -5,8 +5,8 @@ import (
 	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/pkg/errors"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type HtPasswdUserWithId struct {
@@ -19,14 +19,15 @@ type PatchParams struct {
 	Ctx          context.Context
 	StateUserMap map[string]HtPasswdUserWithId
 	PlanUserMap  map[string]HtPasswdUserWithId
-	Resource     *v1.IdentityProviderClient
+	Client       common.IdentityProvidersClient
 	RemovedUsers []string
 	ClusterId    string
+	IdpId        string
 	Response     *resource.UpdateResponse
 }
 
 func DeleteUserFromState(params PatchParams) ([]string, error) {
-	if params.Ctx == nil || params.StateUserMap == nil || params.PlanUserMap == nil || params.Resource == nil ||
+	if params.Ctx == nil || params.StateUserMap == nil || params.PlanUserMap == nil || params.Client == nil ||
 		params.Response == nil {
 		return []string{}, errors.Errorf("Unable to delete user from htpasswd IDP, nil param")
 	}
@@ -34,7 +35,7 @@ func DeleteUserFromState(params PatchParams) ([]string, error) {
 	removedUsers := []string{}
 	for user, htpasswdUser := range params.StateUserMap {
 		if params.PlanUserMap[user].Username == "" { // Not in plan, delete
-			err := DeleteUser(params.Ctx, htpasswdUser.Id, params.Resource)
+			err := DeleteUser(params.Ctx, params.Client, params.ClusterId, params.IdpId, htpasswdUser.Id)
 			if err != nil {
 				return removedUsers, err
 			}
@@ -45,21 +46,21 @@ func DeleteUserFromState(params PatchParams) ([]string, error) {
 }
 
 func PatchOrAddUserInState(params PatchParams) error {
-	if params.Ctx == nil || params.StateUserMap == nil || params.PlanUserMap == nil || params.Resource == nil ||
+	if params.Ctx == nil || params.StateUserMap == nil || params.PlanUserMap == nil || params.Client == nil ||
 		params.RemovedUsers == nil || params.Response == nil {
 		return errors.Errorf("Unable to patch or add user to htpasswd IDP, nil param")
 	}
 	for user, planValue := range params.PlanUserMap {
 		if stateValue, ok := params.StateUserMap[user]; ok { // Is in current state
 			if planValue != stateValue && !slices.Contains(params.RemovedUsers, user) { // Password changed, update
-				err := UpdateUser(params.Ctx, planValue.Password, planValue.Id, params.Resource)
+				err := UpdateUser(params.Ctx, params.Client, params.ClusterId, params.IdpId, planValue.Id, planValue.Password)
 				if err != nil {
 					return err
 				}
 			}
 		} else { // Should be added (not in current state)
 			if !slices.Contains(params.RemovedUsers, user) {
-				err := AddUser(params.Ctx, user, planValue.Password, params.Resource)
+				err := AddUser(params.Ctx, params.Client, params.ClusterId, params.IdpId, user, planValue.Password)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package htpasswd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	clusterId = "myClusterId"
+	idpId     = "myIdpId"
+)
+
+var _ = Describe("HTPasswd helpers", func() {
+	var client *common.MockIdentityProvidersClient
+	var params PatchParams
+
+	BeforeEach(func() {
+		client = common.NewMockIdentityProvidersClient(gomock.NewController(GinkgoT()))
+		params = PatchParams{
+			Ctx: context.TODO(),
+			StateUserMap: map[string]HtPasswdUserWithId{
+				"kept":    {Id: "1", Username: "kept", Password: "password1"},
+				"changed": {Id: "2", Username: "changed", Password: "password2"},
+				"removed": {Id: "3", Username: "removed", Password: "password3"},
+			},
+			PlanUserMap: map[string]HtPasswdUserWithId{
+				"kept":    {Id: "1", Username: "kept", Password: "password1"},
+				"changed": {Id: "2", Username: "changed", Password: "newPassword2"},
+				"added":   {Username: "added", Password: "password4"},
+			},
+			Client:       client,
+			RemovedUsers: []string{},
+			ClusterId:    clusterId,
+			IdpId:        idpId,
+			Response:     &resource.UpdateResponse{},
+		}
+	})
+
+	Context("DeleteUserFromState", func() {
+		It("Deletes the users that aren't in the plan", func() {
+			client.EXPECT().DeleteHTPasswdUser(params.Ctx, clusterId, idpId, "3").Return(nil)
+
+			removed, err := DeleteUserFromState(params)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(removed).To(ConsistOf("removed"))
+		})
+		It("Fails when a user can't be deleted", func() {
+			client.EXPECT().DeleteHTPasswdUser(params.Ctx, clusterId, idpId, "3").Return(errors.New("boom"))
+
+			removed, err := DeleteUserFromState(params)
+			Expect(err).To(MatchError("boom"))
+			Expect(removed).To(BeEmpty())
+		})
+		It("Fails without a client", func() {
+			params.Client = nil
+
+			_, err := DeleteUserFromState(params)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("PatchOrAddUserInState", func() {
+		It("Updates the changed users and adds the new ones", func() {
+			client.EXPECT().UpdateHTPasswdUser(params.Ctx, clusterId, idpId, "2", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _, _ string, user *cmv1.HTPasswdUser) error {
+					Expect(user.Password()).To(Equal("newPassword2"))
+					return nil
+				})
+			client.EXPECT().AddHTPasswdUser(params.Ctx, clusterId, idpId, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, user *cmv1.HTPasswdUser) error {
+					Expect(user.Username()).To(Equal("added"))
+					Expect(user.Password()).To(Equal("password4"))
+					return nil
+				})
+
+			Expect(PatchOrAddUserInState(params)).To(Succeed())
+		})
+		It("Skips the users that were removed", func() {
+			params.RemovedUsers = []string{"changed", "added"}
+
+			Expect(PatchOrAddUserInState(params)).To(Succeed())
+		})
+		It("Fails when a user can't be added", func() {
+			params.PlanUserMap = map[string]HtPasswdUserWithId{
+				"added": {Username: "added", Password: "password4"},
+			}
+			client.EXPECT().AddHTPasswdUser(params.Ctx, clusterId, idpId, gomock.Any()).Return(errors.New("boom"))
+
+			Expect(PatchOrAddUserInState(params)).To(MatchError("boom"))
+		})
+	})
+})
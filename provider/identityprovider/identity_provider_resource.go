@@ -22,7 +22,6 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -33,7 +32,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
@@ -55,7 +53,9 @@ var listOfIDPTypesPathes = []path.Expression{
 }
 
 type IdentityProviderResource struct {
-	collection *cmv1.ClustersClient
+	collection  *cmv1.ClustersClient
+	idpClient   common.IdentityProvidersClient
+	clusterWait common.ClusterWait
 }
 
 func New() resource.Resource {
@@ -68,6 +68,7 @@ func (r *IdentityProviderResource) Metadata(ctx context.Context, req resource.Me
 func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Identity provider.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
 				Description: "Identifier of the cluster.",
@@ -142,6 +143,9 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 	return
 }
@@ -151,15 +155,19 @@ func (r *IdentityProviderResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	collection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	collection := providerData.Connection
 
 	r.collection = collection.ClustersMgmt().V1().Clusters()
+	r.idpClient = common.NewIdentityProvidersClient(r.collection)
+	r.clusterWait = common.NewClusterWait(r.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func (r *IdentityProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -183,35 +191,22 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		return
 	}
 
-	resource := r.collection.Cluster(state.Cluster.ValueString())
-	// We expect the cluster to be already exist
-	// Try to get it and if result with NotFound error, return error to user
-	if resp, err := resource.Get().SendContext(ctx); err != nil && resp.Status() == http.StatusNotFound {
-		message := fmt.Sprintf("Cluster %s not found, error: %v", state.Cluster.ValueString(), err)
-		tflog.Error(ctx, message)
-		response.Diagnostics.AddError(
-			"Can't poll cluster state",
-			message,
-		)
+	// Wait till the cluster is ready:
+	timeout, diags := state.Timeouts.Create(ctx, common.DefaultTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
 		return
 	}
-
-	// Wait till the cluster is ready:
-	pollCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
-	defer cancel()
-	_, err := resource.Poll().
-		Interval(30 * time.Second).
-		Predicate(func(get *cmv1.ClusterGetResponse) bool {
-			return get.Body().State() == cmv1.ClusterStateReady
-		}).
-		StartContext(pollCtx)
+	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, state.Cluster.ValueString(), timeout)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't poll cluster state",
 			fmt.Sprintf(
 				"Can't poll state of cluster with identifier '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -230,7 +225,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeHtpasswd)
 		htpasswdBuilder, err := CreateHTPasswdIDPBuilder(ctx, state.HTPasswd)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.Htpasswd(htpasswdBuilder)
@@ -238,7 +233,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeGitlab)
 		gitlabBuilder, err := CreateGitlabIDPBuilder(ctx, state.Gitlab)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.Gitlab(gitlabBuilder)
@@ -246,7 +241,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeGithub)
 		githubBuilder, err := CreateGithubIDPBuilder(ctx, state.Github)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.Github(githubBuilder)
@@ -254,7 +249,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeGoogle)
 		googleBuilder, err := CreateGoogleIDPBuilder(ctx, mappingMethod, state.Google)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.Google(googleBuilder)
@@ -262,7 +257,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeLDAP)
 		ldapBuilder, err := CreateLDAPIDPBuilder(ctx, state.LDAP)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.LDAP(ldapBuilder)
@@ -270,36 +265,38 @@ func (r *IdentityProviderResource) Create(ctx context.Context, request resource.
 		builder.Type(cmv1.IdentityProviderTypeOpenID)
 		openidBuilder, err := CreateOpenIDIDPBuilder(ctx, state.OpenID)
 		if err != nil {
-			response.Diagnostics.AddError(err.Error(), err.Error())
+			common.AddError(&response.Diagnostics, err.Error(), err.Error(), err)
 			return
 		}
 		builder.OpenID(openidBuilder)
 	}
 	object, err := builder.Build()
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't build identity provider",
 			fmt.Sprintf(
 				"Can't build identity provider with name '%s': %v",
 				state.Name.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-	collection := resource.IdentityProviders()
-	add, err := collection.Add().Body(object).SendContext(ctx)
+	object, err = r.idpClient.Create(ctx, state.Cluster.ValueString(), object)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't create identity provider",
 			fmt.Sprintf(
 				"Can't create identity provider with name '%s' for "+
 					"cluster '%s': %v",
 				state.Name.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-	object = add.Body()
 
 	state.ID = types.StringValue(object.ID())
 
@@ -339,29 +336,27 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 	}
 
 	// Find the identity provider:
-	resource := r.collection.Cluster(state.Cluster.ValueString()).
-		IdentityProviders().
-		IdentityProvider(state.ID.ValueString())
-	get, err := resource.Get().SendContext(ctx)
+	exists, object, err := r.idpClient.Exists(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if get.Status() == http.StatusNotFound {
-			tflog.Warn(ctx, fmt.Sprintf("identity provider (%s) of cluster (%s) not found, removing from state",
-				state.ID.ValueString(), state.Cluster.ValueString(),
-			))
-			response.State.RemoveResource(ctx)
-			return
-		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't find identity provider",
 			fmt.Sprintf(
 				"Can't find identity provider with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-	object := get.Body()
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("identity provider (%s) of cluster (%s) not found, removing from state",
+			state.ID.ValueString(), state.Cluster.ValueString(),
+		))
+		response.State.RemoveResource(ctx)
+		return
+	}
 
 	// Copy the identity provider data into the state:
 	state.Name = types.StringValue(object.Name())
@@ -490,14 +485,14 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			if ok {
 				state.LDAP.Attributes.ID, err = common.StringArrayToList(id)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert LDAP attribute ID to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert LDAP attribute ID to tf list", err.Error(), err)
 				}
 			}
 			email, ok := attributes.GetEmail()
 			if ok {
 				state.LDAP.Attributes.EMail, err = common.StringArrayToList(email)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert LDAP attribute EMail to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert LDAP attribute EMail to tf list", err.Error(), err)
 				}
 			} else {
 				state.LDAP.Attributes.EMail = types.ListNull(types.StringType)
@@ -506,14 +501,14 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			if ok {
 				state.LDAP.Attributes.Name, err = common.StringArrayToList(name)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert LDAP attribute Name to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert LDAP attribute Name to tf list", err.Error(), err)
 				}
 			}
 			preferredUsername, ok := attributes.GetPreferredUsername()
 			if ok {
 				state.LDAP.Attributes.PreferredUsername, err = common.StringArrayToList(preferredUsername)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert LDAP attribute PreferredUsername to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert LDAP attribute PreferredUsername to tf list", err.Error(), err)
 				}
 			}
 		}
@@ -542,7 +537,7 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			if ok {
 				state.OpenID.Claims.EMail, err = common.StringArrayToList(email)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert OpenID claims EMail to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert OpenID claims EMail to tf list", err.Error(), err)
 				}
 			}
 			groups, ok := claims.GetGroups()
@@ -555,14 +550,14 @@ func (r *IdentityProviderResource) Read(ctx context.Context, request resource.Re
 			if ok {
 				state.OpenID.Claims.Name, err = common.StringArrayToList(name)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert OpenID claims Name to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert OpenID claims Name to tf list", err.Error(), err)
 				}
 			}
 			preferredUsername, ok := claims.GetPreferredUsername()
 			if ok {
 				state.OpenID.Claims.PreferredUsername, err = common.StringArrayToList(preferredUsername)
 				if err != nil {
-					response.Diagnostics.AddError("failed to convert OpenID claims PreferredUsername to tf list", err.Error())
+					common.AddError(&response.Diagnostics, "failed to convert OpenID claims PreferredUsername to tf list", err.Error(), err)
 				}
 			}
 		}
@@ -607,10 +602,13 @@ func (r *IdentityProviderResource) Update(ctx context.Context, request resource.
 	}
 	plan.ID = state.ID
 
-	resource := r.collection.Cluster(state.Cluster.ValueString()).IdentityProviders().
-		IdentityProvider(state.ID.ValueString())
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
 
-	UpdateHTPasswd(ctx, resource, state, plan, response)
+	UpdateHTPasswd(ctx, r.idpClient, state, plan, response)
 
 	diags = response.State.Set(ctx, plan)
 	response.Diagnostics.Append(diags...)
@@ -626,19 +624,24 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, request resource.
 		return
 	}
 
+	ctx, cancel := common.WithDeleteTimeout(ctx, state.Timeouts, &response.Diagnostics)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request to delete the identity provider:
-	resource := r.collection.Cluster(state.Cluster.ValueString()).
-		IdentityProviders().
-		IdentityProvider(state.ID.ValueString())
-	_, err := resource.Delete().SendContext(ctx)
+	err := r.idpClient.Delete(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't delete identity provider",
 			fmt.Sprintf(
 				"Can't delete identity provider with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -674,11 +677,13 @@ func (r *IdentityProviderResource) ImportState(ctx context.Context, request reso
 		return
 	}
 
-	providerID, err := getIDPIDFromName(ctx, resource, providerName)
+	providerID, err := getIDPIDFromName(ctx, r.idpClient, clusterID, providerName)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Can't import identity provider",
 			err.Error(),
+			err,
 		)
 		return
 	}
@@ -688,26 +693,13 @@ func (r *IdentityProviderResource) ImportState(ctx context.Context, request reso
 }
 
 // getIDPIDFromName returns the ID of the identity provider with the given name.
-func getIDPIDFromName(ctx context.Context, client *cmv1.ClusterClient, name string) (string, error) {
+func getIDPIDFromName(ctx context.Context, client common.IdentityProvidersClient, clusterID string,
+	name string) (string, error) {
 	tflog.Debug(ctx, "Converting IDP name to ID", map[string]interface{}{"name": name})
 	// Get the list of identity providers for the cluster:
-	pClient := client.IdentityProviders()
-	identityProviders := []*cmv1.IdentityProvider{}
-	page := 1
-	size := 100
-	for {
-		resp, err := pClient.List().
-			Page(page).
-			Size(size).
-			SendContext(ctx)
-		if err != nil {
-			return "", fmt.Errorf("failed to list identity providers: %v", err)
-		}
-		identityProviders = append(identityProviders, resp.Items().Slice()...)
-		if resp.Size() < size {
-			break
-		}
-		page++
+	identityProviders, err := client.List(ctx, clusterID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list identity providers: %v", err)
 	}
 
 	// Find the identity provider with the given name
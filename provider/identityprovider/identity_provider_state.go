@@ -17,6 +17,7 @@ limitations under the License.
 package identityprovider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -31,4 +32,5 @@ type IdentityProviderState struct {
 	Google        *GoogleIdentityProvider   `tfsdk:"google"`
 	LDAP          *LDAPIdentityProvider     `tfsdk:"ldap"`
 	OpenID        *OpenIDIdentityProvider   `tfsdk:"openid"`
+	Timeouts      timeouts.Value            `tfsdk:"timeouts"`
 }
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identityprovider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+)
+
+// stateUpgraders is the chain of upgraders of the state, see common.StateUpgraders.
+var stateUpgraders = []common.StateUpgrader{
+	upgradeStateV0,
+}
+
+var _ resource.ResourceWithUpgradeState = &IdentityProviderResource{}
+
+func (r *IdentityProviderResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return common.StateUpgraders(stateUpgraders...)
+}
+
+// upgradeStateV0 converts the HTPasswd identity providers of the releases before 1.3.0, that had
+// a single user with the `username` and `password` attributes directly inside `htpasswd`.
+func upgradeStateV0(ctx context.Context, state map[string]interface{}) error {
+	htpasswd, ok := state["htpasswd"].(map[string]interface{})
+	if !ok || htpasswd["users"] != nil || htpasswd["username"] == nil {
+		return nil
+	}
+	htpasswd["users"] = []interface{}{
+		map[string]interface{}{
+			"username": htpasswd["username"],
+			"password": htpasswd["password"],
+		},
+	}
+	delete(htpasswd, "username")
+	delete(htpasswd, "password")
+	return nil
+}
@@ -15,16 +15,95 @@ limitations under the License.
 */
 package info
 
-type ocmEnv string
+import (
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+type OCMEnv string
 
 const (
-	ocmEnvProd  ocmEnv = "production"
-	ocmEnvStage ocmEnv = "stage"
-	ocmEnvInt   ocmEnv = "integration"
+	OCMEnvProd    OCMEnv = "production"
+	OCMEnvStage   OCMEnv = "stage"
+	OCMEnvInt     OCMEnv = "integration"
+	OCMEnvProdGov OCMEnv = "production-gov"
 )
 
-var ocmAWSAccounts = map[ocmEnv]string{
-	ocmEnvProd:  "710019948333",
-	ocmEnvStage: "644306948063",
-	ocmEnvInt:   "896164604406",
+// OCMEnvironment contains the endpoints and identifiers that belong together for one OCM
+// environment.
+type OCMEnvironment struct {
+	Name         OCMEnv
+	URL          string
+	TokenURL     string
+	ClientID     string
+	AWSAccountID string
+}
+
+var ocmEnvironments = map[OCMEnv]OCMEnvironment{
+	OCMEnvProd: {
+		Name:         OCMEnvProd,
+		URL:          sdk.DefaultURL,
+		TokenURL:     sdk.DefaultTokenURL,
+		ClientID:     sdk.DefaultClientID,
+		AWSAccountID: "710019948333",
+	},
+	OCMEnvStage: {
+		Name:         OCMEnvStage,
+		URL:          "https://api.stage.openshift.com",
+		TokenURL:     sdk.DefaultTokenURL,
+		ClientID:     sdk.DefaultClientID,
+		AWSAccountID: "644306948063",
+	},
+	OCMEnvInt: {
+		Name:         OCMEnvInt,
+		URL:          "https://api.integration.openshift.com",
+		TokenURL:     sdk.DefaultTokenURL,
+		ClientID:     sdk.DefaultClientID,
+		AWSAccountID: "896164604406",
+	},
+	OCMEnvProdGov: {
+		Name:     OCMEnvProdGov,
+		URL:      "https://api.openshiftusgov.com",
+		TokenURL: "https://sso.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token",
+		ClientID: "console-dot",
+	},
+}
+
+// OCMEnvironmentNames returns the sorted names of the known OCM environments.
+func OCMEnvironmentNames() []string {
+	names := make([]string, 0, len(ocmEnvironments))
+	for name := range ocmEnvironments {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetOCMEnvironment returns the OCM environment with the given name.
+func GetOCMEnvironment(name string) (OCMEnvironment, bool) {
+	env, ok := ocmEnvironments[OCMEnv(name)]
+	return env, ok
+}
+
+// GetOCMEnvironmentFromURL returns the OCM environment that serves the given API URL. URLs that
+// don't match any of the known environments exactly are classified by their host name, and
+// default to production.
+func GetOCMEnvironmentFromURL(url string) OCMEnvironment {
+	url = strings.TrimSuffix(url, "/")
+	for _, env := range ocmEnvironments {
+		if env.URL == url {
+			return env
+		}
+	}
+	switch {
+	case strings.Contains(url, "openshiftusgov"):
+		return ocmEnvironments[OCMEnvProdGov]
+	case strings.Contains(url, "stage"):
+		return ocmEnvironments[OCMEnvStage]
+	case strings.Contains(url, "integration"):
+		return ocmEnvironments[OCMEnvInt]
+	}
+	return ocmEnvironments[OCMEnvProd]
 }
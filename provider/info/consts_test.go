@@ -0,0 +1,32 @@
+package info
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OCM environments", func() {
+	It("Lists the environment names sorted", func() {
+		Expect(OCMEnvironmentNames()).To(Equal([]string{"integration", "production", "production-gov", "stage"}))
+	})
+
+	It("Resolves an environment by name", func() {
+		env, ok := GetOCMEnvironment("stage")
+		Expect(ok).To(BeTrue())
+		Expect(env.URL).To(Equal("https://api.stage.openshift.com"))
+		Expect(env.AWSAccountID).To(Equal("644306948063"))
+
+		_, ok = GetOCMEnvironment("unknown")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Resolves an environment by exact URL", func() {
+		Expect(GetOCMEnvironmentFromURL("https://api.integration.openshift.com/").Name).To(Equal(OCMEnvInt))
+		Expect(GetOCMEnvironmentFromURL("https://api.openshiftusgov.com").Name).To(Equal(OCMEnvProdGov))
+	})
+
+	It("Classifies unknown URLs by host name", func() {
+		Expect(GetOCMEnvironmentFromURL("https://api.stage.example.com").Name).To(Equal(OCMEnvStage))
+		Expect(GetOCMEnvironmentFromURL("http://127.0.0.1:8000").Name).To(Equal(OCMEnvProd))
+	})
+})
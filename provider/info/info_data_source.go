@@ -18,13 +18,13 @@ package info
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type OCMInfoDataSource struct {
@@ -84,6 +84,10 @@ func (d *OCMInfoDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "OCM AWS account ID",
 				Computed:    true,
 			},
+			"ocm_environment": schema.StringAttribute{
+				Description: "Name of the OCM environment serving the OCM API url",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -95,7 +99,7 @@ func (d *OCMInfoDataSource) Configure(ctx context.Context, req datasource.Config
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	d.collection = connection.AccountsMgmt().V1().CurrentAccount()
@@ -131,21 +135,12 @@ func (d *OCMInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	state.OrganizationExternalID = types.StringValue(obj.Organization().ExternalID())
 	state.OrganizationName = types.StringValue(obj.Organization().Name())
 
+	ocmEnv := GetOCMEnvironmentFromURL(d.ocmAPI)
 	state.OCMAPI = types.StringValue(d.ocmAPI)
-	state.OCMAWSAccountID = types.StringValue(extractOCMAWSAccount(d.ocmAPI))
+	state.OCMAWSAccountID = common.EmptiableStringToStringType(ocmEnv.AWSAccountID)
+	state.OCMEnvironment = types.StringValue(string(ocmEnv.Name))
 
 	// Save the state:
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
-
-func extractOCMAWSAccount(url string) string {
-	env := ocmEnvProd
-	if strings.Contains(url, "stage") {
-		env = ocmEnvStage
-	} else if strings.Contains(url, "integration") {
-		env = ocmEnvInt
-	}
-
-	return ocmAWSAccounts[env]
-}
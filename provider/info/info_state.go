@@ -28,4 +28,5 @@ type OCMInfoState struct {
 
 	OCMAWSAccountID types.String `tfsdk:"ocm_aws_account_id"`
 	OCMAPI          types.String `tfsdk:"ocm_api"`
+	OCMEnvironment  types.String `tfsdk:"ocm_environment"`
 }
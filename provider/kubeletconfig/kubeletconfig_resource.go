@@ -31,7 +31,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/openshift-online/ocm-common/pkg/ocm/client"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
@@ -69,7 +68,7 @@ func (k *KubeletConfigResource) Metadata(_ context.Context, request resource.Met
 	response.TypeName = request.ProviderTypeName + resourceTypeName
 }
 
-func (k *KubeletConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (k *KubeletConfigResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 
 	resp.Schema = schema.Schema{
 		Description: "KubeletConfig allows setting a customized Kubelet configuration",
@@ -100,6 +99,9 @@ func (k *KubeletConfigResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description: "Name of the KubeletConfig." + common.ValueCannotBeChangedStringDescription,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.CreateTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -117,17 +119,25 @@ func (k *KubeletConfigResource) Create(ctx context.Context, req resource.CreateR
 
 	isHCP, err := isHCP(ctx, clusterId, k.clusterClient)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't check cluster is HyperShift or not",
 			err.Error(),
+			err,
 		)
 		return
 	}
-	waitTimeoutInMinutes := int64(60)
-	if _, err := k.clusterWait.WaitForClusterToBeReady(ctx, clusterId, waitTimeoutInMinutes); err != nil {
-		resp.Diagnostics.AddError(
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, err := k.clusterWait.WaitForClusterToBeReady(ctx, clusterId, timeout); err != nil {
+		common.AddError(
+			&resp.Diagnostics,
 			"Cluster is not ready",
 			fmt.Sprintf("Cluster with id '%s' is not in the ready state: %v", clusterId, err),
+			err,
 		)
 		return
 	}
@@ -135,7 +145,7 @@ func (k *KubeletConfigResource) Create(ctx context.Context, req resource.CreateR
 	if !isHCP {
 		configs, _, err := k.configsClient.List(ctx, clusterId, client.NewPaging(1, -1))
 		if err != nil {
-			resp.Diagnostics.AddError(failedToCreateSummary, err.Error())
+			common.AddError(&resp.Diagnostics, failedToCreateSummary, err.Error(), err)
 			return
 		}
 		if len(configs) > 0 {
@@ -147,15 +157,15 @@ func (k *KubeletConfigResource) Create(ctx context.Context, req resource.CreateR
 
 	kubeletConfig, err := k.convertStateToApiResource(plan)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToCreateSummary,
-			fmt.Sprintf("Failed to build request to create KubeletConfig on cluster '%s': %v", clusterId, err))
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to build request to create KubeletConfig on cluster '%s': %v", clusterId, err), err)
 		return
 	}
 
 	createdConfig, err := k.configsClient.Create(ctx, clusterId, kubeletConfig)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToCreateSummary,
-			fmt.Sprintf("Failed to create KubeletConfig on cluster '%s': %v", clusterId, err))
+		common.AddError(&resp.Diagnostics, failedToCreateSummary,
+			fmt.Sprintf("Failed to create KubeletConfig on cluster '%s': %v", clusterId, err), err)
 		return
 	}
 
@@ -209,14 +219,14 @@ func (k *KubeletConfigResource) Read(ctx context.Context, req resource.ReadReque
 	name := state.Name.ValueString()
 	kubeletConfigId, err := getKubeletConfigId(ctx, state, clusterId, name, k.configsClient)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToReadSummary, err.Error())
+		common.AddError(&resp.Diagnostics, failedToReadSummary, err.Error(), err)
 		return
 	}
 
 	exists, kubeletConfig, err := k.configsClient.Exists(ctx, clusterId, kubeletConfigId)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToReadSummary,
-			fmt.Sprintf("Cannot read KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err))
+		common.AddError(&resp.Diagnostics, failedToReadSummary,
+			fmt.Sprintf("Cannot read KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err), err)
 		return
 	}
 
@@ -266,29 +276,29 @@ func (k *KubeletConfigResource) Update(ctx context.Context, req resource.UpdateR
 	name := state.Name.ValueString()
 	kubeletConfigId, err := getKubeletConfigId(ctx, state, clusterId, name, k.configsClient)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToUpdateSummary, err.Error())
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary, err.Error(), err)
 		return
 	}
 	if _, err := k.configsClient.Get(ctx, clusterId, kubeletConfigId); err != nil {
-		resp.Diagnostics.AddError(failedToUpdateSummary,
-			fmt.Sprintf("Failed to read details of existing KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err))
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary,
+			fmt.Sprintf("Failed to read details of existing KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err), err)
 		return
 	}
 
 	plan.ID = types.StringValue(kubeletConfigId)
 	kubeletConfig, err := k.convertStateToApiResource(plan)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToUpdateSummary,
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary,
 			fmt.Sprintf("Failed to build request to update existing KubeletConfig '%s' for cluster '%s': %v",
-				kubeletConfigId, clusterId, err))
+				kubeletConfigId, clusterId, err), err)
 		return
 	}
 
 	updateResponse, err := k.configsClient.Update(ctx, clusterId, kubeletConfig)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToUpdateSummary,
+		common.AddError(&resp.Diagnostics, failedToUpdateSummary,
 			fmt.Sprintf("Failed to update existing KubeletConfig '%s' for cluster '%s': %v",
-				kubeletConfigId, clusterId, err))
+				kubeletConfigId, clusterId, err), err)
 		return
 	}
 
@@ -306,14 +316,14 @@ func (k *KubeletConfigResource) Delete(ctx context.Context, req resource.DeleteR
 	name := state.Name.ValueString()
 	kubeletConfigId, err := getKubeletConfigId(ctx, state, clusterId, name, k.configsClient)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToDeleteSummary, err.Error())
+		common.AddError(&resp.Diagnostics, failedToDeleteSummary, err.Error(), err)
 		return
 	}
 
 	err = k.configsClient.Delete(ctx, clusterId, kubeletConfigId)
 	if err != nil {
-		resp.Diagnostics.AddError(failedToDeleteSummary,
-			fmt.Sprintf("Failed to delete KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err))
+		common.AddError(&resp.Diagnostics, failedToDeleteSummary,
+			fmt.Sprintf("Failed to delete KubeletConfig '%s' for cluster '%s': %v", kubeletConfigId, clusterId, err), err)
 		return
 	}
 
@@ -326,19 +336,21 @@ func (k *KubeletConfigResource) Configure(_ context.Context, req resource.Config
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	clusterCollection := connection.ClustersMgmt().V1().Clusters()
 	k.clusterClient = common.NewClusterClient(clusterCollection)
 	k.configsClient = client.NewKubeletConfigsClient(clusterCollection)
-	k.clusterWait = common.NewClusterWait(clusterCollection, connection)
+	k.clusterWait = common.NewClusterWait(clusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func isHCP(ctx context.Context, clusterId string, clusterClient common.ClusterClient) (bool, error) {
@@ -107,7 +107,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 
 	Context("Create", func() {
 
-		waitTimeoutInMinutes := int64(60)
+		waitTimeout := common.DefaultTimeout
 
 		var plan tfsdk.Plan
 		var state tfsdk.State
@@ -132,7 +132,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 		})
 
 		It("Creates KubeletConfig", func() {
-			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeoutInMinutes).Return(&cmv1.Cluster{}, nil)
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeout).Return(&cmv1.Cluster{}, nil)
 			clusterClient.EXPECT().FetchCluster(gomock.Any(), gomock.Eq(clusterId)).Return(classicCluster, nil)
 			configsClient.EXPECT().List(gomock.Eq(ctx), gomock.Eq(clusterId), gomock.Any()).Return([]*v1.KubeletConfig{}, false, nil)
 			configsClient.EXPECT().Create(
@@ -143,7 +143,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 		})
 
 		It("Does not create KubeletConfig if the cluster is not ready", func() {
-			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeoutInMinutes).Return(
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeout).Return(
 				nil, fmt.Errorf("cluster is not ready"))
 			clusterClient.EXPECT().FetchCluster(gomock.Any(), gomock.Eq(clusterId)).Return(hcpCluster, nil)
 
@@ -152,7 +152,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 		})
 
 		It("Does not create KubeletConfig if it already exists in classic cluster", func() {
-			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeoutInMinutes).Return(&cmv1.Cluster{}, nil)
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeout).Return(&cmv1.Cluster{}, nil)
 			clusterClient.EXPECT().FetchCluster(gomock.Any(), gomock.Eq(clusterId)).Return(classicCluster, nil)
 			configsClient.EXPECT().List(gomock.Eq(ctx), gomock.Eq(clusterId), gomock.Any()).
 				Return([]*v1.KubeletConfig{returnedKubeletConfig}, true, nil)
@@ -162,7 +162,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 		})
 
 		It("Creates the second KubeletConfig for HCP cluster", func() {
-			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeoutInMinutes).Return(&cmv1.Cluster{}, nil)
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeout).Return(&cmv1.Cluster{}, nil)
 			clusterClient.EXPECT().FetchCluster(gomock.Any(), gomock.Eq(clusterId)).Return(hcpCluster, nil)
 			configsClient.EXPECT().Create(
 				gomock.Eq(ctx), gomock.Eq(clusterId), test.MatchKubeletConfig(kubeletConfig)).Return(returnedKubeletConfig, nil)
@@ -172,7 +172,7 @@ var _ = Describe("KubeletConfig Resource", func() {
 		})
 
 		It("Fails the plan if cannot create KubeletConfig", func() {
-			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeoutInMinutes).Return(&cmv1.Cluster{}, nil)
+			clusterWait.EXPECT().WaitForClusterToBeReady(gomock.Eq(ctx), gomock.Eq(clusterId), waitTimeout).Return(&cmv1.Cluster{}, nil)
 			clusterClient.EXPECT().FetchCluster(gomock.Any(), gomock.Eq(clusterId)).Return(classicCluster, nil)
 			configsClient.EXPECT().List(gomock.Eq(ctx), gomock.Eq(clusterId), gomock.Any()).
 				Return([]*v1.KubeletConfig{returnedKubeletConfig}, true, nil)
@@ -340,11 +340,14 @@ func createState(ctx context.Context, resource KubeletConfigResource) tfsdk.Stat
 	configName, err := types.StringUnknown().ToTerraformValue(ctx)
 	Expect(err).NotTo(HaveOccurred())
 
+	timeouts := tftypes.NewValue(response.Schema.Blocks["timeouts"].Type().TerraformType(ctx), nil)
+
 	state := map[string]tftypes.Value{
 		"cluster":        cluster,
 		"pod_pids_limit": pids,
 		"id":             configId,
 		"name":           configName,
+		"timeouts":       timeouts,
 	}
 
 	return tfsdk.State{
@@ -367,11 +370,14 @@ func createPlan(ctx context.Context, resource KubeletConfigResource, requiredPid
 	configName, err := types.StringUnknown().ToTerraformValue(ctx)
 	Expect(err).NotTo(HaveOccurred())
 
+	timeouts := tftypes.NewValue(response.Schema.Blocks["timeouts"].Type().TerraformType(ctx), nil)
+
 	state := map[string]tftypes.Value{
 		"cluster":        cluster,
 		"pod_pids_limit": pids,
 		"id":             configId,
 		"name":           configName,
+		"timeouts":       timeouts,
 	}
 
 	return tfsdk.Plan{
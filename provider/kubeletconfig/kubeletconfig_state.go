@@ -16,11 +16,15 @@ limitations under the License.
 
 package kubeletconfig
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type KubeletConfigState struct {
-	ID           types.String `tfsdk:"id"`
-	Cluster      types.String `tfsdk:"cluster"`
-	PodPidsLimit types.Int64  `tfsdk:"pod_pids_limit"`
-	Name         types.String `tfsdk:"name"`
+	ID           types.String   `tfsdk:"id"`
+	Cluster      types.String   `tfsdk:"cluster"`
+	PodPidsLimit types.Int64    `tfsdk:"pod_pids_limit"`
+	Name         types.String   `tfsdk:"name"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
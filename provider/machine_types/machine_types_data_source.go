@@ -24,8 +24,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type MachineTypesDataSource struct {
@@ -86,7 +87,7 @@ func (s *MachineTypesDataSource) Configure(ctx context.Context, req datasource.C
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	s.collection = connection.ClustersMgmt().V1().MachineTypes()
@@ -101,9 +102,11 @@ func (s *MachineTypesDataSource) Read(ctx context.Context, req datasource.ReadRe
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Can't list machine types",
 				err.Error(),
+				err,
 			)
 			return
 		}
@@ -0,0 +1,13 @@
+package classic
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMachinePool(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Classic Machine Pool Suite")
+}
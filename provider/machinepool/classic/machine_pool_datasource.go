@@ -26,12 +26,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type MachinePoolDatasource struct {
-	collection *cmv1.ClustersClient
+	collection    *cmv1.ClustersClient
+	clusterClient common.ClusterClient
+	machinePools  common.MachinePoolsClient
 }
 
 var _ datasource.DataSource = &MachinePoolDatasource{}
@@ -50,16 +53,19 @@ func (r *MachinePoolDatasource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterClient = common.NewClusterClient(r.collection)
+	r.machinePools = common.NewMachinePoolsClient(r.collection)
 }
 
 func (r *MachinePoolDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -165,6 +171,11 @@ func (r *MachinePoolDatasource) Schema(ctx context.Context, req datasource.Schem
 				ElementType: types.StringType,
 				Computed:    true,
 			},
+			"aws_tags": schema.MapAttribute{
+				Description: "User defined tags of the machine pool resources created in AWS.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"ignore_deletion_error": schema.BoolAttribute{
 				Description: "Indicates to the provider to disregard API errors when deleting the machine pool." +
 					" This will remove the resource from the management file, but not necessirely delete the underlying pool in case it errors." +
@@ -172,21 +183,24 @@ func (r *MachinePoolDatasource) Schema(ctx context.Context, req datasource.Schem
 					" This is not recommended to be set in other use cases",
 				Computed: true,
 			},
+			"timeouts": common.TimeoutsDataSourceAttribute(ctx,
+				"Timeouts of the operations of the machine pool resource, always null for the data source."),
 		},
 	}
 }
 
 func (r *MachinePoolDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Get the current state:
+	// The data source has all the attributes of the resource except the merged tags:
 	state := &MachinePoolState{}
-	diags := req.Config.Get(ctx, state)
+	diags := req.Config.Get(ctx, &state.machinePoolAttributes)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	state.ID = state.Name
 
-	notFound, diags := readState(ctx, state, r.collection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.machinePools)
 	if notFound {
 		diags.AddError(
 			"Failed to find machine pool",
@@ -203,6 +217,7 @@ func (r *MachinePoolDatasource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	state.IgnoreDeletionError = types.BoolNull()
+	state.Timeouts = common.NullTimeouts()
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state.machinePoolAttributes)...)
 }
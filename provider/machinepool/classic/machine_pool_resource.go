@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"regexp"
 	"strings"
 
@@ -43,7 +42,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	diskValidator "github.com/openshift-online/ocm-common/pkg/machinepool/validations"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
@@ -58,12 +56,22 @@ var machinepoolNameRE = regexp.MustCompile(
 
 type MachinePoolResource struct {
 	clusterCollection *cmv1.ClustersClient
+	clusterClient     common.ClusterClient
+	machinePools      common.MachinePoolsClient
 	clusterWait       common.ClusterWait
+	defaultTags       map[string]string
 }
 
 var _ resource.ResourceWithConfigure = &MachinePoolResource{}
 var _ resource.ResourceWithImportState = &MachinePoolResource{}
 var _ resource.ResourceWithConfigValidators = &MachinePoolResource{}
+var _ resource.ResourceWithModifyPlan = &MachinePoolResource{}
+
+// createErrorAttributes are the attributes that the errors returned by OCM when the machine pool is
+// created can point to.
+var createErrorAttributes = []common.ErrorAttribute{
+	{Kind: common.SubnetsError, Path: path.Root("subnet_id")},
+}
 
 func New() resource.Resource {
 	return &MachinePoolResource{}
@@ -76,6 +84,7 @@ func (r *MachinePoolResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Machine pool.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"cluster": schema.StringAttribute{
 				Description: "Identifier of the cluster. " + common.ValueCannotBeChangedStringDescription,
@@ -217,6 +226,11 @@ func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"aws_tags_all": schema.MapAttribute{
+				Description: common.TagsAllDescription,
+				ElementType: types.StringType,
+				Computed:    true,
+			},
 			"ignore_deletion_error": schema.BoolAttribute{
 				Description: "Indicates to the provider to disregard API errors when deleting the machine pool." +
 					" This will remove the resource from the management file, but not necessirely delete the underlying pool in case it errors." +
@@ -227,6 +241,9 @@ func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 				Default:  booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -244,17 +261,28 @@ func (r *MachinePoolResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
-	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.clusterClient = common.NewClusterClient(r.clusterCollection)
+	r.machinePools = common.NewMachinePoolsClient(r.clusterCollection)
+	r.clusterWait = common.NewClusterWait(r.clusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.defaultTags = providerData.DefaultTags
+}
+
+// ModifyPlan calculates the effective AWS tags of the machine pool, merging the default tags of
+// the provider, so that they are visible in the plan.
+func (r *MachinePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.ModifyPlanTagsAll(ctx, req, resp, r.defaultTags, path.Root("aws_tags"), path.Root("aws_tags_all"), "machine pool")
 }
 
 func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -278,15 +306,21 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Wait till the cluster is ready:
-	waitTimeoutInMinutes := int64(60)
-	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), waitTimeoutInMinutes)
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	cluster, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), timeout)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot poll cluster state",
 			fmt.Sprintf(
 				"Cannot poll state of cluster with identifier '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -307,12 +341,14 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 		// Get cluster version to pass to disk size validator
 		getCluster, err := resource.Get().SendContext(ctx)
 		if err != nil {
-			diags.AddError(
+			common.AddError(
+				&diags,
 				"Can't find cluster",
 				fmt.Sprintf(
 					"Can't find cluster with identifier '%s': %v",
 					plan.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -322,9 +358,11 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 			int(*workerDiskSize),
 		)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot build machine pool",
 				err.Error(),
+				err,
 			)
 			return
 		}
@@ -338,23 +376,27 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 
 	awsMachinePoolBuilder, err := setSpotInstances(plan)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot build machine pool",
 			fmt.Sprintf(
 				"Cannot build machine pool for cluster '%s: %v'", plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
 	isMultiAZPool, err := r.validateAZConfig(cluster, plan)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot build machine pool",
 			fmt.Sprintf(
 				"Cannot build machine pool for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -370,27 +412,32 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 		}
 		additionalSecurityGroupIds, err := common.StringListToArray(ctx, plan.AdditionalSecurityGroupIds)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot convert Additional Security Groups to slice",
 				fmt.Sprintf(
 					"Cannot convert Additional Security Groups to slice for cluster '%s: %v'", plan.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
 		awsMachinePoolBuilder.AdditionalSecurityGroupIds(additionalSecurityGroupIds...)
 	}
-	if common.HasValue(plan.AwsTags) {
+	// The plan contains the tags merged with the default tags of the provider:
+	if common.HasValue(plan.AwsTagsAll) {
 		if awsMachinePoolBuilder == nil {
 			awsMachinePoolBuilder = cmv1.NewAWSMachinePool()
 		}
-		awsTags, err := common.OptionalMap(ctx, plan.AwsTags)
+		awsTags, err := common.OptionalMap(ctx, plan.AwsTagsAll)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot convert AWS tags map object to string map",
 				fmt.Sprintf(
 					"Cannot convert AWS tags map object to string map for cluster '%s: %v'", plan.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -459,38 +506,43 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 
 	object, err := builder.Build()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot build machine pool",
 			fmt.Sprintf(
 				"Cannot build machine pool for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
-	collection := resource.MachinePools()
-	add, err := collection.Add().Body(object).Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	object, err = r.machinePools.Create(ctx, plan.Cluster.ValueString(), object)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot create machine pool",
 			fmt.Sprintf(
 				"Cannot create machine pool for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
+			createErrorAttributes...,
 		)
 		return
 	}
-	object = add.Body()
 
 	// Save the state:
 	err = populateState(ctx, object, plan, cluster)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -504,14 +556,17 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 func (r *MachinePoolResource) magicImport(ctx context.Context, plan *MachinePoolState, resp *resource.CreateResponse) {
 	machinepoolName := plan.Name.ValueString()
 	state := &MachinePoolState{
-		ID:      types.StringValue(machinepoolName),
-		Cluster: plan.Cluster,
-		Name:    types.StringValue(machinepoolName),
+		machinePoolAttributes: machinePoolAttributes{
+			ID:      types.StringValue(machinepoolName),
+			Cluster: plan.Cluster,
+			Name:    types.StringValue(machinepoolName),
+		},
+		AwsTagsAll: types.MapNull(types.StringType),
 	}
 	plan.ID = types.StringValue(machinepoolName)
 	adjustInitialStateToPlan(state, plan)
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.machinePools)
 	if notFound {
 		// We disallow creating a machine pool with the default name. This
 		// case can only happen if the default machine pool was deleted and
@@ -549,7 +604,7 @@ func (r *MachinePoolResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.machinePools)
 	if notFound {
 		// If we can't find the machine pool, it was deleted. Remove if from the
 		// state and don't return an error so the TF apply() will automatically
@@ -568,63 +623,64 @@ func (r *MachinePoolResource) Read(ctx context.Context, req resource.ReadRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func fetchCluster(ctx context.Context, state *MachinePoolState, collection *cmv1.ClustersClient, diags *diag.Diagnostics) *cmv1.Cluster {
-	clusterResource := collection.Cluster(state.Cluster.ValueString())
-	getCluster, err := clusterResource.Get().SendContext(ctx)
+func fetchCluster(ctx context.Context, state *MachinePoolState, clusterClient common.ClusterClient, diags *diag.Diagnostics) *cmv1.Cluster {
+	exists, cluster, err := clusterClient.Exists(ctx, state.Cluster.ValueString())
 	if err != nil {
-		if getCluster.Status() == http.StatusNotFound {
-			tflog.Warn(ctx, fmt.Sprintf("cluster '%s' not found, clearing state",
-				state.Cluster.ValueString(),
-			))
-			return nil
-		}
-		diags.AddError(
+		common.AddError(
+			diags,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return nil
 	}
-	return getCluster.Body()
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("cluster '%s' not found, clearing state",
+			state.Cluster.ValueString(),
+		))
+		return nil
+	}
+	return cluster
 }
 
-func readState(ctx context.Context, state *MachinePoolState, collection *cmv1.ClustersClient) (poolNotFound bool, diags diag.Diagnostics) {
+func readState(ctx context.Context, state *MachinePoolState, clusterClient common.ClusterClient,
+	machinePools common.MachinePoolsClient) (poolNotFound bool, diags diag.Diagnostics) {
 	diags = diag.Diagnostics{}
 
-	clusterObject := fetchCluster(ctx, state, collection, &diags)
+	clusterObject := fetchCluster(ctx, state, clusterClient, &diags)
 	if clusterObject == nil {
 		return
 	}
 
-	resource := collection.Cluster(state.Cluster.ValueString()).
-		MachinePools().
-		MachinePool(state.ID.ValueString())
-	get, err := resource.Get().Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	exists, object, err := machinePools.Exists(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if get.Status() == http.StatusNotFound {
-			poolNotFound = true
-			return
-		}
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to fetch machine pool",
 			fmt.Sprintf(
-				"Failed to fetch machine pool with identifier %s for cluster %s. Response code: %v",
-				state.ID.ValueString(), state.Cluster.ValueString(), get.Status(),
+				"Failed to fetch machine pool with identifier %s for cluster %s: %v",
+				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-
-	object := get.Body()
+	if !exists {
+		poolNotFound = true
+		return
+	}
 	err = populateState(ctx, object, state, clusterObject)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -676,11 +732,18 @@ func (r *MachinePoolResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = r.doUpdate(ctx, state, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Timeouts = plan.Timeouts
 
 	// Save the state:
 	diags = resp.State.Set(ctx, state)
@@ -694,24 +757,23 @@ func (r *MachinePoolResource) doUpdate(ctx context.Context, state *MachinePoolSt
 		return diags
 	}
 
-	clusterObject := fetchCluster(ctx, plan, r.clusterCollection, &diags)
+	clusterObject := fetchCluster(ctx, plan, r.clusterClient, &diags)
 	if clusterObject == nil {
 		return diags
 	}
 
-	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		MachinePools().
-		MachinePool(state.ID.ValueString())
-	_, err := resource.Get().Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	_, err := r.machinePools.Get(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Cannot find machine pool",
 			fmt.Sprintf(
 				"Cannot find machine pool with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
@@ -794,39 +856,41 @@ func (r *MachinePoolResource) doUpdate(ctx context.Context, state *MachinePoolSt
 
 	machinePool, err := mpBuilder.Build()
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Cannot update machine pool",
 			fmt.Sprintf(
 				"Cannot update machine pool for cluster '%s: %v ", state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
-	update, err := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		MachinePools().
-		MachinePool(state.ID.ValueString()).Update().Parameter("fetchUserTagsOnly", true).Body(machinePool).SendContext(ctx)
+	object, err := r.machinePools.Update(ctx, state.Cluster.ValueString(), machinePool)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update machine pool",
 			fmt.Sprintf(
 				"Failed to update machine pool '%s'  on cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
 
-	object := update.Body()
-
 	adjustInitialStateToPlan(state, plan)
 	// Save the state:
 	err = populateState(ctx, object, state, clusterObject)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return diags
 	}
@@ -844,6 +908,9 @@ func adjustInitialStateToPlan(state, plan *MachinePoolState) {
 	if common.HasValue(plan.AwsTags) {
 		state.AwsTags = plan.AwsTags
 	}
+	if common.HasValue(plan.AwsTagsAll) {
+		state.AwsTagsAll = plan.AwsTagsAll
+	}
 }
 
 // Validate the machine pool's settings that pertain to availability zones.
@@ -958,11 +1025,14 @@ func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ctx, cancel := common.WithDeleteTimeout(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request to delete the machine pool:
-	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		MachinePools().
-		MachinePool(state.ID.ValueString())
-	_, err := resource.Delete().SendContext(ctx)
+	err := r.machinePools.Delete(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
 		if common.BoolWithFalseDefault(state.IgnoreDeletionError) {
 			resp.Diagnostics.AddWarning(
@@ -997,13 +1067,15 @@ func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 			return
 		} else {
 			// Wasn't the last one, return error
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot delete machine pool",
 				fmt.Sprintf(
 					"Cannot delete machine pool with identifier '%s' for "+
 						"cluster '%s': %v",
 					state.ID.ValueString(), state.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -1016,12 +1088,7 @@ func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 
 // countPools returns the number of machine pools in the given cluster
 func (r *MachinePoolResource) countPools(ctx context.Context, clusterID string) (int, error) {
-	resource := r.clusterCollection.Cluster(clusterID).MachinePools()
-	resp, err := resource.List().SendContext(ctx)
-	if err != nil {
-		return 0, err
-	}
-	return resp.Size(), nil
+	return r.machinePools.Count(ctx, clusterID)
 }
 
 func (r *MachinePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -1180,7 +1247,16 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *MachinePoo
 	if len(awsTags) == 0 {
 		return awsTags, nil
 	}
-	if cluster.AWS() == nil || len(cluster.AWS().Tags()) == 0 {
+	// Tags inherited from the cluster or from the default tags of the provider aren't part of
+	// the machine pool input:
+	inheritedTags, err := common.OptionalMap(ctx, state.AwsTagsAll)
+	if err != nil {
+		return awsTags, err
+	}
+	if cluster.AWS() != nil {
+		inheritedTags = common.MergeStringMaps(inheritedTags, cluster.AWS().Tags())
+	}
+	if len(inheritedTags) == 0 {
 		return awsTags, nil
 	}
 	filteredTags := make(map[string]string, len(awsTags))
@@ -1191,8 +1267,7 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *MachinePoo
 	if err != nil {
 		return filteredTags, err
 	}
-	clusterTags := cluster.AWS().Tags()
-	for k := range clusterTags {
+	for k := range inheritedTags {
 		if _, ok := currentNpTfTags[k]; !ok {
 			delete(filteredTags, k)
 		}
@@ -0,0 +1,160 @@
+package classic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	clusterId     = "123"
+	machinePoolId = "pool"
+)
+
+var _ = Describe("Machine pool update", func() {
+	var (
+		ctx           context.Context
+		clusterClient *common.MockClusterClient
+		machinePools  *common.MockMachinePoolsClient
+		r             *MachinePoolResource
+		state         *MachinePoolState
+		plan          *MachinePoolState
+	)
+
+	// newState returns the state of a machine pool with two replicas in a single zone.
+	newState := func() *MachinePoolState {
+		state := &MachinePoolState{
+			AwsTagsAll: types.MapNull(types.StringType),
+		}
+		state.Cluster = types.StringValue(clusterId)
+		state.ID = types.StringValue(machinePoolId)
+		state.Name = types.StringValue(machinePoolId)
+		state.MachineType = types.StringValue("m5.xlarge")
+		state.Replicas = types.Int64Value(2)
+		state.AutoScalingEnabled = types.BoolValue(false)
+		state.Labels = types.MapNull(types.StringType)
+		state.AwsTags = types.MapNull(types.StringType)
+		state.AdditionalSecurityGroupIds = types.ListNull(types.StringType)
+		return state
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		clusterClient = common.NewMockClusterClient(ctrl)
+		machinePools = common.NewMockMachinePoolsClient(ctrl)
+		r = &MachinePoolResource{
+			clusterClient: clusterClient,
+			machinePools:  machinePools,
+		}
+		state = newState()
+		plan = newState()
+	})
+
+	// expectPool makes the cluster and the current machine pool exist.
+	expectPool := func() {
+		cluster, err := cmv1.NewCluster().ID(clusterId).Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusterClient.EXPECT().Exists(gomock.Any(), clusterId).Return(true, cluster, nil)
+		current, err := cmv1.NewMachinePool().ID(machinePoolId).Replicas(2).Build()
+		Expect(err).ToNot(HaveOccurred())
+		machinePools.EXPECT().Get(gomock.Any(), clusterId, machinePoolId).Return(current, nil)
+	}
+
+	// expectUpdate checks the pool sent to OCM and returns it in a single zone.
+	expectUpdate := func(check func(pool *cmv1.MachinePool)) {
+		machinePools.EXPECT().Update(gomock.Any(), clusterId, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, clusterId string, pool *cmv1.MachinePool) (*cmv1.MachinePool, error) {
+				check(pool)
+				return cmv1.NewMachinePool().Copy(pool).
+					InstanceType("m5.xlarge").
+					AvailabilityZones("us-east-1a").
+					Build()
+			})
+	}
+
+	It("Updates the replicas and the labels", func() {
+		expectPool()
+		expectUpdate(func(pool *cmv1.MachinePool) {
+			Expect(pool.ID()).To(Equal(machinePoolId))
+			Expect(pool.Replicas()).To(Equal(3))
+			Expect(pool.Labels()).To(Equal(map[string]string{"role": "db"}))
+		})
+		plan.Replicas = types.Int64Value(3)
+		plan.Labels = types.MapValueMust(types.StringType, map[string]attr.Value{
+			"role": types.StringValue("db"),
+		})
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(state.Replicas.ValueInt64()).To(BeEquivalentTo(3))
+		Expect(state.Labels.Elements()).To(HaveKeyWithValue("role", types.StringValue("db")))
+		Expect(state.AvailabilityZone.ValueString()).To(Equal("us-east-1a"))
+	})
+
+	It("Enables the autoscaling", func() {
+		expectPool()
+		expectUpdate(func(pool *cmv1.MachinePool) {
+			Expect(pool.Autoscaling().MinReplicas()).To(Equal(1))
+			Expect(pool.Autoscaling().MaxReplicas()).To(Equal(4))
+			_, ok := pool.GetReplicas()
+			Expect(ok).To(BeFalse())
+		})
+		plan.Replicas = types.Int64Null()
+		plan.AutoScalingEnabled = types.BoolValue(true)
+		plan.MinReplicas = types.Int64Value(1)
+		plan.MaxReplicas = types.Int64Value(4)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(state.AutoScalingEnabled.ValueBool()).To(BeTrue())
+		Expect(state.MaxReplicas.ValueInt64()).To(BeEquivalentTo(4))
+	})
+
+	It("Rejects the change of the machine type without calling OCM", func() {
+		plan.MachineType = types.StringValue("m5.2xlarge")
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+	})
+
+	It("Rejects the replicas combined with the autoscaling", func() {
+		expectPool()
+		plan.AutoScalingEnabled = types.BoolValue(true)
+		plan.MinReplicas = types.Int64Value(1)
+		plan.MaxReplicas = types.Int64Value(4)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Detail()).To(ContainSubstring("either replicas should be set or autoscaling enabled"))
+	})
+
+	It("Fails when the machine pool can't be found", func() {
+		cluster, err := cmv1.NewCluster().ID(clusterId).Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusterClient.EXPECT().Exists(gomock.Any(), clusterId).Return(true, cluster, nil)
+		machinePools.EXPECT().Get(gomock.Any(), clusterId, machinePoolId).Return(nil, errors.New("not found"))
+		plan.Replicas = types.Int64Value(3)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Summary()).To(Equal("Cannot find machine pool"))
+	})
+
+	It("Reports the errors of the update", func() {
+		expectPool()
+		machinePools.EXPECT().Update(gomock.Any(), clusterId, gomock.Any()).Return(nil, errors.New("quota exceeded"))
+		plan.Replicas = types.Int64Value(3)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Detail()).To(ContainSubstring("quota exceeded"))
+	})
+})
@@ -17,10 +17,18 @@ limitations under the License.
 package classic
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type MachinePoolState struct {
+	machinePoolAttributes
+
+	AwsTagsAll types.Map `tfsdk:"aws_tags_all"`
+}
+
+// machinePoolAttributes are the attributes shared by the machine pool resource and data source.
+type machinePoolAttributes struct {
 	Cluster                    types.String  `tfsdk:"cluster"`
 	ID                         types.String  `tfsdk:"id"`
 	MachineType                types.String  `tfsdk:"machine_type"`
@@ -42,6 +50,8 @@ type MachinePoolState struct {
 	AdditionalSecurityGroupIds types.List    `tfsdk:"aws_additional_security_group_ids"`
 	AwsTags                    types.Map     `tfsdk:"aws_tags"`
 	IgnoreDeletionError        types.Bool    `tfsdk:"ignore_deletion_error"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Taints struct {
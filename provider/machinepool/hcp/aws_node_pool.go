@@ -17,6 +17,13 @@ import (
 const MaxAdditionalSecurityGroupHcp = 10
 
 type AWSNodePool struct {
+	awsNodePoolAttributes
+
+	TagsAll types.Map `tfsdk:"tags_all"`
+}
+
+// awsNodePoolAttributes are the AWS settings shared by the machine pool resource and data source.
+type awsNodePoolAttributes struct {
 	InstanceType               types.String `tfsdk:"instance_type"`
 	InstanceProfile            types.String `tfsdk:"instance_profile"`
 	Tags                       types.Map    `tfsdk:"tags"`
@@ -46,6 +53,11 @@ func AwsNodePoolResource() map[string]schema.Attribute {
 			ElementType: types.StringType,
 			Optional:    true,
 		},
+		"tags_all": schema.MapAttribute{
+			Description: common.TagsAllDescription,
+			ElementType: types.StringType,
+			Computed:    true,
+		},
 		"additional_security_group_ids": schema.ListAttribute{
 			Description: "Additional security group ids. " + common.ValueCannotBeChangedStringDescription,
 			ElementType: types.StringType,
@@ -27,13 +27,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type HcpMachinePoolDatasource struct {
-	collection *cmv1.ClustersClient
+	collection    *cmv1.ClustersClient
+	clusterClient common.ClusterClient
+	nodePools     common.NodePoolsClient
 }
 
 var _ datasource.DataSource = &HcpMachinePoolDatasource{}
@@ -52,16 +53,19 @@ func (r *HcpMachinePoolDatasource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
+	r.clusterClient = common.NewClusterClient(r.collection)
+	r.nodePools = common.NewNodePoolsClient(r.collection)
 }
 
 func (r *HcpMachinePoolDatasource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -179,21 +183,26 @@ func (r *HcpMachinePoolDatasource) Schema(ctx context.Context, req datasource.Sc
 					" This is not recommended to be set in other use cases",
 				Computed: true,
 			},
+			"timeouts": common.TimeoutsDataSourceAttribute(ctx,
+				"Timeouts of the operations of the machine pool resource, always null for the data source."),
 		},
 	}
 }
 
 func (r *HcpMachinePoolDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	// Get the current state:
-	state := &HcpMachinePoolState{}
-	diags := req.Config.Get(ctx, state)
+	config := &hcpMachinePoolDataSourceState{}
+	diags := req.Config.Get(ctx, config)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state := &HcpMachinePoolState{
+		hcpMachinePoolAttributes: config.hcpMachinePoolAttributes,
+	}
 	state.ID = state.Name
 
-	notFound, diags := readState(ctx, state, r.collection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.nodePools)
 	if notFound {
 		diags.AddError(
 			"Failed to find machine pool",
@@ -212,6 +221,14 @@ func (r *HcpMachinePoolDatasource) Read(ctx context.Context, req datasource.Read
 	state.UpgradeAcksFor = types.StringNull()
 	state.Version = types.StringNull()
 	state.IgnoreDeletionError = types.BoolNull()
+	state.Timeouts = common.NullTimeouts()
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	// The data source has all the attributes of the resource except the merged tags:
+	result := &hcpMachinePoolDataSourceState{
+		hcpMachinePoolAttributes: state.hcpMachinePoolAttributes,
+	}
+	if state.AWSNodePool != nil {
+		result.AWSNodePool = &state.AWSNodePool.awsNodePoolAttributes
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
 }
@@ -19,7 +19,6 @@ package hcp
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"regexp"
 	"strings"
 	"time"
@@ -43,7 +42,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	diskValidator "github.com/openshift-online/ocm-common/pkg/machinepool/validations"
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	rosa "github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -63,13 +61,24 @@ var standardNodePoolRegex = regexp.MustCompile(
 
 type HcpMachinePoolResource struct {
 	clusterCollection *cmv1.ClustersClient
-	versionCollection *cmv1.VersionsClient
+	clusterClient     common.ClusterClient
+	nodePools         common.NodePoolsClient
+	versionsClient    common.VersionsClient
 	clusterWait       common.ClusterWait
+	defaultTags       map[string]string
 }
 
 var _ resource.ResourceWithConfigure = &HcpMachinePoolResource{}
 var _ resource.ResourceWithImportState = &HcpMachinePoolResource{}
 var _ resource.ResourceWithConfigValidators = &HcpMachinePoolResource{}
+var _ resource.ResourceWithModifyPlan = &HcpMachinePoolResource{}
+
+// createErrorAttributes are the attributes that the errors returned by OCM when the machine pool is
+// created can point to.
+var createErrorAttributes = []common.ErrorAttribute{
+	{Kind: common.SubnetsError, Path: path.Root("subnet_id")},
+	{Kind: common.VersionError, Path: path.Root("version")},
+}
 
 func New() resource.Resource {
 	return &HcpMachinePoolResource{}
@@ -82,6 +91,7 @@ func (r *HcpMachinePoolResource) Metadata(ctx context.Context, req resource.Meta
 func (r *HcpMachinePoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Machine pool.",
+		Version:     int64(len(stateUpgraders)),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier of the machine pool.",
@@ -218,6 +228,9 @@ func (r *HcpMachinePoolResource) Schema(ctx context.Context, req resource.Schema
 				Default:  booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -234,18 +247,30 @@ func (r *HcpMachinePoolResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.clusterCollection = connection.ClustersMgmt().V1().Clusters()
-	r.versionCollection = connection.ClustersMgmt().V1().Versions()
-	r.clusterWait = common.NewClusterWait(r.clusterCollection, connection)
+	r.clusterClient = common.NewClusterClient(r.clusterCollection)
+	r.nodePools = common.NewNodePoolsClient(r.clusterCollection)
+	r.versionsClient = common.NewVersionsClient(connection.ClustersMgmt().V1().Versions())
+	r.clusterWait = common.NewClusterWait(r.clusterCollection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
+	r.defaultTags = providerData.DefaultTags
+}
+
+// ModifyPlan calculates the effective AWS tags of the machine pool, merging the default tags of
+// the provider, so that they are visible in the plan.
+func (r *HcpMachinePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	awsNodePool := path.Root("aws_node_pool")
+	common.ModifyPlanTagsAll(ctx, req, resp, r.defaultTags, awsNodePool.AtName("tags"), awsNodePool.AtName("tags_all"), "machine pool")
 }
 
 func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -269,14 +294,21 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 	}
 
 	// Wait till the cluster is ready:
-	clusterObject, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), 60)
+	timeout, diags := plan.Timeouts.Create(ctx, common.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clusterObject, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), timeout)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot poll cluster state",
 			fmt.Sprintf(
 				"Cannot poll state of cluster with identifier '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -295,7 +327,8 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 	if plan.AWSNodePool != nil {
 		awsNodePoolBuilder := cmv1.NewAWSNodePool()
 		awsNodePoolBuilder.InstanceType(plan.AWSNodePool.InstanceType.ValueString())
-		awsTags, err := common.OptionalMap(ctx, plan.AWSNodePool.Tags)
+		// The plan contains the tags merged with the default tags of the provider:
+		awsTags, err := common.OptionalMap(ctx, plan.AWSNodePool.TagsAll)
 		if err != nil {
 			return
 		}
@@ -306,11 +339,13 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 		if common.HasValue(plan.AWSNodePool.AdditionalSecurityGroupIds) {
 			additionalSecurityGroupIds, err := common.StringListToArray(ctx, plan.AWSNodePool.AdditionalSecurityGroupIds)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				common.AddError(
+					&resp.Diagnostics,
 					"Cannot convert Additional Security Groups to slice",
 					fmt.Sprintf(
 						"Cannot convert Additional Security Groups to slice for cluster '%s: %v'", plan.Cluster.ValueString(), err,
 					),
+					err,
 				)
 				return
 			}
@@ -325,9 +360,11 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 		if workerDiskSize := common.OptionalInt64(plan.AWSNodePool.DiskSize); workerDiskSize != nil {
 			err := diskValidator.ValidateNodePoolRootDiskSize(int(*workerDiskSize))
 			if err != nil {
-				resp.Diagnostics.AddError(
+				common.AddError(
+					&resp.Diagnostics,
 					"Cannot build machine pool",
 					err.Error(),
+					err,
 				)
 				return
 			}
@@ -401,12 +438,14 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 	if common.HasValue(plan.TuningConfigs) {
 		tuningConfigs, err := common.StringListToArray(ctx, plan.TuningConfigs)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot build machine pool",
 				fmt.Sprintf(
 					"Cannot build tuning configs for machine pool pool for cluster '%s': %v",
 					plan.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -432,39 +471,43 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 
 	object, err := builder.Build()
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot build machine pool",
 			fmt.Sprintf(
 				"Cannot build machine pool for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
-	collection := r.clusterCollection.Cluster(clusterObject.ID()).NodePools()
-	add, err := collection.Add().Body(object).
-		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	object, err = r.nodePools.Create(ctx, clusterObject.ID(), object)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot create machine pool",
 			fmt.Sprintf(
 				"Cannot create machine pool for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
+			createErrorAttributes...,
 		)
 		return
 	}
-	object = add.Body()
 
 	// Save the state:
 	err = populateState(ctx, object, plan, clusterObject)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -478,14 +521,16 @@ func (r *HcpMachinePoolResource) Create(ctx context.Context, req resource.Create
 func (r *HcpMachinePoolResource) magicImport(ctx context.Context, plan *HcpMachinePoolState, resp *resource.CreateResponse) {
 	nodePoolName := plan.Name.ValueString()
 	state := &HcpMachinePoolState{
-		ID:      types.StringValue(nodePoolName),
-		Cluster: plan.Cluster,
-		Name:    types.StringValue(nodePoolName),
+		hcpMachinePoolAttributes: hcpMachinePoolAttributes{
+			ID:      types.StringValue(nodePoolName),
+			Cluster: plan.Cluster,
+			Name:    types.StringValue(nodePoolName),
+		},
 	}
 	plan.ID = types.StringValue(nodePoolName)
 	adjustInitialStateToPlan(state, plan)
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.nodePools)
 	if notFound {
 		// We disallow creating a machine pool with the default name. This
 		// case can only happen if the default machine pool was deleted and
@@ -523,7 +568,7 @@ func (r *HcpMachinePoolResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	notFound, diags := readState(ctx, state, r.clusterCollection)
+	notFound, diags := readState(ctx, state, r.clusterClient, r.nodePools)
 	if notFound {
 		// If we can't find the machine pool, it was deleted. Remove if from the
 		// state and don't return an error so the TF apply() will automatically
@@ -542,64 +587,65 @@ func (r *HcpMachinePoolResource) Read(ctx context.Context, req resource.ReadRequ
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func fetchCluster(ctx context.Context, state *HcpMachinePoolState, collection *cmv1.ClustersClient, diags *diag.Diagnostics) *cmv1.Cluster {
-	clusterResource := collection.Cluster(state.Cluster.ValueString())
-	getCluster, err := clusterResource.Get().SendContext(ctx)
+func fetchCluster(ctx context.Context, state *HcpMachinePoolState, clusterClient common.ClusterClient, diags *diag.Diagnostics) *cmv1.Cluster {
+	exists, cluster, err := clusterClient.Exists(ctx, state.Cluster.ValueString())
 	if err != nil {
-		if getCluster.Status() == http.StatusNotFound {
-			tflog.Warn(ctx, fmt.Sprintf("cluster '%s' not found, clearing state",
-				state.Cluster.ValueString(),
-			))
-			return nil
-		}
-		diags.AddError(
+		common.AddError(
+			diags,
 			"Can't find cluster",
 			fmt.Sprintf(
 				"Can't find cluster with identifier '%s': %v",
 				state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return nil
 	}
-	return getCluster.Body()
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("cluster '%s' not found, clearing state",
+			state.Cluster.ValueString(),
+		))
+		return nil
+	}
+	return cluster
 }
 
-func readState(ctx context.Context, state *HcpMachinePoolState, collection *cmv1.ClustersClient) (poolNotFound bool, diags diag.Diagnostics) {
+func readState(ctx context.Context, state *HcpMachinePoolState, clusterClient common.ClusterClient,
+	nodePools common.NodePoolsClient) (poolNotFound bool, diags diag.Diagnostics) {
 	diags = diag.Diagnostics{}
 
-	clusterObject := fetchCluster(ctx, state, collection, &diags)
+	clusterObject := fetchCluster(ctx, state, clusterClient, &diags)
 	if clusterObject == nil {
 		return
 	}
 
-	nodePoolResource := collection.Cluster(state.Cluster.ValueString()).
-		NodePools().
-		NodePool(state.ID.ValueString())
-	getNp, err := nodePoolResource.Get().
-		Parameter("fetchUserTagsOnly", true).SendContext(ctx)
+	exists, object, err := nodePools.Exists(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
-		if getNp.Status() == http.StatusNotFound {
-			poolNotFound = true
-			return
-		}
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to fetch machine pool",
 			fmt.Sprintf(
-				"Failed to fetch machine pool with identifier %s for cluster %s. Response code: %v, err: %v",
-				state.ID.ValueString(), state.Cluster.ValueString(), getNp.Status(), err,
+				"Failed to fetch machine pool with identifier %s for cluster %s: %v",
+				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
+	if !exists {
+		poolNotFound = true
+		return
+	}
 
-	npObject := getNp.Body()
-	err = populateState(ctx, npObject, state, clusterObject)
+	err = populateState(ctx, object, state, clusterObject)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return
 	}
@@ -652,11 +698,18 @@ func (r *HcpMachinePoolResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	ctx, cancel := common.WithUpdateTimeout(ctx, plan.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	diags = r.doUpdate(ctx, state, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Timeouts = plan.Timeouts
 
 	// Save the state:
 	diags = resp.State.Set(ctx, state)
@@ -670,33 +723,34 @@ func (r *HcpMachinePoolResource) doUpdate(ctx context.Context, state *HcpMachine
 		return diags
 	}
 
-	clusterObject := fetchCluster(ctx, plan, r.clusterCollection, &diags)
+	clusterObject := fetchCluster(ctx, plan, r.clusterClient, &diags)
 	if clusterObject == nil {
 		return diags
 	}
 
-	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		NodePools().
-		NodePool(state.ID.ValueString())
-	_, err := resource.Get().SendContext(ctx)
+	_, err := r.nodePools.Get(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Cannot find machine pool",
 			fmt.Sprintf(
 				"Cannot find machine pool with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
 
 	// Schedule a cluster upgrade if a newer version is requested
 	if err := r.upgradeMachinePoolIfNeeded(ctx, state, plan); err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Can't upgrade machine pool",
 			fmt.Sprintf("Can't upgrade machine pool version with identifier: `%s`, %v", state.ID.ValueString(), err),
+			err,
 		)
 		return diags
 	}
@@ -771,12 +825,14 @@ func (r *HcpMachinePoolResource) doUpdate(ctx context.Context, state *HcpMachine
 	if shouldPatchTuningConfigs {
 		tuningConfigs, err := common.StringListToArray(ctx, patchTuningConfigs)
 		if err != nil {
-			diags.AddError(
+			common.AddError(
+				&diags,
 				"Cannot update machine pool",
 				fmt.Sprintf(
 					"Cannot update tuning configs for machine pool pool for cluster '%s': %v",
 					plan.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return diags
 		}
@@ -794,41 +850,42 @@ func (r *HcpMachinePoolResource) doUpdate(ctx context.Context, state *HcpMachine
 
 	nodePool, err := npBuilder.Build()
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Cannot update machine pool",
 			fmt.Sprintf(
 				"Cannot update machine pool for cluster '%s: %v ", state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
-	update, err := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		NodePools().
-		NodePool(state.ID.ValueString()).Update().
-		Parameter("fetchUserTagsOnly", true).Body(nodePool).SendContext(ctx)
+	object, err := r.nodePools.Update(ctx, state.Cluster.ValueString(), nodePool)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update machine pool",
 			fmt.Sprintf(
 				"Failed to update machine pool '%s'  on cluster '%s': %v",
 				state.ID.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return diags
 	}
 
-	object := update.Body()
-
 	adjustInitialStateToPlan(state, plan)
 
 	// Save the state:
 	err = populateState(ctx, object, state, clusterObject)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Can't populate machine pool state",
 			fmt.Sprintf(
 				"Received error %v", err,
 			),
+			err,
 		)
 		return diags
 	}
@@ -848,11 +905,14 @@ func adjustInitialStateToPlan(state, plan *HcpMachinePoolState) {
 	state.IgnoreDeletionError = plan.IgnoreDeletionError
 
 	if state.AWSNodePool == nil {
-		state.AWSNodePool = new(AWSNodePool)
+		state.AWSNodePool = &AWSNodePool{TagsAll: types.MapNull(types.StringType)}
 	}
 	if common.HasValue(plan.AWSNodePool.Tags) {
 		state.AWSNodePool.Tags = plan.AWSNodePool.Tags
 	}
+	if common.HasValue(plan.AWSNodePool.TagsAll) {
+		state.AWSNodePool.TagsAll = plan.AWSNodePool.TagsAll
+	}
 
 	if common.HasValue(plan.TuningConfigs) {
 		state.TuningConfigs = plan.TuningConfigs
@@ -943,7 +1003,7 @@ func (r *HcpMachinePoolResource) upgradeMachinePoolIfNeeded(ctx context.Context,
 
 func (r *HcpMachinePoolResource) validateUpgrade(ctx context.Context, state, plan *HcpMachinePoolState) error {
 	availableVersions, err := upgrade.GetAvailableUpgradeVersions(
-		ctx, r.clusterCollection, r.versionCollection, state.Cluster.ValueString(), state.ID.ValueString())
+		ctx, r.clusterCollection, r.versionsClient, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
 		return fmt.Errorf("failed to get available upgrades: %v", err)
 	}
@@ -1082,11 +1142,14 @@ func (r *HcpMachinePoolResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	ctx, cancel := common.WithDeleteTimeout(ctx, state.Timeouts, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Send the request to delete the machine pool:
-	resource := r.clusterCollection.Cluster(state.Cluster.ValueString()).
-		NodePools().
-		NodePool(state.ID.ValueString())
-	_, err := resource.Delete().SendContext(ctx)
+	err := r.nodePools.Delete(ctx, state.Cluster.ValueString(), state.ID.ValueString())
 	if err != nil {
 		if common.BoolWithFalseDefault(state.IgnoreDeletionError) {
 			resp.Diagnostics.AddWarning(
@@ -1121,13 +1184,15 @@ func (r *HcpMachinePoolResource) Delete(ctx context.Context, req resource.Delete
 			return
 		} else {
 			// Wasn't the last one, return error
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Cannot delete machine pool",
 				fmt.Sprintf(
 					"Cannot delete machine pool with identifier '%s' for "+
 						"cluster '%s': %v",
 					state.ID.ValueString(), state.Cluster.ValueString(), err,
 				),
+				err,
 			)
 			return
 		}
@@ -1140,12 +1205,7 @@ func (r *HcpMachinePoolResource) Delete(ctx context.Context, req resource.Delete
 
 // countPools returns the number of machine pools in the given cluster
 func (r *HcpMachinePoolResource) countPools(ctx context.Context, clusterID string) (int, error) {
-	resource := r.clusterCollection.Cluster(clusterID).NodePools()
-	resp, err := resource.List().SendContext(ctx)
-	if err != nil {
-		return 0, err
-	}
-	return resp.Size(), nil
+	return r.nodePools.Count(ctx, clusterID)
 }
 
 func (r *HcpMachinePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -1171,7 +1231,7 @@ func populateState(ctx context.Context, object *cmv1.NodePool, state *HcpMachine
 
 	if awsNodePool, ok := object.GetAWSNodePool(); ok {
 		if state.AWSNodePool == nil {
-			state.AWSNodePool = new(AWSNodePool)
+			state.AWSNodePool = &AWSNodePool{TagsAll: types.MapNull(types.StringType)}
 		}
 		if instanceType, ok := awsNodePool.GetInstanceType(); ok {
 			state.AWSNodePool.InstanceType = types.StringValue(instanceType)
@@ -1315,7 +1375,16 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *HcpMachine
 	if len(awsTags) == 0 {
 		return awsTags, nil
 	}
-	if cluster.AWS() == nil || len(cluster.AWS().Tags()) == 0 {
+	// Tags inherited from the cluster or from the default tags of the provider aren't part of
+	// the machine pool input:
+	inheritedTags, err := common.OptionalMap(ctx, state.AWSNodePool.TagsAll)
+	if err != nil {
+		return awsTags, err
+	}
+	if cluster.AWS() != nil {
+		inheritedTags = common.MergeStringMaps(inheritedTags, cluster.AWS().Tags())
+	}
+	if len(inheritedTags) == 0 {
 		return awsTags, nil
 	}
 	filteredTags := make(map[string]string, len(awsTags))
@@ -1326,8 +1395,7 @@ func filterClusterTagsNotPresentInNpInput(ctx context.Context, state *HcpMachine
 	if err != nil {
 		return filteredTags, err
 	}
-	clusterTags := cluster.AWS().Tags()
-	for k := range clusterTags {
+	for k := range inheritedTags {
 		if _, ok := currentNpTfTags[k]; !ok {
 			delete(filteredTags, k)
 		}
@@ -0,0 +1,167 @@
+package hcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	clusterId  = "123"
+	nodePoolId = "pool"
+)
+
+var _ = Describe("Node pool update", func() {
+	var (
+		ctx           context.Context
+		clusterClient *common.MockClusterClient
+		nodePools     *common.MockNodePoolsClient
+		r             *HcpMachinePoolResource
+		state         *HcpMachinePoolState
+		plan          *HcpMachinePoolState
+	)
+
+	// newState returns the state of a node pool with two replicas and no autoscaling.
+	newState := func() *HcpMachinePoolState {
+		state := &HcpMachinePoolState{
+			AWSNodePool: &AWSNodePool{TagsAll: types.MapNull(types.StringType)},
+		}
+		state.Cluster = types.StringValue(clusterId)
+		state.ID = types.StringValue(nodePoolId)
+		state.Name = types.StringValue(nodePoolId)
+		state.Replicas = types.Int64Value(2)
+		state.AutoScaling = &AutoScaling{Enabled: types.BoolValue(false)}
+		state.Labels = types.MapNull(types.StringType)
+		state.SubnetID = types.StringValue("subnet-1")
+		state.TuningConfigs = types.ListNull(types.StringType)
+		state.AWSNodePool.InstanceType = types.StringValue("m5.xlarge")
+		state.AWSNodePool.Tags = types.MapNull(types.StringType)
+		state.AWSNodePool.AdditionalSecurityGroupIds = types.ListNull(types.StringType)
+		return state
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl := gomock.NewController(GinkgoT())
+		clusterClient = common.NewMockClusterClient(ctrl)
+		nodePools = common.NewMockNodePoolsClient(ctrl)
+		r = &HcpMachinePoolResource{
+			clusterClient: clusterClient,
+			nodePools:     nodePools,
+		}
+		state = newState()
+		plan = newState()
+	})
+
+	// expectPool makes the cluster and the current node pool exist.
+	expectPool := func() {
+		cluster, err := cmv1.NewCluster().ID(clusterId).Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusterClient.EXPECT().Exists(gomock.Any(), clusterId).Return(true, cluster, nil)
+		current, err := cmv1.NewNodePool().ID(nodePoolId).Replicas(2).Build()
+		Expect(err).ToNot(HaveOccurred())
+		nodePools.EXPECT().Get(gomock.Any(), clusterId, nodePoolId).Return(current, nil)
+	}
+
+	// expectUpdate checks the node pool sent to OCM and returns it in the subnet of the state.
+	expectUpdate := func(check func(pool *cmv1.NodePool)) {
+		nodePools.EXPECT().Update(gomock.Any(), clusterId, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, clusterId string, pool *cmv1.NodePool) (*cmv1.NodePool, error) {
+				check(pool)
+				return cmv1.NewNodePool().Copy(pool).
+					Subnet("subnet-1").
+					AvailabilityZone("us-east-1a").
+					Build()
+			})
+	}
+
+	It("Updates the replicas and the labels", func() {
+		expectPool()
+		expectUpdate(func(pool *cmv1.NodePool) {
+			Expect(pool.ID()).To(Equal(nodePoolId))
+			Expect(pool.Replicas()).To(Equal(3))
+			Expect(pool.Labels()).To(Equal(map[string]string{"role": "db"}))
+			Expect(pool.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+		})
+		plan.Replicas = types.Int64Value(3)
+		plan.Labels = types.MapValueMust(types.StringType, map[string]attr.Value{
+			"role": types.StringValue("db"),
+		})
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(state.Replicas.ValueInt64()).To(BeEquivalentTo(3))
+		Expect(state.Labels.Elements()).To(HaveKeyWithValue("role", types.StringValue("db")))
+		Expect(state.AvailabilityZone.ValueString()).To(Equal("us-east-1a"))
+	})
+
+	It("Enables the autoscaling", func() {
+		expectPool()
+		expectUpdate(func(pool *cmv1.NodePool) {
+			Expect(pool.Autoscaling().MinReplica()).To(Equal(1))
+			Expect(pool.Autoscaling().MaxReplica()).To(Equal(4))
+			_, ok := pool.GetReplicas()
+			Expect(ok).To(BeFalse())
+		})
+		plan.Replicas = types.Int64Null()
+		plan.AutoScaling = &AutoScaling{
+			Enabled:     types.BoolValue(true),
+			MinReplicas: types.Int64Value(1),
+			MaxReplicas: types.Int64Value(4),
+		}
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeFalse(), "%v", diags)
+		Expect(state.AutoScaling.Enabled.ValueBool()).To(BeTrue())
+		Expect(state.AutoScaling.MaxReplicas.ValueInt64()).To(BeEquivalentTo(4))
+	})
+
+	It("Rejects the change of the instance type without calling OCM", func() {
+		plan.AWSNodePool.InstanceType = types.StringValue("m5.2xlarge")
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+	})
+
+	It("Rejects the replicas combined with the autoscaling", func() {
+		expectPool()
+		plan.AutoScaling = &AutoScaling{
+			Enabled:     types.BoolValue(true),
+			MinReplicas: types.Int64Value(1),
+			MaxReplicas: types.Int64Value(4),
+		}
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Detail()).To(ContainSubstring("either replicas should be set or autoscaling enabled"))
+	})
+
+	It("Fails when the node pool can't be found", func() {
+		cluster, err := cmv1.NewCluster().ID(clusterId).Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusterClient.EXPECT().Exists(gomock.Any(), clusterId).Return(true, cluster, nil)
+		nodePools.EXPECT().Get(gomock.Any(), clusterId, nodePoolId).Return(nil, errors.New("not found"))
+		plan.Replicas = types.Int64Value(3)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Summary()).To(Equal("Cannot find machine pool"))
+	})
+
+	It("Reports the errors of the update", func() {
+		expectPool()
+		nodePools.EXPECT().Update(gomock.Any(), clusterId, gomock.Any()).Return(nil, errors.New("quota exceeded"))
+		plan.Replicas = types.Int64Value(3)
+
+		diags := r.doUpdate(ctx, state, plan)
+		Expect(diags.HasError()).To(BeTrue())
+		Expect(diags[0].Detail()).To(ContainSubstring("quota exceeded"))
+	})
+})
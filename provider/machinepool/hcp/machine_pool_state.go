@@ -17,10 +17,26 @@ limitations under the License.
 package hcp
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type HcpMachinePoolState struct {
+	hcpMachinePoolAttributes
+
+	AWSNodePool *AWSNodePool `tfsdk:"aws_node_pool"`
+}
+
+// hcpMachinePoolDataSourceState is the state of the data source, that doesn't have the merged
+// tags of the AWS settings.
+type hcpMachinePoolDataSourceState struct {
+	hcpMachinePoolAttributes
+
+	AWSNodePool *awsNodePoolAttributes `tfsdk:"aws_node_pool"`
+}
+
+// hcpMachinePoolAttributes are the attributes shared by the machine pool resource and data source.
+type hcpMachinePoolAttributes struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Cluster     types.String `tfsdk:"cluster"`
@@ -38,12 +54,13 @@ type HcpMachinePoolState struct {
 	UpgradeAcksFor types.String `tfsdk:"upgrade_acknowledgements_for"`
 
 	NodePoolStatus types.Object `tfsdk:"status"`
-	AWSNodePool    *AWSNodePool `tfsdk:"aws_node_pool"`
 	TuningConfigs  types.List   `tfsdk:"tuning_configs"`
 	KubeletConfigs types.String `tfsdk:"kubelet_configs"`
 	AutoRepair     types.Bool   `tfsdk:"auto_repair"`
 
 	IgnoreDeletionError types.Bool `tfsdk:"ignore_deletion_error"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Taints struct {
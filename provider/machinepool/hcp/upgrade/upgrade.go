@@ -26,7 +26,6 @@ import (
 	ocmUtils "github.com/openshift-online/ocm-common/pkg/ocm/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
-	"github.com/zgalor/weberr"
 )
 
 type MachinePoolUpgrade struct {
@@ -39,7 +38,7 @@ type MachinePoolUpgrade struct {
 func GetAvailableUpgradeVersions(
 	ctx context.Context,
 	clustersClient *cmv1.ClustersClient,
-	versionClient *cmv1.VersionsClient,
+	versionClient common.VersionsClient,
 	clusterId string, nodePoolId string) ([]*cmv1.Version, error) {
 	clusterResp, err := clustersClient.Cluster(clusterId).Get().SendContext(ctx)
 	if err != nil {
@@ -60,13 +59,10 @@ func GetAvailableUpgradeVersions(
 	availableUpgradeVersions := []*cmv1.Version{}
 	for _, v := range version.AvailableUpgrades() {
 		id := ocmUtils.CreateVersionId(v, cluster.Version().ChannelGroup())
-		resp, err := versionClient.Version(id).
-			Get().
-			Send()
+		availableVersion, err := versionClient.Get(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get version information: %v", err)
 		}
-		availableVersion := resp.Body()
 		if availableVersion.HostedControlPlaneEnabled() {
 			availableUpgradeVersions = append(availableUpgradeVersions, availableVersion)
 		}
@@ -236,8 +232,7 @@ func getMissingGateAgreements(
 		}
 		// return original error if invaild version gate detected
 		if len(gates) > 0 && gates[0].ID() == "" {
-			errType := weberr.ErrorType(response.Error().Status())
-			return []*cmv1.VersionGate{}, errType.Set(weberr.Errorf(response.Error().Reason()))
+			return []*cmv1.VersionGate{}, common.HandleErr(response.Error(), err)
 		}
 		return gates, nil
 	}
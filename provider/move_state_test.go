@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Move state", func() {
+	var ctx context.Context
+	var server tfprotov6.ProviderServer
+	var schemas map[string]*tfprotov6.Schema
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = providerserver.NewProtocol6(New())()
+		response, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		schemas = response.ResourceSchemas
+	})
+
+	move := func(sourceTypeName string, json string) *tfprotov6.MoveResourceStateResponse {
+		response, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/terraform-redhat/rhcs",
+			SourceTypeName:        sourceTypeName,
+			SourceState:           &tfprotov6.RawState{JSON: []byte(json)},
+			TargetTypeName:        "rhcs_cluster_rosa_classic",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return response
+	}
+
+	It("Moves the legacy cluster to the classic cluster", func() {
+		response := move("rhcs_cluster", `{
+			"id": "123",
+			"product": "rosa",
+			"name": "my-cluster",
+			"cloud_provider": "aws",
+			"cloud_region": "us-east-1",
+			"aws_access_key_id": "my-key",
+			"aws_secret_access_key": "my-secret",
+			"aws_account_id": "111111111111",
+			"aws_subnet_ids": ["subnet-1", "subnet-2"],
+			"compute_machine_type": "m5.xlarge",
+			"compute_nodes": 3,
+			"version": "openshift-v4.14.1",
+			"properties": {"rosa_tf_version": "1.0.0", "owner": "me"},
+			"proxy": {"http_proxy": "http://proxy.example.com"},
+			"wait": true
+		}`)
+		Expect(response.Diagnostics).To(BeEmpty())
+
+		value, err := response.TargetState.Unmarshal(schemas["rhcs_cluster_rosa_classic"].ValueType())
+		Expect(err).NotTo(HaveOccurred())
+		attributes := map[string]tftypes.Value{}
+		Expect(value.As(&attributes)).To(Succeed())
+		Expect(attributes["id"].Equal(tftypes.NewValue(tftypes.String, "123"))).To(BeTrue())
+		Expect(attributes["replicas"].Equal(tftypes.NewValue(tftypes.Number, 3))).To(BeTrue())
+		Expect(attributes["version"].Equal(tftypes.NewValue(tftypes.String, "4.14.1"))).To(BeTrue())
+		Expect(attributes["wait_for_create_complete"].Equal(tftypes.NewValue(tftypes.Bool, true))).To(BeTrue())
+		Expect(attributes["aws_subnet_ids"].Equal(tftypes.NewValue(tftypes.List{ElementType: tftypes.String},
+			[]tftypes.Value{
+				tftypes.NewValue(tftypes.String, "subnet-1"),
+				tftypes.NewValue(tftypes.String, "subnet-2"),
+			}))).To(BeTrue())
+		Expect(attributes["properties"].Equal(tftypes.NewValue(tftypes.Map{ElementType: tftypes.String},
+			map[string]tftypes.Value{
+				"owner": tftypes.NewValue(tftypes.String, "me"),
+			}))).To(BeTrue())
+		proxy := map[string]tftypes.Value{}
+		Expect(attributes["proxy"].As(&proxy)).To(Succeed())
+		Expect(proxy["http_proxy"].Equal(tftypes.NewValue(tftypes.String, "http://proxy.example.com"))).To(BeTrue())
+	})
+
+	It("Rejects the legacy clusters that aren't ROSA", func() {
+		response := move("rhcs_cluster", `{"id": "123", "product": "osd", "name": "my-cluster"}`)
+		Expect(response.Diagnostics).To(HaveLen(1))
+		Expect(response.Diagnostics[0].Detail).To(ContainSubstring("only 'rosa' clusters can be moved"))
+	})
+
+	It("Rejects other resources", func() {
+		response := move("rhcs_machine_pool", `{"id": "123"}`)
+		Expect(response.Diagnostics).To(HaveLen(1))
+		Expect(response.Diagnostics[0].Severity).To(Equal(tfprotov6.DiagnosticSeverityError))
+	})
+})
@@ -24,8 +24,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rhcsCommon "github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/ocm_policies/common"
 )
 
@@ -125,7 +125,7 @@ func (s *OcmPoliciesDataSource) Configure(ctx context.Context, req datasource.Co
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*rhcsCommon.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	s.awsInquiries = connection.ClustersMgmt().V1().AWSInquiries()
@@ -176,7 +176,7 @@ func (s *OcmPoliciesDataSource) Read(ctx context.Context, req datasource.ReadReq
 		case "sts_support_trust_policy":
 			jitRole, err := common.ParseRhSupportRole(ctx, awsPolicy.Details())
 			if err != nil {
-				resp.Diagnostics.AddError("failed to fetch HCP policies", fmt.Sprintf("%v", err))
+				rhcsCommon.AddError(&resp.Diagnostics, "failed to fetch HCP policies", fmt.Sprintf("%v", err), err)
 			}
 			accountRolePolicies.SupportRhSreRole = types.StringValue(jitRole)
 		case InstanceWorker:
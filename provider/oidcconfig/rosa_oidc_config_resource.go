@@ -19,7 +19,6 @@ package oidcconfig
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -27,12 +26,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type RosaOidcConfigResource struct {
-	oidcConfigClient   *cmv1.OidcConfigsClient
+	oidcConfigClient   common.OidcConfigsClient
 	clustersClient     *cmv1.ClustersClient
 	awsInquiriesClient *cmv1.AWSInquiriesClient
 }
@@ -92,16 +92,17 @@ func (o *RosaOidcConfigResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
-	o.oidcConfigClient = connection.ClustersMgmt().V1().OidcConfigs()
+	o.oidcConfigClient = common.NewOidcConfigsClient(connection.ClustersMgmt().V1().OidcConfigs())
 	o.clustersClient = connection.ClustersMgmt().V1().Clusters()
 	o.awsInquiriesClient = connection.ClustersMgmt().V1().AWSInquiries()
 }
@@ -134,11 +135,13 @@ func (o *RosaOidcConfigResource) Create(ctx context.Context, request resource.Cr
 		}
 		oidcConfig, err = cmv1.NewOidcConfig().Managed(true).Build()
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"There was a problem building the managed OIDC Configuration",
 				fmt.Sprintf(
 					"There was a problem building the managed OIDC Configuration: %v", err,
 				),
+				err,
 			)
 			return
 		}
@@ -163,38 +166,42 @@ func (o *RosaOidcConfigResource) Create(ctx context.Context, request resource.Cr
 			Build()
 
 		if err != nil {
-			response.Diagnostics.AddError(
+			common.AddError(
+				&response.Diagnostics,
 				"There was a problem building the unmanaged OIDC Configuration",
 				fmt.Sprintf(
 					"There was a problem building the unmanaged OIDC Configuration: %v", err,
 				),
+				err,
 			)
 			return
 		}
 	}
 
-	object, err := o.oidcConfigClient.Add().Body(oidcConfig).SendContext(ctx)
+	oidcConfig, err = o.oidcConfigClient.Create(ctx, oidcConfig)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"There was a problem registering the OIDC Configuration",
 			fmt.Sprintf(
 				"There was a problem registering the OIDC Configuration: %v", err,
 			),
+			err,
 		)
 		return
 	}
 
-	oidcConfig = object.Body()
-
 	// Save the state:
 	err = o.populateState(ctx, oidcConfig, state)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update OIDC Config",
 			fmt.Sprintf(
 				"Cannot update "+
 					"OIDC Config '%s': %v", state.ID.ValueString(), err,
 			),
+			err,
 		)
 	}
 	response.Diagnostics.Append(diags...)
@@ -215,36 +222,38 @@ func (o *RosaOidcConfigResource) Read(ctx context.Context, request resource.Read
 	}
 
 	// Find the oidc config:
-	get, err := o.oidcConfigClient.OidcConfig(state.ID.ValueString()).Get().SendContext(ctx)
+	exists, object, err := o.oidcConfigClient.Exists(ctx, state.ID.ValueString())
 	if err != nil {
-		if get.Status() == http.StatusNotFound {
-			tflog.Warn(ctx, fmt.Sprintf("oidc config (%s) not found, removing from state",
-				state.ID.ValueString(),
-			))
-			response.State.RemoveResource(ctx)
-			return
-		}
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Cannot find OIDC config",
 			fmt.Sprintf(
 				"Cannot find OIDC config with ID %s, %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
-
-	object := get.Body()
+	if !exists {
+		tflog.Warn(ctx, fmt.Sprintf("oidc config (%s) not found, removing from state",
+			state.ID.ValueString(),
+		))
+		response.State.RemoveResource(ctx)
+		return
+	}
 
 	// Save the state:
 	err = o.populateState(ctx, object, state)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update OIDC Config",
 			fmt.Sprintf(
 				"Cannot update "+
 					"OIDC Config '%s': %v", state.ID.ValueString(), err,
 			),
+			err,
 		)
 	}
 	response.Diagnostics.Append(diags...)
@@ -276,29 +285,31 @@ func (o *RosaOidcConfigResource) Delete(ctx context.Context, request resource.De
 	}
 
 	// Find the oidc config:
-	get, err := o.oidcConfigClient.OidcConfig(state.ID.ValueString()).Get().SendContext(ctx)
+	oidcConfig, err := o.oidcConfigClient.Get(ctx, state.ID.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Cannot find OIDC config",
 			fmt.Sprintf(
 				"Cannot find OIDC config with ID %s, %v",
 				state.ID.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 
-	oidcConfig := get.Body()
-
 	// check if there is a cluster using the oidc endpoint:
 	hasClusterUsingOidcConfig, err := o.hasAClusterUsingOidcEndpointUrl(ctx, oidcConfig.IssuerUrl())
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"There was a problem checking if any clusters are using OIDC config",
 			fmt.Sprintf(
 				"There was a problem checking if any clusters are using OIDC config '%s' : %v",
 				oidcConfig.IssuerUrl(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -315,12 +326,14 @@ func (o *RosaOidcConfigResource) Delete(ctx context.Context, request resource.De
 
 	err = o.deleteOidcConfig(ctx, state.ID.ValueString())
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"There was a problem deleting the OIDC config",
 			fmt.Sprintf(
 				"There was a problem deleting the OIDC config '%s' : %v",
 				oidcConfig.IssuerUrl(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -346,11 +359,7 @@ func (o *RosaOidcConfigResource) hasAClusterUsingOidcEndpointUrl(ctx context.Con
 }
 
 func (o *RosaOidcConfigResource) deleteOidcConfig(ctx context.Context, id string) error {
-	_, err := o.oidcConfigClient.
-		OidcConfig(id).
-		Delete().
-		SendContext(ctx)
-	return err
+	return o.oidcConfigClient.Delete(ctx, id)
 }
 
 func (o *RosaOidcConfigResource) ImportState(ctx context.Context, request resource.ImportStateRequest,
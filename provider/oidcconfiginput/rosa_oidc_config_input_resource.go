@@ -23,6 +23,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	rosaOidcConfig "github.com/openshift-online/ocm-common/pkg/rosa/oidcconfigs"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type RosaOidcConfigInputResource struct {
@@ -99,12 +101,14 @@ func (o *RosaOidcConfigInputResource) Create(ctx context.Context, request resour
 	region := state.Region.ValueString()
 	oidcConfigInput, err := rosaOidcConfig.BuildOidcConfigInput("", region)
 	if err != nil {
-		response.Diagnostics.AddError(
+		common.AddError(
+			&response.Diagnostics,
 			"Cannot generate oidc config input object",
 			fmt.Sprintf(
 				"Cannot generate oidc config input object: %v",
 				err,
 			),
+			err,
 		)
 		return
 	}
@@ -21,26 +21,40 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	tfpschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/build"
+	ocmconfig "github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/config"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/credentialprocess"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/transport"
 	"github.com/terraform-redhat/terraform-provider-rhcs/logging"
 	classicAutoscaler "github.com/terraform-redhat/terraform-provider-rhcs/provider/autoscaler/classic"
 	hcpAutoscaler "github.com/terraform-redhat/terraform-provider-rhcs/provider/autoscaler/hcp"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/breakglasscredential"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cloudprovider"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/cluster"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/classic"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterrosa/hcp"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/clusterwaiter"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 	defaultingress "github.com/terraform-redhat/terraform-provider-rhcs/provider/defaultingress/classic"
 	hcpingress "github.com/terraform-redhat/terraform-provider-rhcs/provider/defaultingress/hcp"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/dnsdomain"
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/externalauthprovider"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/group"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/groupmembership"
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/identityprovider"
@@ -67,13 +81,24 @@ var _ tfprovider.Provider = &Provider{}
 
 // Config contains the configuration of the provider.
 type Config struct {
-	URL          types.String `tfsdk:"url"`
-	TokenURL     types.String `tfsdk:"token_url"`
-	Token        types.String `tfsdk:"token"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	TrustedCAs   types.String `tfsdk:"trusted_cas"`
-	Insecure     types.Bool   `tfsdk:"insecure"`
+	Environment                  types.String         `tfsdk:"environment"`
+	URL                          types.String         `tfsdk:"url"`
+	TokenURL                     types.String         `tfsdk:"token_url"`
+	Token                        types.String         `tfsdk:"token"`
+	ClientID                     types.String         `tfsdk:"client_id"`
+	ClientSecret                 types.String         `tfsdk:"client_secret"`
+	CredentialProcess            types.String         `tfsdk:"credential_process"`
+	TrustedCAs                   types.String         `tfsdk:"trusted_cas"`
+	Insecure                     types.Bool           `tfsdk:"insecure"`
+	Retry                        *RetryConfig         `tfsdk:"retry"`
+	RateLimit                    *RateLimitConfig     `tfsdk:"rate_limit"`
+	OutboundProxy                *OutboundProxyConfig `tfsdk:"outbound_proxy"`
+	CacheTTLInSeconds            types.Int64          `tfsdk:"cache_ttl_in_seconds"`
+	ClusterWaitIntervalInSeconds types.Int64          `tfsdk:"cluster_wait_interval_in_seconds"`
+	AuditLogPath                 types.String         `tfsdk:"audit_log_path"`
+	DryRun                       types.Bool           `tfsdk:"dry_run"`
+	Cassette                     *CassetteConfig      `tfsdk:"cassette"`
+	DefaultTags                  types.Map            `tfsdk:"default_tags"`
 }
 
 // New creates the provider.
@@ -90,6 +115,17 @@ func (p *Provider) Metadata(ctx context.Context, req tfprovider.MetadataRequest,
 func (p *Provider) Schema(ctx context.Context, req tfprovider.SchemaRequest, resp *tfprovider.SchemaResponse) {
 	resp.Schema = tfpschema.Schema{
 		Attributes: map[string]tfpschema.Attribute{
+			"environment": tfpschema.StringAttribute{
+				Description: fmt.Sprintf("Name of the OCM environment to connect to. It sets the URL, the "+
+					"token URL and the default client identifier of that environment at once. "+
+					"Can't be combined with `url` or `token_url`. Options are %s.",
+					strings.Join(info.OCMEnvironmentNames(), ",")),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(info.OCMEnvironmentNames()...),
+					stringvalidator.ConflictsWith(path.MatchRoot("url"), path.MatchRoot("token_url")),
+				},
+			},
 			"url": tfpschema.StringAttribute{
 				Description: fmt.Sprintf("URL sets the base URL of the API gateway. The default is `%s`", sdk.DefaultURL),
 				Optional:    true,
@@ -113,6 +149,17 @@ func (p *Provider) Schema(ctx context.Context, req tfprovider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"credential_process": tfpschema.StringAttribute{
+				Description: "Command that is executed with the system shell to obtain the OCM tokens. It must " +
+					"write to its standard output a JSON object containing `access_token` and/or `refresh_token`, " +
+					"and optionally `expires_at` in RFC 3339 format. When only an access token is returned the " +
+					"command is executed again when that token is close to its expiration. " +
+					"Can't be combined with `token` or `client_secret`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("client_secret")),
+				},
+			},
 			"trusted_cas": tfpschema.StringAttribute{
 				Description: "PEM encoded certificates of authorities that will " +
 					"be trusted. If this is not explicitly specified, then " +
@@ -127,17 +174,94 @@ func (p *Provider) Schema(ctx context.Context, req tfprovider.SchemaRequest, res
 					"for production environments.",
 				Optional: true,
 			},
+			"retry": tfpschema.SingleNestedAttribute{
+				Description: "Retry policy applied to all the requests sent to the OCM API, so that " +
+					"transient failures and throttling don't interrupt the apply. When not set a " +
+					"default policy is used.",
+				Attributes: retrySchema(),
+				Optional:   true,
+			},
+			"rate_limit": tfpschema.SingleNestedAttribute{
+				Description: "Client side limits for the requests sent to the OCM API, shared by all " +
+					"the resources and data sources, to protect the organization quotas and avoid " +
+					"throttling when Terraform runs with high parallelism.",
+				Attributes: rateLimitSchema(),
+				Optional:   true,
+			},
+			"outbound_proxy": tfpschema.SingleNestedAttribute{
+				Description: "Proxy used by the provider itself to connect to the OCM API and the " +
+					"other services that it talks to. This is not the proxy of the cluster nodes, " +
+					"which is configured with the `proxy` attribute of the cluster resources.",
+				Attributes: outboundProxySchema(),
+				Optional:   true,
+			},
+			"cache_ttl_in_seconds": tfpschema.Int64Attribute{
+				Description: fmt.Sprintf("Number of seconds that the responses for versions, machine "+
+					"types, cloud providers, regions and clusters are reused by all the resources and "+
+					"data sources, instead of sending the same request again. Changes made by the "+
+					"provider to a cluster discard its cached response. Zero disables the cache. "+
+					"The default value is %d.", int64(transport.DefaultCacheTTL/time.Second)),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"cluster_wait_interval_in_seconds": tfpschema.Int64Attribute{
+				Description: fmt.Sprintf("Number of seconds between the requests sent to check a "+
+					"cluster while the resources wait for it. Each check writes the state of the "+
+					"cluster and the time waited so far to the log. The default value is %d.",
+					int64(common.DefaultClusterWaitInterval/time.Second)),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"audit_log_path": tfpschema.StringAttribute{
+				Description: "Path of a file where the provider appends a JSON line for each request " +
+					"that creates, changes or deletes something in OCM, with the time, the kind and " +
+					"address of the object, the method, path, operation identifier and status of the " +
+					"request, and the changed fields with the secrets redacted. It can also be set with " +
+					"the `RHCS_AUDIT_LOG_PATH` environment variable.",
+				Optional: true,
+			},
+			"dry_run": tfpschema.BoolAttribute{
+				Description: "When set to 'true' the requests that would create, change or delete " +
+					"something in OCM aren't sent. They are written to the log with their body and " +
+					"answered with a simulated success, so that the apply completes without changing " +
+					"anything. Read only requests are sent as usual.",
+				Optional: true,
+			},
+			"cassette": tfpschema.SingleNestedAttribute{
+				Description: "Records the requests sent to OCM and their responses into a file, " +
+					"with the secrets redacted, or replays them later without network access. " +
+					"This is meant to reproduce problems, not for regular use.",
+				Attributes: cassetteSchema(),
+				Optional:   true,
+			},
+			"default_tags": tfpschema.MapAttribute{
+				Description: "Tags applied to all the AWS resources created by the clusters and " +
+					"machine pools managed by this provider. They are merged with the tags set in " +
+					"each resource, and the tags set in the resource take precedence.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
 
-func (p *Provider) getAttrValueOrConfig(attr types.String, envSuffix string) (string, bool) {
+// getAttrValueOrConfig returns the value of a setting, taken in order of precedence from the
+// provider attribute, the `RHCS_<envSuffix>` environment variable and the given value loaded
+// from the OCM configuration file.
+func (p *Provider) getAttrValueOrConfig(attr types.String, envSuffix string, fileValue string) (string, bool) {
 	if !attr.IsNull() {
 		return attr.ValueString(), true
 	}
 	if value, ok := os.LookupEnv(fmt.Sprintf("RHCS_%s", envSuffix)); ok {
 		return value, true
 	}
+	if fileValue != "" {
+		return fileValue, true
+	}
 	return "", false
 }
 
@@ -158,27 +282,105 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 	// the log package as well.
 	logger := logging.New()
 
+	// Credentials explicitly given in the provider configuration or in the environment take
+	// precedence. When there are none we use the credential process if configured, and then we
+	// fall back to the configuration file written by `ocm login` or `rosa login`, taking the URLs
+	// from it as well, so that the tokens are always sent to the environment that issued them.
+	token, tokenExists := p.getAttrValueOrConfig(config.Token, "TOKEN", "")
+	clientID, clientIdExists := p.getAttrValueOrConfig(config.ClientID, "CLIENT_ID", "")
+	clientSecret, clientSecretExists := p.getAttrValueOrConfig(config.ClientSecret, "CLIENT_SECRET", "")
+	ocmConfig := &ocmconfig.Config{}
+	var credentialProcess *credentialprocess.Process
+	if !tokenExists && !(clientIdExists && clientSecretExists) {
+		if command, ok := p.getAttrValueOrConfig(config.CredentialProcess, "CREDENTIAL_PROCESS", ""); ok {
+			credentialProcess = credentialprocess.New(command)
+			processToken, err := credentialProcess.Token(ctx)
+			if err != nil {
+				resp.Diagnostics.AddError("Can't obtain tokens from the credential process", err.Error())
+				return
+			}
+			// Refresh tokens are given to the connection, which knows how to renew the access
+			// tokens by itself. Otherwise the credential process is executed again whenever the
+			// access token is about to expire.
+			if processToken.RefreshToken != "" {
+				ocmConfig.AccessToken = processToken.AccessToken
+				ocmConfig.RefreshToken = processToken.RefreshToken
+				credentialProcess = nil
+			}
+		} else {
+			fileConfig, err := ocmconfig.Load()
+			if err != nil {
+				resp.Diagnostics.AddError("Can't load OCM configuration file", err.Error())
+				return
+			}
+			if fileConfig != nil && fileConfig.HasCredentials() {
+				tflog.Info(ctx, "Using credentials from the OCM configuration file")
+				ocmConfig = fileConfig
+			}
+		}
+	}
+
 	// Create the builder:
-	builder := sdk.NewConnectionBuilder()
+	var builder *sdk.ConnectionBuilder
+	if credentialProcess != nil {
+		builder = sdk.NewUnauthenticatedConnectionBuilder()
+		builder.TransportWrapper(credentialProcess.Wrap)
+	} else {
+		builder = sdk.NewConnectionBuilder()
+	}
 	builder.Logger(logger)
 	builder.Agent(fmt.Sprintf("OCM-TF/%s-%s", build.Version, build.Commit))
 
+	// A named environment sets the API and token URLs as a set, so that they can't be mixed
+	// from different environments. It takes precedence over the `RHCS_URL` and `RHCS_TOKEN_URL`
+	// environment variables and over the OCM configuration file, but not over the explicit
+	// `url` and `token_url` attributes.
+	url, urlExists := p.getAttrValueOrConfig(config.URL, "URL", ocmConfig.URL)
+	tokenURL, tokenURLExists := p.getAttrValueOrConfig(config.TokenURL, "TOKEN_URL", ocmConfig.TokenURL)
+	if envName, ok := p.getAttrValueOrConfig(config.Environment, "ENVIRONMENT", ""); ok {
+		env, ok := info.GetOCMEnvironment(envName)
+		if !ok {
+			resp.Diagnostics.AddError(
+				"Invalid OCM environment",
+				fmt.Sprintf("Unknown OCM environment '%s', options are %s",
+					envName, strings.Join(info.OCMEnvironmentNames(), ",")),
+			)
+			return
+		}
+		if config.URL.IsNull() {
+			url, urlExists = env.URL, true
+		}
+		if config.TokenURL.IsNull() {
+			tokenURL, tokenURLExists = env.TokenURL, true
+		}
+		if ocmConfig.ClientSecret == "" {
+			ocmConfig.ClientID = env.ClientID
+		}
+	}
+
 	// Copy the settings:
-	if url, ok := p.getAttrValueOrConfig(config.URL, "URL"); ok {
+	if urlExists {
 		builder.URL(url)
 	}
-	if tokenURL, ok := p.getAttrValueOrConfig(config.TokenURL, "TOKEN_URL"); ok {
+	if tokenURLExists {
 		builder.TokenURL(tokenURL)
 	}
-	if token, ok := p.getAttrValueOrConfig(config.Token, "TOKEN"); ok {
+	if tokenExists {
 		builder.Tokens(token)
+	} else if tokens := ocmConfig.Tokens(); len(tokens) > 0 {
+		builder.Tokens(tokens...)
+	}
+	if !clientIdExists && ocmConfig.ClientID != "" {
+		clientID, clientIdExists = ocmConfig.ClientID, true
+		clientSecret, clientSecretExists = ocmConfig.ClientSecret, true
 	}
-	clientID, clientIdExists := p.getAttrValueOrConfig(config.ClientID, "CLIENT_ID")
-	clientSecret, clientSecretExists := p.getAttrValueOrConfig(config.ClientSecret, "CLIENT_SECRET")
 	if clientIdExists && clientSecretExists {
 		builder.Client(clientID, clientSecret)
 	}
-	if trustedCAs, ok := p.getAttrValueOrConfig(config.TrustedCAs, "TRUSTED_CAS"); ok {
+	if len(ocmConfig.Scopes) > 0 {
+		builder.Scopes(ocmConfig.Scopes...)
+	}
+	if trustedCAs, ok := p.getAttrValueOrConfig(config.TrustedCAs, "TRUSTED_CAS", ""); ok {
 		pool := x509.NewCertPool()
 		if !pool.AppendCertsFromPEM([]byte(trustedCAs)) {
 			resp.Diagnostics.AddError(
@@ -191,6 +393,94 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 	}
 	if !config.Insecure.IsNull() {
 		builder.Insecure(config.Insecure.ValueBool())
+	} else if ocmConfig.Insecure {
+		builder.Insecure(true)
+	}
+
+	// The dry run goes first, so that the cache and the audit log don't see the requests that
+	// aren't sent:
+	if config.DryRun.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Dry run mode is enabled",
+			"The requests that would create, change or delete something in OCM aren't sent, "+
+				"they are written to the log and answered with a simulated success.",
+		)
+		builder.TransportWrapper(transport.NewDryRun().Wrap)
+	}
+
+	// The cassette goes before the cache, so that replaying doesn't depend on the timing:
+	cassette, err := p.buildCassette(config.Cassette)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid cassette configuration", err.Error())
+		return
+	}
+	if cassette != nil {
+		builder.TransportWrapper(cassette.Wrap)
+	}
+
+	// The cache goes before the retry and rate limit wrappers, so that the responses that it
+	// serves don't wait or count for the limits:
+	cacheTTL := transport.DefaultCacheTTL
+	if !config.CacheTTLInSeconds.IsNull() {
+		cacheTTL = time.Duration(config.CacheTTLInSeconds.ValueInt64()) * time.Second
+	}
+	builder.TransportWrapper(transport.NewCache(cacheTTL).Wrap)
+
+	// The audit log goes before the retry wrapper, so that requests that are retried are written
+	// only once:
+	if auditLogPath, ok := p.getAttrValueOrConfig(config.AuditLogPath, "AUDIT_LOG_PATH", ""); ok && auditLogPath != "" {
+		auditLog, err := transport.NewAuditLog(auditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid audit log configuration", err.Error())
+			return
+		}
+		builder.TransportWrapper(auditLog.Wrap)
+	}
+
+	// The provider applies its own retry policy, so the one built into the SDK is disabled to
+	// avoid multiplying the attempts:
+	retryPolicy, err := buildRetryPolicy(ctx, config.Retry)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid retry configuration", err.Error())
+		return
+	}
+	builder.RetryLimit(0)
+	builder.TransportWrapper(transport.NewRetryWrapper(retryPolicy))
+
+	// The rate limit is applied after the retry wrapper so that every attempt counts:
+	rateLimit, err := buildRateLimit(config.RateLimit)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid rate limit configuration", err.Error())
+		return
+	}
+	builder.TransportWrapper(transport.NewRateLimitWrapper(rateLimit))
+
+	// Each attempt that passes the limits is written to the log with its duration:
+	builder.TransportWrapper(logger.Wrap)
+
+	// The proxy wrapper replaces the transport created by the SDK, so it has to be the last one:
+	proxy, err := buildProxy(ctx, config.OutboundProxy)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid outbound proxy configuration", err.Error())
+		return
+	}
+	builder.TransportWrapper(transport.NewProxyWrapper(proxy))
+	proxyClient, err := transport.NewProxyClient(proxy)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid outbound proxy configuration", err.Error())
+		return
+	}
+
+	// The requests sent to other services also go to the cassette, otherwise replaying would
+	// still need network access and the results would depend on it:
+	if cassette != nil {
+		proxyClient.Transport = cassette.WrapExternal(proxyClient.Transport)
+	}
+
+	defaultTags, err := common.OptionalMap(ctx, config.DefaultTags)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid default tags", err.Error())
+		return
 	}
 
 	// Create the connection:
@@ -200,9 +490,19 @@ func (p *Provider) Configure(ctx context.Context, req tfprovider.ConfigureReques
 		return
 	}
 
-	// Save the connection:
-	resp.DataSourceData = connection
-	resp.ResourceData = connection
+	// Save the connection and the settings shared by the resources:
+	providerData := &common.ProviderData{
+		Connection:       connection,
+		HttpClient:       common.DefaultHttpClient{Client: proxyClient},
+		DefaultTags:      defaultTags,
+		ClusterReadiness: common.NewClusterReadiness(),
+	}
+	if !config.ClusterWaitIntervalInSeconds.IsNull() {
+		providerData.ClusterWaitInterval = time.Duration(config.ClusterWaitIntervalInSeconds.ValueInt64()) *
+			time.Second
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 // Resources returns the resources supported by the provider.
@@ -225,6 +525,8 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 		hcpingress.New,
 		tuningconfigs.New,
 		hcpAutoscaler.New,
+		externalauthprovider.New,
+		breakglasscredential.New,
 	}
 }
 
@@ -244,5 +546,6 @@ func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSour
 		hcpOperatorRoles.New,
 		hcpStsPolicies.New,
 		trusted_ip_addresses.New,
+		breakglasscredential.NewDataSource,
 	}
 }
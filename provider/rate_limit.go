@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	tfpschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/transport"
+)
+
+// RateLimitConfig contains the client side limits applied to the requests sent to OCM.
+type RateLimitConfig struct {
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	Burst                 types.Int64   `tfsdk:"burst"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+}
+
+func rateLimitSchema() map[string]tfpschema.Attribute {
+	return map[string]tfpschema.Attribute{
+		"requests_per_second": tfpschema.Float64Attribute{
+			Description: "Average number of requests per second sent to the OCM API. " +
+				"When not set the rate isn't limited.",
+			Optional: true,
+			Validators: []validator.Float64{
+				float64validator.AtLeast(0.01),
+			},
+		},
+		"burst": tfpschema.Int64Attribute{
+			Description: "Number of requests that can be sent at once before the `requests_per_second` " +
+				"limit applies. The default value is 1.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"max_concurrent_requests": tfpschema.Int64Attribute{
+			Description: "Maximum number of requests waiting for a response from the OCM API at the " +
+				"same time. When not set the number isn't limited.",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+	}
+}
+
+// buildRateLimit converts the rate limit configuration into the limits applied by the
+// transport. Settings that aren't explicitly given don't limit the requests.
+func buildRateLimit(config *RateLimitConfig) (transport.RateLimit, error) {
+	limit := transport.RateLimit{}
+	if config == nil {
+		return limit, nil
+	}
+	if !config.RequestsPerSecond.IsNull() {
+		limit.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	if !config.Burst.IsNull() {
+		limit.Burst = int(config.Burst.ValueInt64())
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		limit.MaxInFlight = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+	return limit, limit.Validate()
+}
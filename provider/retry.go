@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	tfpschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/ocm/transport"
+)
+
+// RetryConfig contains the retry policy applied to all the requests sent to OCM.
+type RetryConfig struct {
+	MaxAttempts             types.Int64   `tfsdk:"max_attempts"`
+	InitialBackoffInSeconds types.Int64   `tfsdk:"initial_backoff_in_seconds"`
+	MaxBackoffInSeconds     types.Int64   `tfsdk:"max_backoff_in_seconds"`
+	Jitter                  types.Float64 `tfsdk:"jitter"`
+	RetryableStatusCodes    types.List    `tfsdk:"retryable_status_codes"`
+}
+
+func retrySchema() map[string]tfpschema.Attribute {
+	return map[string]tfpschema.Attribute{
+		"max_attempts": tfpschema.Int64Attribute{
+			Description: fmt.Sprintf("Total number of times a request is sent, including the first one. "+
+				"The default value is %d.", transport.DefaultRetryMaxAttempts),
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"initial_backoff_in_seconds": tfpschema.Int64Attribute{
+			Description: fmt.Sprintf("Time to wait before the first retry. It is doubled for each following retry. "+
+				"The default value is %d seconds.", int64(transport.DefaultRetryInitialInterval.Seconds())),
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"max_backoff_in_seconds": tfpschema.Int64Attribute{
+			Description: fmt.Sprintf("Maximum time to wait between two attempts, also when the server asks for "+
+				"a longer wait with the `Retry-After` header. The default value is %d seconds.",
+				int64(transport.DefaultRetryMaxInterval.Seconds())),
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"jitter": tfpschema.Float64Attribute{
+			Description: fmt.Sprintf("Factor used to randomize the wait intervals, between 0 and 1. "+
+				"The default value is %v.", transport.DefaultRetryJitter),
+			Optional: true,
+			Validators: []validator.Float64{
+				float64validator.Between(0, 1),
+			},
+		},
+		"retryable_status_codes": tfpschema.ListAttribute{
+			Description: fmt.Sprintf("HTTP status codes that cause a request to be retried. Codes 429 and 503 "+
+				"are retried for any request, the rest only for requests without side effects, like GET. "+
+				"The default value is %v.", transport.DefaultRetryableStatusCodes),
+			ElementType: types.Int64Type,
+			Optional:    true,
+			Validators: []validator.List{
+				listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+			},
+		},
+	}
+}
+
+// buildRetryPolicy converts the retry configuration into a retry policy, using the default
+// values for the settings that aren't explicitly given.
+func buildRetryPolicy(ctx context.Context, config *RetryConfig) (transport.RetryPolicy, error) {
+	policy := transport.DefaultRetryPolicy()
+	if config == nil {
+		return policy, nil
+	}
+	if !config.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(config.MaxAttempts.ValueInt64())
+	}
+	if !config.InitialBackoffInSeconds.IsNull() {
+		policy.InitialInterval = time.Duration(config.InitialBackoffInSeconds.ValueInt64()) * time.Second
+	}
+	if !config.MaxBackoffInSeconds.IsNull() {
+		policy.MaxInterval = time.Duration(config.MaxBackoffInSeconds.ValueInt64()) * time.Second
+	}
+	if !config.Jitter.IsNull() {
+		policy.Jitter = config.Jitter.ValueFloat64()
+	}
+	if !config.RetryableStatusCodes.IsNull() {
+		codes := []int64{}
+		diags := config.RetryableStatusCodes.ElementsAs(ctx, &codes, false)
+		if diags.HasError() {
+			return policy, fmt.Errorf("can't read retryable status codes")
+		}
+		policy.RetryableStatusCodes = make([]int, len(codes))
+		for i, code := range codes {
+			policy.RetryableStatusCodes[i] = int(code)
+		}
+	}
+	return policy, policy.Validate()
+}
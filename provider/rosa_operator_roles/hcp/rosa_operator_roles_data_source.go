@@ -27,7 +27,6 @@ import (
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
@@ -104,7 +103,7 @@ func (s *RosaOperatorRolesDataSource) Configure(ctx context.Context, req datasou
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	s.awsInquiries = connection.ClustersMgmt().V1().AWSInquiries()
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/terraform-redhat/terraform-provider-rhcs/internal/schemacompat"
+)
+
+// schemaSnapshotsDir is the directory that contains the snapshots of the schemas of the released
+// resources and data sources, and the allowlist of the accepted incompatible changes.
+const schemaSnapshotsDir = "testdata/schemas"
+
+// updateSchemaSnapshotsEnv is the environment variable that, when set to 'true', updates the
+// snapshots with the current schemas after checking that the changes are compatible.
+const updateSchemaSnapshotsEnv = "UPDATE_SCHEMA_SNAPSHOTS"
+
+var _ = Describe("Schema compatibility", func() {
+	It("Doesn't break the existing configurations", func() {
+		ctx := context.Background()
+		current, err := schemacompat.Render(ctx, New())
+		Expect(err).NotTo(HaveOccurred())
+		old, err := schemacompat.Load(schemaSnapshotsDir)
+		Expect(err).NotTo(HaveOccurred())
+		allowlist, err := schemacompat.LoadAllowlist(filepath.Join(schemaSnapshotsDir, "allowlist.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		changes := schemacompat.Check(old, current, allowlist)
+		messages := make([]string, len(changes))
+		for i, change := range changes {
+			messages[i] = change.String()
+		}
+		Expect(messages).To(BeEmpty(),
+			"Backward incompatible schema changes. Avoid them, or increase the version of the "+
+				"resource schema and add a state upgrader, or add them to '%s/allowlist.json' with "+
+				"the reason.", schemaSnapshotsDir)
+
+		if os.Getenv(updateSchemaSnapshotsEnv) == "true" {
+			Expect(schemacompat.Write(schemaSnapshotsDir, current)).To(Succeed())
+			return
+		}
+		outdated, err := schemacompat.Outdated(old, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outdated).To(BeEmpty(),
+			"The schema snapshots are outdated for: %s. Run 'make schema-snapshots' and commit the "+
+				"changes.", strings.Join(outdated, ", "))
+	})
+})
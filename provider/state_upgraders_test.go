@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("State upgraders", func() {
+	var ctx context.Context
+	var server tfprotov6.ProviderServer
+	var schemas map[string]*tfprotov6.Schema
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = providerserver.NewProtocol6(New())()
+		response, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		schemas = response.ResourceSchemas
+	})
+
+	// upgrade upgrades the given JSON state written with the given version of the schema of the
+	// resource, and returns the upgraded attributes.
+	upgrade := func(typeName string, version int64, json string) map[string]tftypes.Value {
+		response, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+			TypeName: typeName,
+			Version:  version,
+			RawState: &tfprotov6.RawState{JSON: []byte(json)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, diagnostic := range response.Diagnostics {
+			Expect(diagnostic.Severity).NotTo(Equal(tfprotov6.DiagnosticSeverityError),
+				"%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+		value, err := response.UpgradedState.Unmarshal(schemas[typeName].ValueType())
+		Expect(err).NotTo(HaveOccurred())
+		attributes := map[string]tftypes.Value{}
+		Expect(value.As(&attributes)).To(Succeed())
+		return attributes
+	}
+
+	stringMap := func(value tftypes.Value) map[string]string {
+		values := map[string]tftypes.Value{}
+		Expect(value.As(&values)).To(Succeed())
+		result := map[string]string{}
+		for key, value := range values {
+			var text string
+			Expect(value.As(&text)).To(Succeed())
+			result[key] = text
+		}
+		return result
+	}
+
+	It("Upgrades every prior version of every resource", func() {
+		for typeName, schema := range schemas {
+			for version := int64(0); version < schema.Version; version++ {
+				By(typeName)
+				upgrade(typeName, version, `{"id": "123"}`)
+			}
+		}
+	})
+
+	It("Copies the tags of the legacy cluster states", func() {
+		for _, typeName := range []string{"rhcs_cluster_rosa_classic", "rhcs_cluster_rosa_hcp"} {
+			attributes := upgrade(typeName, 0, `{"id": "123", "tags": {"a": "b"}, "removed": true}`)
+			Expect(stringMap(attributes["tags_all"])).To(Equal(map[string]string{"a": "b"}))
+		}
+	})
+
+	It("Copies the tags of the legacy machine pool states", func() {
+		attributes := upgrade("rhcs_machine_pool", 0, `{"id": "123", "aws_tags": {"a": "b"}}`)
+		Expect(stringMap(attributes["aws_tags_all"])).To(Equal(map[string]string{"a": "b"}))
+
+		attributes = upgrade("rhcs_hcp_machine_pool", 0, `{"id": "123", "aws_node_pool": {"tags": {"a": "b"}}}`)
+		awsNodePool := map[string]tftypes.Value{}
+		Expect(attributes["aws_node_pool"].As(&awsNodePool)).To(Succeed())
+		Expect(stringMap(awsNodePool["tags_all"])).To(Equal(map[string]string{"a": "b"}))
+	})
+
+	It("Converts the single user of the legacy htpasswd identity providers", func() {
+		attributes := upgrade("rhcs_identity_provider", 0,
+			`{"id": "123", "htpasswd": {"username": "my-user", "password": "my-password"}}`)
+		htpasswd := map[string]tftypes.Value{}
+		Expect(attributes["htpasswd"].As(&htpasswd)).To(Succeed())
+		users := []tftypes.Value{}
+		Expect(htpasswd["users"].As(&users)).To(Succeed())
+		Expect(users).To(HaveLen(1))
+		Expect(stringMap(users[0])).To(Equal(map[string]string{
+			"username": "my-user",
+			"password": "my-password",
+		}))
+	})
+})
@@ -23,8 +23,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type TrustedIpsDataSource struct {
@@ -77,14 +78,15 @@ func (s *TrustedIpsDataSource) Configure(ctx context.Context, req datasource.Con
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connection, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	// Get the collection of cloud providers:
 	s.collection = connection.ClustersMgmt().V1().TrustedIPAddresses()
@@ -103,9 +105,11 @@ func (s *TrustedIpsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Can't list trusted IP addresses",
 				err.Error(),
+				err,
 			)
 			return
 		}
@@ -18,7 +18,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
@@ -80,16 +79,18 @@ func (r *TuningConfigResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	connection, ok := req.ProviderData.(*sdk.Connection)
+	providerData, ok := req.ProviderData.(*common.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.Connaction, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *common.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
+	connection := providerData.Connection
 
 	r.collection = connection.ClustersMgmt().V1().Clusters()
-	r.clusterWait = common.NewClusterWait(r.collection, connection)
+	r.clusterWait = common.NewClusterWait(r.collection, providerData.ClusterWaitInterval,
+		providerData.ClusterReadiness)
 }
 
 func (r *TuningConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -101,25 +102,29 @@ func (r *TuningConfigResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Wait till the cluster is ready:
-	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), 60)
+	_, err := r.clusterWait.WaitForClusterToBeReady(ctx, plan.Cluster.ValueString(), common.DefaultTimeout)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot poll cluster state",
 			fmt.Sprintf(
 				"Cannot poll state of cluster with identifier '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
 	err = r.createTuningConfig(ctx, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Failed building cluster tuning config",
 			fmt.Sprintf(
 				"Failed building tuning config for cluster '%s': %v",
 				plan.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -138,13 +143,15 @@ func (r *TuningConfigResource) Read(ctx context.Context, req resource.ReadReques
 
 	err := r.populateTuningConfig(ctx, state)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Failed getting cluster tuning config",
 			fmt.Sprintf(
 				"Failed getting tuning config with"+
 					" identifier '%s' for cluster '%s': %v",
 				state.Id.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 		return
 	}
@@ -182,13 +189,15 @@ func (r *TuningConfigResource) Update(ctx context.Context, req resource.UpdateRe
 
 	err := r.updateTuningConfig(ctx, state, plan, plan.Cluster.ValueString(), r.collection)
 	if err != nil {
-		diags.AddError(
+		common.AddError(
+			&diags,
 			"Failed to update tuning config",
 			fmt.Sprintf(
 				"Failed to update tuning config with"+
 					" identifier '%s' for cluster '%s': %v",
 				state.Id.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 	}
 
@@ -224,13 +233,15 @@ func (r *TuningConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 		TuningConfig(state.Id.ValueString())
 	_, err := resource.Delete().SendContext(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		common.AddError(
+			&resp.Diagnostics,
 			"Cannot delete tuning config",
 			fmt.Sprintf(
 				"Cannot delete tuning config with identifier '%s' for "+
 					"cluster '%s': %v",
 				state.Id.ValueString(), state.Cluster.ValueString(), err,
 			),
+			err,
 		)
 	}
 	// Remove the state:
@@ -22,8 +22,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/terraform-redhat/terraform-provider-rhcs/provider/common"
 )
 
 type VersionsDataSource struct {
@@ -91,7 +92,7 @@ func (s *VersionsDataSource) Configure(ctx context.Context, req datasource.Confi
 	}
 
 	// Cast the provider data to the specific implementation:
-	connection := req.ProviderData.(*sdk.Connection)
+	connection := req.ProviderData.(*common.ProviderData).Connection
 
 	// Get the collection of cloud providers:
 	s.collection = connection.ClustersMgmt().V1().Versions()
@@ -122,9 +123,11 @@ func (s *VersionsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	for {
 		listResponse, err := listRequest.SendContext(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			common.AddError(
+				&resp.Diagnostics,
 				"Can't list versions",
 				err.Error(),
+				err,
 			)
 			return
 		}